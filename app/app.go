@@ -1,7 +1,7 @@
 package app
 
 /*
-#cgo LDFLAGS: -L../renderer/lib/Release -lrenderer -lglfw3 -lgdi32 -static
+#cgo LDFLAGS: -L../renderer/lib/Release -lglfw3 -lgdi32 -static
 #include "../renderer/include/renderer.h"
 #include <stdlib.h>
 */
@@ -11,10 +11,11 @@ import (
 	"log"
 	"runtime"
 	"sort"
-	"strings"
-	"unsafe"
 
+	"github.com/aj-2000/mogi/atlas"
 	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/geometry"
+	"github.com/aj-2000/mogi/internal/bidi"
 
 	"github.com/aj-2000/mogi/internal/ui"
 	"github.com/aj-2000/mogi/math"
@@ -28,6 +29,13 @@ type App struct {
 	lastFrameTime float32
 	fps           float32
 	le            *ui.LayoutEngine
+	fonts         map[ui.Font]string
+	keyboard      *keyboard
+	overlays      []overlay
+	modals        []*modalEntry
+	nextModalID   ModalID
+	pointer       math.Vec2f32 // cursor as seen by the tree being handled
+	theme         ui.Theme
 }
 
 func (app *App) Container() *ui.Container {
@@ -50,6 +58,94 @@ func (app *App) Table() *ui.Table {
 	return ui.NewTable()
 }
 
+func (app *App) Icon(name string) *ui.Icon {
+	return ui.NewIcon(name)
+}
+
+func (app *App) RichText(spans ...*ui.Span) *ui.RichText {
+	return ui.NewRichText(spans...)
+}
+
+func (app *App) Markdown(source string) *ui.Markdown {
+	return ui.NewMarkdown(source)
+}
+
+func (app *App) Checkbox(label string) *ui.Checkbox {
+	return ui.NewCheckbox(label)
+}
+
+func (app *App) RadioGroup(options ...string) *ui.RadioGroup {
+	return ui.NewRadioGroup(options...)
+}
+
+func (app *App) Switch(label string) *ui.Switch {
+	return ui.NewSwitch(label)
+}
+
+func (app *App) Slider(minValue, maxValue float32) *ui.Slider {
+	return ui.NewSlider(minValue, maxValue)
+}
+
+func (app *App) RangeSlider(minValue, maxValue float32) *ui.RangeSlider {
+	return ui.NewRangeSlider(minValue, maxValue)
+}
+
+func (app *App) Select(options ...string) *ui.Select {
+	return ui.NewSelect(options...)
+}
+
+func (app *App) ComboBox(options ...string) *ui.Select {
+	return ui.NewComboBox(options...)
+}
+
+func (app *App) Modal(title string, content ...ui.IComponent) *ui.Modal {
+	return ui.NewModal(title, content...)
+}
+
+func (app *App) Tabs(tabs ...*ui.Tab) *ui.Tabs {
+	return ui.NewTabs(tabs...)
+}
+
+func (app *App) TreeView(roots ...*ui.TreeNode) *ui.TreeView {
+	return ui.NewTreeView(roots...)
+}
+
+func (app *App) Chart(chartType ui.ChartType, series ...ui.ChartSeries) *ui.Chart {
+	return ui.NewChart(chartType, series...)
+}
+
+func (app *App) Sparkline(values ...float32) *ui.Chart {
+	return ui.NewSparkline(values...)
+}
+
+func (app *App) Canvas(draw func(p *ui.Painter, size math.Vec2f32)) *ui.Canvas {
+	return ui.NewCanvas(draw)
+}
+
+func (app *App) ProgressBar(value float32) *ui.ProgressBar {
+	return ui.NewProgressBar(value)
+}
+
+func (app *App) Spinner() *ui.Spinner {
+	return ui.NewSpinner()
+}
+
+func (app *App) Skeleton(shape ui.SkeletonShape) *ui.Skeleton {
+	return ui.NewSkeleton(shape)
+}
+
+func (app *App) VirtualList(count int, estimateHeight func(i int) float32, build func(i int) ui.IComponent) *ui.VirtualList {
+	return ui.NewVirtualList(count, estimateHeight, build)
+}
+
+func (app *App) VirtualGrid(count int, cellSize math.Vec2f32, build func(i int) ui.IComponent) *ui.VirtualGrid {
+	return ui.NewVirtualGrid(count, cellSize, build)
+}
+
+func (app *App) Tooltip(text string) *ui.Tooltip {
+	return ui.NewTooltip(text)
+}
+
 func (app *App) Run(f func(app *App) ui.IComponent) {
 	if app.renderer == nil {
 		log.Fatalln("Renderer is not initialized")
@@ -68,6 +164,8 @@ func (app *App) Run(f func(app *App) ui.IComponent) {
 
 		app.totalTime += float64(app.deltaTime)
 		app.totalFrames++
+		app.pollInput()
+		ui.UseTheme(app.theme)
 		root := f(app)
 		root = app.le.ConvertDerivedComponentToPrimitivesRecursive(root)
 		app.le.AssignIDsRecursive(root)
@@ -75,14 +173,28 @@ func (app *App) Run(f func(app *App) ui.IComponent) {
 			// should not run on the first frame
 			app.le.CopyStateToComponentsRecursive(root)
 		}
+		ui.AdvanceAnimations(root, app.deltaTime)
 		app.le.Layout(root, math.Vec2f32{}, windowSize)
-		// Logic that requires state from the previous frame
+		app.le.AdvanceTransitions(root, app.deltaTime)
+		app.overlays = app.collectPopups(root, windowSize, nil)
+		app.overlays = app.layoutModals(windowSize, app.overlays)
+		// Logic that requires state from the previous frame. Overlays see
+		// the pointer first and hide it from the tree underneath.
+		app.handleOverlayInput()
 		HandleOnClicks(app, root)
+		if !app.handleModalKeys() {
+			HandleKeyboard(app, app.keyboardRoot(root))
+		}
 		app.le.CopyStateFromComponentsRecursive(root)
+		for _, o := range app.overlays {
+			app.le.CopyStateFromComponentsRecursive(o.root)
+		}
+		app.showTooltip(root, windowSize)
 
-		app.renderer.clear()
+		app.renderer.clear(app.theme.Background)
 		componentRenderer := &ComponentRenderer{Component: root}
 		componentRenderer.Render(app)
+		app.renderOverlays()
 		app.renderer.present()
 		app.renderer.handleEvents()
 		app.le.EndLayout()
@@ -90,11 +202,7 @@ func (app *App) Run(f func(app *App) ui.IComponent) {
 }
 
 func (app *App) CalculateTextWidth(font *C.FontData, text string) float32 {
-	cstr := C.CString(text)
-	defer C.free(unsafe.Pointer(cstr))
-
-	width := C.calculate_text_width(font, cstr)
-	return float32(width)
+	return app.renderer.calculateTextWidth(font, text)
 }
 
 // TODO: it's not correct for some reason
@@ -106,6 +214,11 @@ func (app *App) GetFPS() float32 {
 	return 1.0 / deltaTime
 }
 
+// GetDeltaTime returns how long the last frame took, in seconds.
+func (app *App) GetDeltaTime() float32 {
+	return app.deltaTime
+}
+
 func (app *App) GetAvgFPS() float32 {
 	if app.totalFrames == 0 {
 		return 0
@@ -128,21 +241,73 @@ func (app *App) GetWindowSize() math.Vec2f32 {
 	return app.renderer.getWindowSize()
 }
 
+// LoadAtlas loads an atlas manifest written by cmd/atlas (or atlas.Atlas.Save)
+// and makes its entries available to Icon and Image.SetSprite by name.
+// Loading the same manifest again replaces the pages loaded from it, so an
+// atlas rebuilt in place is picked up.
+func (app *App) LoadAtlas(manifestPath string) error {
+	a, err := atlas.Load(manifestPath)
+	if err != nil {
+		return err
+	}
+	return app.renderer.spriteManager.add(app.renderer.textureManager, manifestPath, a)
+}
+
+// AddAtlas registers an atlas built at runtime with atlas.Builder.
+func (app *App) AddAtlas(name string, a *atlas.Atlas) error {
+	return app.renderer.spriteManager.add(app.renderer.textureManager, "atlas:"+name, a)
+}
+
 // TODO: should we expose c font data to public?
 func (app *App) LoadFont(path string, size float32) (*FontData, error) {
 	return app.renderer.fontManager.load(path, size)
 }
 
+// RegisterFont maps a font family and weight to a TTF file, so that
+// RichText spans can select it with ui.Font.
+func (app *App) RegisterFont(font ui.Font, path string) {
+	app.fonts[font] = path
+}
+
+// resolveFont returns the TTF path for font, falling back to the upright
+// face, then the regular weight of the family and then the default font.
+func (app *App) resolveFont(font ui.Font) string {
+	if path, ok := app.fonts[font]; ok {
+		return path
+	}
+	if path, ok := app.fonts[ui.Font{Family: font.Family, Weight: font.Weight}]; ok {
+		return path
+	}
+	if path, ok := app.fonts[ui.Font{Family: font.Family}]; ok {
+		return path
+	}
+	return defaultFontPath
+}
+
 func NewApp(width, height int, title string) *App {
 	runtime.LockOSThread()
 	// TODO: make it cleaner
 	var app *App
 	app = &App{
 		le: ui.NewLayoutEngine(func(s string, fontSize float32) float32 {
-			font, _ := app.LoadFont("JetBrainsMonoNL-Regular.ttf", fontSize)
+			font, _ := app.LoadFont(defaultFontPath, fontSize)
 			return app.CalculateTextWidth(font, s)
 		}),
 		renderer: newRenderer(width, height, title),
+		fonts:    make(map[ui.Font]string),
+		keyboard: newKeyboard(),
+		theme:    ui.DarkTheme(),
+	}
+	app.le.MeasureText = func(f ui.Font, s string, fontSize float32) float32 {
+		font, _ := app.LoadFont(app.resolveFont(f), fontSize)
+		return app.CalculateTextWidth(font, s)
+	}
+	app.le.FontAscent = func(f ui.Font, fontSize float32) float32 {
+		font, err := app.LoadFont(app.resolveFont(f), fontSize)
+		if err != nil {
+			return fontSize * 0.8
+		}
+		return float32(font.ascent)
 	}
 	app.SetVSync(true)
 	return app
@@ -159,7 +324,7 @@ func HandleOnClicks(app *App, component ui.IComponent) {
 
 	// Get cursor state once per component
 	// TODO: move it to wrapper?
-	cursorPos := app.renderer.getMousePos()
+	cursorPos := app.pointer
 	mouseDown := app.renderer.IsMousePressed(0)      // held this frame
 	mouseReleased := app.renderer.IsMouseReleased(0) // just went up this frame
 
@@ -186,10 +351,177 @@ func HandleOnClicks(app *App, component ui.IComponent) {
 		}
 	}
 
+	// Clickable spans (links) inside rich text behave like small buttons
+	if rt, ok := component.(*ui.RichText); ok {
+		origin := rt.AbsolutePos()
+		origin.Add(rt.Padding()).Add(rt.Border())
+		local := rt.LocalPoint(cursorPos)
+		local.Sub(origin)
+
+		hovered := -1
+		if rt.IsPointInsideComponent(cursorPos) {
+			hovered = rt.SpanAt(local)
+		}
+		rt.HoveredSpan = hovered
+
+		if hovered >= 0 && rt.Spans[hovered].OnClick != nil && mouseDown {
+			rt.PressedSpan = hovered
+		}
+		if rt.PressedSpan >= 0 && rt.PressedSpan != hovered {
+			rt.PressedSpan = -1
+		}
+		if rt.PressedSpan >= 0 && mouseReleased {
+			span := rt.Spans[rt.PressedSpan]
+			rt.PressedSpan = -1
+			span.OnClick(span)
+		}
+	}
+
+	// Checkboxes and switches toggle like buttons and take focus on press
+	switch c := component.(type) {
+	case *ui.Checkbox:
+		c.IsMouseOver, c.IsPressed = app.handleToggleClick(c, c.IsPressed, c.Disabled, cursorPos, mouseDown, mouseReleased, c.Toggle)
+	case *ui.Switch:
+		c.IsMouseOver, c.IsPressed = app.handleToggleClick(c, c.IsPressed, c.Disabled, cursorPos, mouseDown, mouseReleased, c.Toggle)
+	case *ui.RadioGroup:
+		hovered := -1
+		if !c.Disabled && c.IsPointInsideComponent(cursorPos) {
+			local := c.LocalPoint(cursorPos)
+			local.Sub(c.AbsolutePos()).Sub(c.Padding())
+			hovered = c.OptionAt(local)
+		}
+		c.HoveredOption = hovered
+		if hovered >= 0 && mouseDown {
+			c.PressedOption = hovered
+			app.focus(c)
+		}
+		if c.PressedOption >= 0 && c.PressedOption != hovered {
+			c.PressedOption = -1
+		}
+		if c.PressedOption >= 0 && mouseReleased {
+			option := c.PressedOption
+			c.PressedOption = -1
+			c.Select(option)
+		}
+	case *ui.Select:
+		c.IsMouseOver, c.IsPressed = app.handleToggleClick(c, c.IsPressed, c.Disabled, cursorPos, mouseDown, mouseReleased, func() {
+			c.SetOpen(!c.Open)
+		})
+		// A press outside the select and its popup closes it.
+		if c.Open && app.mouseJustPressed() && !c.IsMouseOver {
+			if popup := app.popupOf(c); popup == nil || !popup.root.IsPointInsideComponent(app.renderer.getMousePos()) {
+				c.Close()
+			}
+		}
+	case *ui.Slider:
+		geometry := c.Geometry()
+		origin, _ := contentBox(c)
+		local := c.LocalPoint(cursorPos)
+		local.Sub(origin)
+		over := !c.Disabled && c.IsPointInsideComponent(cursorPos) && geometry.OnTrack(local)
+		// Once dragging, the slider keeps the mouse until the button is
+		// released, even when the cursor leaves the track.
+		if over && app.mouseJustPressed() {
+			c.IsDragging = true
+			app.focus(c)
+		}
+		if c.IsDragging {
+			if mouseDown {
+				c.Slide(geometry.ValueAt(local))
+			} else {
+				c.IsDragging = false
+			}
+		}
+		c.IsMouseOver = over || c.IsDragging
+	case *ui.RangeSlider:
+		geometry := c.Geometry()
+		origin, content := contentBox(c)
+		local := c.LocalPoint(cursorPos)
+		local.Sub(origin)
+		over := !c.Disabled && c.IsPointInsideComponent(cursorPos) && geometry.OnTrack(local)
+		hovered := -1
+		if over {
+			hovered = c.ThumbAt(local, content)
+		}
+		c.HoveredThumb = hovered
+		if over && app.mouseJustPressed() {
+			// Clicking the track jumps the nearest thumb to the cursor.
+			thumb := hovered
+			if thumb < 0 {
+				thumb = c.NearestThumb(geometry.ValueAt(local))
+			}
+			c.DraggedThumb = thumb
+			c.ActiveThumb = thumb
+			app.focus(c)
+		}
+		if c.DraggedThumb >= 0 {
+			if mouseDown {
+				v := geometry.ValueAt(local)
+				if c.Low == c.High {
+					// Overlapping thumbs separate in the direction of the drag.
+					c.DraggedThumb = c.NearestThumb(v)
+					c.ActiveThumb = c.DraggedThumb
+				}
+				c.SlideThumb(c.DraggedThumb, v)
+			} else {
+				c.DraggedThumb = -1
+			}
+		}
+	case *ui.Tabs:
+		app.handleTabsClick(c, cursorPos, mouseDown, mouseReleased)
+	case *ui.TreeView:
+		app.handleTreeClick(c, cursorPos, mouseDown)
+	case *ui.Table:
+		app.handleTableClick(c, cursorPos, mouseDown)
+	case *ui.Chart:
+		app.handleChartHover(c, cursorPos)
+	case scroller:
+		app.handleScrollbar(c, cursorPos, mouseDown)
+	}
+
+	// Children scrolled out of a clipping parent cannot be clicked.
+	if clipPos, clipSize, ok := clipBox(component); ok && !(math.Rect{Pos: clipPos, Size: clipSize}).Contains(component.LocalPoint(cursorPos)) {
+		app.pointer = offscreen
+		defer func() { app.pointer = cursorPos }()
+	}
+
 	// recurse into children
 	for _, child := range component.Children() {
 		HandleOnClicks(app, child)
 	}
+
+	// The innermost scroller under the pointer takes the wheel, so children
+	// go first.
+	if s, ok := component.(scroller); ok {
+		app.handleWheel(s)
+	}
+}
+
+// contentBox returns the absolute origin and the size of comp's content box,
+// inside padding and border.
+func contentBox(comp ui.IComponent) (math.Vec2f32, math.Vec2f32) {
+	inset := comp.Padding()
+	inset.Add(comp.Border())
+	box := math.Rect{Pos: comp.AbsolutePos(), Size: comp.Size()}.Inset(inset.X, inset.Y, inset.X, inset.Y)
+	return box.Pos, box.Size
+}
+
+// handleToggleClick applies the button press pattern to a two-state input and
+// returns its new hover and pressed flags.
+func (app *App) handleToggleClick(comp ui.IComponent, pressed, disabled bool, cursorPos math.Vec2f32, mouseDown, mouseReleased bool, toggle func()) (bool, bool) {
+	over := !disabled && comp.IsPointInsideComponent(cursorPos)
+	if over && mouseDown {
+		pressed = true
+		app.focus(comp)
+	}
+	if pressed && !over {
+		pressed = false
+	}
+	if pressed && mouseReleased {
+		pressed = false
+		toggle()
+	}
+	return over, pressed
 }
 
 func (app *App) GetMousePos() math.Vec2f32 {
@@ -203,6 +535,11 @@ const (
 	RenderCommandDrawRectangle
 	RenderCommandDrawText
 	RenderCommandDrawTexture
+	RenderCommandDrawLine
+	RenderCommandDrawPolyline
+	RenderCommandDrawPolygon
+	RenderCommandDrawCircle
+	RenderCommandDrawTriangles
 )
 
 func (r RenderCommandKind) String() string {
@@ -213,6 +550,16 @@ func (r RenderCommandKind) String() string {
 		return "RenderCommandDrawText"
 	case RenderCommandDrawTexture:
 		return "RenderCommandDrawTexture"
+	case RenderCommandDrawLine:
+		return "RenderCommandDrawLine"
+	case RenderCommandDrawPolyline:
+		return "RenderCommandDrawPolyline"
+	case RenderCommandDrawPolygon:
+		return "RenderCommandDrawPolygon"
+	case RenderCommandDrawCircle:
+		return "RenderCommandDrawCircle"
+	case RenderCommandDrawTriangles:
+		return "RenderCommandDrawTriangles"
 	default:
 		return "RenderCommandNone"
 	}
@@ -224,6 +571,7 @@ type RenderCommand struct {
 	Size            math.Vec2f32
 	Color           color.RGBA
 	Font            *C.FontData
+	FontPath        string
 	Text            string
 	BorderWidth     math.Vec2f32
 	BorderColor     color.RGBA
@@ -234,7 +582,33 @@ type RenderCommand struct {
 	PressedColor    color.RGBA
 	FontSize        float32
 	Path            string
+	Sprite          string
 	Display         ui.Display
+	End             math.Vec2f32 // line end point; Pos is the start
+	Thickness       float32
+	// Points are the vertices of a polyline (drawn in Color with
+	// Thickness) or of a convex polygon (filled with Color).
+	Points []math.Vec2f32
+	// Radius is the radius of a circle around Pos, filled with Color.
+	Radius float32
+	// Vertices are a list of triangles filled with Color, faded by each
+	// vertex's alpha.
+	Vertices []geometry.Vertex
+	// Shadows are cast by a rectangle, behind it or (inset) inside its
+	// border, and Gradient fills it instead of BackgroundColor.
+	Shadows  []ui.BoxShadow
+	Gradient *color.Gradient
+	// Clip restricts the command to the rectangle at ClipPos of ClipSize.
+	Clip     bool
+	ClipPos  math.Vec2f32
+	ClipSize math.Vec2f32
+	// Transform, if set, maps the command from its component's laid out box
+	// to the screen. ClipTransform does the same for the clip rectangle,
+	// which may belong to an ancestor transformed differently.
+	Transform     *math.Mat3
+	ClipTransform *math.Mat3
+	// Masks clip the command further, outermost first.
+	Masks []clipMask
 }
 
 type RenderCommandArray = []RenderCommand
@@ -267,90 +641,90 @@ func (cr *ComponentRenderer) GenerateRenderCommands(app *App) RenderCommandArray
 			ZIndex:          zIndex,
 			Display:         comp.Display(),
 			BackgroundColor: backgroundColor,
+			Shadows:         comp.Shadows(),
+			Gradient:        comp.Gradient(),
 		})
 
 	case *ui.Text:
-		// TODO: style‐driven values (you can pull these from comp.Style instead)
-		fontSize := comp.FontSize
-		paddingAndBorderX := comp.Padding().X + borderWidth.X
-		lineHeight := fontSize
-
-		if comp.Wrapped {
-			// 1) figure out max width for each line
-			// TODO: fix this bug (why we need to subtract 20?)
-			maxLineWidth := size.X - 2*paddingAndBorderX
-
-			// 2) break into words and greedily fill lines
-			words := strings.Fields(comp.Content)
-			var lines []string
-			var curr strings.Builder
-
-			for _, w := range words {
-				test := curr.String()
-				if test != "" {
-					test += " " + w
-				} else {
-					test = w
-				}
-
-				if app.le.CalculateTextWidth(test, fontSize) <= maxLineWidth {
-					if curr.Len() > 0 {
-						curr.WriteString(" ")
-					}
-					curr.WriteString(w)
-				} else {
-					lines = append(lines, curr.String())
-					curr.Reset()
-					curr.WriteString(w)
-				}
-			}
-			if curr.Len() > 0 {
-				lines = append(lines, curr.String())
-			}
-
-			// 3) compute wrapped block size
-			var widest float32
-			for _, line := range lines {
-				w := app.le.CalculateTextWidth(line, fontSize)
-				if w > widest {
-					widest = w
-				}
-			}
+		inset := comp.Padding()
+		inset.Add(borderWidth)
+		contentSize := size
+		contentSize.Sub(*inset.Clone().Scale(2))
+
+		// one draw-text command per laid-out line (or word, when justified)
+		for _, run := range app.le.LayoutText(comp, contentSize) {
+			runPos := pos
+			runPos.Add(inset).Add(run.Pos)
+			commands = append(commands, RenderCommand{
+				Kind:     RenderCommandDrawText,
+				Text:     run.Text,
+				Color:    comp.Color,
+				Pos:      runPos,
+				Display:  comp.Display(),
+				FontSize: comp.FontSize,
+				ZIndex:   zIndex,
+			})
+		}
 
-			// 4) emit one draw‐text command per line
-			for i, line := range lines {
-				linePos := pos.Clone().Add(math.Vec2f32{
-					Y: float32(i) * lineHeight,
-				})
+	case *ui.RichText:
+		origin := pos
+		origin.Add(comp.Padding()).Add(borderWidth)
+		for _, f := range comp.Fragments() {
+			span := comp.Spans[f.Span]
+			fontSize := comp.SpanFontSize(f.Span)
+			textColor := comp.SpanColor(f.Span)
+			fragPos := origin
+			fragPos.Add(f.Pos)
+
+			if span.Background.A > 0 {
 				commands = append(commands, RenderCommand{
-					Kind:     RenderCommandDrawText,
-					Text:     line,
-					Color:    comp.Color,
-					Pos:      *linePos,
-					Display:  comp.Display(),
-					FontSize: fontSize,
-					ZIndex:   zIndex,
+					Kind:            RenderCommandDrawRectangle,
+					Pos:             fragPos,
+					Size:            f.Size,
+					BackgroundColor: span.Background,
+					Display:         comp.Display(),
+					ZIndex:          zIndex,
 				})
 			}
-
-		} else {
 			commands = append(commands, RenderCommand{
 				Kind:     RenderCommandDrawText,
-				Text:     comp.Content,
-				Color:    comp.Color,
-				Pos:      pos,
+				Text:     f.Text,
+				Color:    textColor,
+				Pos:      fragPos,
+				FontPath: app.resolveFont(span.Font),
 				Display:  comp.Display(),
 				FontSize: fontSize,
-				ZIndex:   zIndex,
+				ZIndex:   zIndex + 1,
 			})
+
+			// decorations are thin rectangles relative to the baseline
+			thickness := max(1, fontSize/16)
+			ascent := f.Baseline - f.Pos.Y
+			hoveredLink := span.OnClick != nil && comp.HoveredSpan == f.Span
+			if span.Underline || hoveredLink {
+				commands = append(commands, RenderCommand{
+					Kind:            RenderCommandDrawRectangle,
+					Pos:             math.Vec2f32{X: fragPos.X, Y: origin.Y + f.Baseline + thickness},
+					Size:            math.Vec2f32{X: f.Size.X, Y: thickness},
+					BackgroundColor: textColor,
+					Display:         comp.Display(),
+					ZIndex:          zIndex + 1,
+				})
+			}
+			if span.Strikethrough {
+				commands = append(commands, RenderCommand{
+					Kind:            RenderCommandDrawRectangle,
+					Pos:             math.Vec2f32{X: fragPos.X, Y: origin.Y + f.Baseline - ascent*0.35},
+					Size:            math.Vec2f32{X: f.Size.X, Y: thickness},
+					BackgroundColor: textColor,
+					Display:         comp.Display(),
+					ZIndex:          zIndex + 1,
+				})
+			}
 		}
 
 	case *ui.Button:
-		if comp.IsPressed {
-			backgroundColor = comp.PressedColor
-		} else if comp.IsMouseOver {
-			backgroundColor = comp.HoverColor
-		}
+		backgroundColor = comp.Fill()
 		buttonCommand := RenderCommand{
 			Kind:            RenderCommandDrawRectangle,
 			Color:           backgroundColor,
@@ -365,9 +739,14 @@ func (cr *ComponentRenderer) GenerateRenderCommands(app *App) RenderCommandArray
 			Display:         comp.Display(),
 			BorderRadius:    borderRadius,
 			BackgroundColor: backgroundColor,
+			Shadows:         comp.Shadows(),
+		}
+		if comp.Gradient() != nil && !comp.IsMouseOver && !comp.IsPressed {
+			// The hover and pressed colors show over a gradient.
+			buttonCommand.Gradient = comp.Gradient()
 		}
 		commands = append(commands, buttonCommand)
-		font, err := app.LoadFont("JetBrainsMonoNL-Regular.ttf", comp.FontSize())
+		font, err := app.LoadFont(defaultFontPath, comp.FontSize())
 		if err != nil {
 			log.Printf("Failed to load font during render: %v", err)
 			return nil
@@ -377,7 +756,7 @@ func (cr *ComponentRenderer) GenerateRenderCommands(app *App) RenderCommandArray
 		textPos := *pos.Add(*offset)
 		commands = append(commands, RenderCommand{
 			Kind:     RenderCommandDrawText,
-			Text:     comp.Label,
+			Text:     bidi.Visual(comp.Label, bidi.Auto),
 			Color:    comp.TextColor,
 			Pos:      textPos,
 			Display:  comp.Display(),
@@ -389,19 +768,97 @@ func (cr *ComponentRenderer) GenerateRenderCommands(app *App) RenderCommandArray
 		imageCommand := RenderCommand{
 			Kind:    RenderCommandDrawTexture,
 			Path:    comp.Path,
+			Sprite:  comp.Sprite,
+			Color:   color.White,
 			Pos:     pos,
 			Display: comp.Display(),
 			Size:    size,
 			ZIndex:  zIndex,
 		}
 		commands = append(commands, imageCommand)
+
+	case *ui.Icon:
+		commands = append(commands, RenderCommand{
+			Kind:    RenderCommandDrawTexture,
+			Sprite:  comp.Name,
+			Color:   comp.Color,
+			Pos:     pos,
+			Display: comp.Display(),
+			Size:    size,
+			ZIndex:  zIndex,
+		})
+
+	case *ui.Checkbox:
+		commands = append(commands, app.checkboxCommands(comp, pos, size, zIndex)...)
+
+	case *ui.Switch:
+		commands = append(commands, app.switchCommands(comp, pos, size, zIndex)...)
+
+	case *ui.RadioGroup:
+		commands = append(commands, app.radioGroupCommands(comp, pos, size, zIndex)...)
+
+	case *ui.Slider:
+		commands = append(commands, app.sliderCommands(comp, zIndex)...)
+
+	case *ui.RangeSlider:
+		commands = append(commands, app.rangeSliderCommands(comp, zIndex)...)
+
+	case *ui.Select:
+		commands = append(commands, app.selectCommands(comp, zIndex)...)
+
+	case *ui.Tabs:
+		commands = append(commands, app.tabsCommands(comp, zIndex)...)
+
+	case *ui.TreeView:
+		commands = append(commands, app.treeViewCommands(comp, zIndex)...)
+
+	case *ui.Table:
+		commands = append(commands, app.tableCommands(comp, zIndex)...)
+
+	case *ui.Chart:
+		commands = append(commands, app.chartCommands(comp, zIndex)...)
+
+	case *ui.Canvas:
+		commands = append(commands, canvasCommands(comp, zIndex)...)
+
+	case *ui.ProgressBar:
+		commands = append(commands, app.progressBarCommands(comp, zIndex)...)
+
+	case *ui.Spinner:
+		commands = append(commands, spinnerCommands(comp, zIndex)...)
+
+	case *ui.Skeleton:
+		commands = append(commands, skeletonCommands(comp, zIndex)...)
+
+	case *ui.VirtualList, *ui.VirtualGrid:
+		commands = append(commands, RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             pos,
+			Size:            size,
+			BackgroundColor: backgroundColor,
+			BorderWidth:     borderWidth,
+			BorderColor:     borderColor,
+			BorderRadius:    borderRadius,
+			ZIndex:          zIndex,
+		})
+		commands = append(commands, scrollbarCommands(comp.(scroller), zIndex+1)...)
 	}
 
+	own := len(commands)
+	fadeCommands(commands, cr.Component.AbsoluteOpacity())
 	for _, child := range cr.Component.Children() {
 		childRenderer := &ComponentRenderer{Component: child}
 		childCommands := childRenderer.GenerateRenderCommands(app)
 		commands = append(commands, childCommands...)
 	}
+	transform := cr.Component.AbsoluteTransform()
+	if clipPos, clipSize, ok := clipBox(cr.Component); ok {
+		clipCommands(commands[own:], clipPos, clipSize)
+		if radius := clipRadius(cr.Component); radius > 0 || transform[0][1] != 0 || transform[1][0] != 0 {
+			maskCommands(commands[own:], math.Rect{Pos: clipPos, Size: clipSize}, radius)
+		}
+	}
+	transformCommands(commands, own, transform)
 
 	return commands
 }
@@ -413,22 +870,66 @@ func (cr *ComponentRenderer) Render(app *App) {
 	// for _, command := range commands {
 	// 	log.Printf("RenderCommand: %+v\n", command)
 	// }
-	// Sort commands by ZIndex
-	sort.Slice(commands, func(i, j int) bool {
+	// Sort commands by ZIndex, keeping emission order within a layer
+	sort.SliceStable(commands, func(i, j int) bool {
 		return commands[i].ZIndex < commands[j].ZIndex
 	})
+	var clip RenderCommand // the clip currently set on the renderer
+	var transform *math.Mat3
+	var masks []clipMask // the masks currently pushed on the renderer
+	defer app.renderer.clearClip()
+	defer app.renderer.clearTransform()
+	defer func() { app.renderer.setMasks(masks, nil) }()
 	for _, command := range commands {
 		if command.Display == ui.DisplayNone {
 			continue
 		}
+		if command.Clip != clip.Clip || command.ClipPos != clip.ClipPos || command.ClipSize != clip.ClipSize ||
+			!sameTransform(command.ClipTransform, clip.ClipTransform) {
+			if command.Clip {
+				app.renderer.setClip(screenClip(command))
+			} else {
+				app.renderer.clearClip()
+			}
+			clip = command
+		}
+		if !sameTransform(command.Transform, transform) {
+			if command.Transform != nil {
+				app.renderer.setTransform(*command.Transform)
+			} else {
+				app.renderer.clearTransform()
+			}
+			transform = command.Transform
+		}
+		app.renderer.setMasks(masks, command.Masks)
+		masks = command.Masks
 		switch command.Kind {
 		case RenderCommandDrawRectangle:
-			app.renderer.drawRectangle(command.Pos, command.Size, command.BackgroundColor, command.BorderWidth, command.BorderColor, command.BorderRadius)
+			app.renderer.drawShadows(command, false)
+			if command.Gradient != nil {
+				app.renderer.drawGradientRectangle(command.Pos, command.Size, command.Gradient, command.BorderWidth, command.BorderColor, command.BorderRadius)
+			} else {
+				app.renderer.drawRectangle(command.Pos, command.Size, command.BackgroundColor, command.BorderWidth, command.BorderColor, command.BorderRadius)
+			}
+			app.renderer.drawShadows(command, true)
 
 		case RenderCommandDrawText:
-			app.renderer.drawText("JetBrainsMonoNL-Regular.ttf", command.FontSize, command.Text, command.Pos, command.Color)
+			fontPath := command.FontPath
+			if fontPath == "" {
+				fontPath = defaultFontPath
+			}
+			app.renderer.drawText(fontPath, command.FontSize, command.Text, command.Pos, command.Color)
 
 		case RenderCommandDrawTexture:
+			if command.Sprite != "" {
+				s, ok := app.renderer.spriteManager.lookup(command.Sprite)
+				if !ok {
+					log.Printf("Unknown sprite %q, skipping texture render", command.Sprite)
+					continue
+				}
+				app.renderer.drawSprite(s, command.Pos, command.Size, command.Color)
+				continue
+			}
 			if command.Path == "" {
 				log.Println("Texture path is empty, skipping texture render")
 				continue
@@ -438,7 +939,22 @@ func (cr *ComponentRenderer) Render(app *App) {
 				log.Printf("Failed to load texture: %v", err)
 				return
 			}
-			app.renderer.drawTexture(textureID, command.Pos, command.Size)
+			app.renderer.drawTexture(textureID, command.Pos, command.Size, command.Color)
+
+		case RenderCommandDrawLine:
+			app.renderer.drawLine(command.Pos, command.End, command.Color, command.Thickness)
+
+		case RenderCommandDrawPolyline:
+			app.renderer.drawPolyline(command.Points, command.Color, command.Thickness)
+
+		case RenderCommandDrawPolygon:
+			app.renderer.drawPolygon(command.Points, command.Color)
+
+		case RenderCommandDrawCircle:
+			app.renderer.drawCircle(command.Pos, command.Radius, command.Color)
+
+		case RenderCommandDrawTriangles:
+			app.renderer.drawTriangles(command.Vertices, command.Color)
 		default:
 			log.Printf("Unknown render command kind: %v", command.Kind)
 		}
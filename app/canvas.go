@@ -0,0 +1,41 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Canvases
+// ——————————————————————————————————————————————————————————————————————————————
+
+// canvasCommands paints a canvas and turns what it painted into render
+// commands, moved from its content box's coordinates to the window's and
+// clipped to the content box.
+func canvasCommands(c *ui.Canvas, zIndex int) RenderCommandArray {
+	origin, content := contentBox(c)
+	commands := RenderCommandArray{{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             c.AbsolutePos(),
+		Size:            c.Size(),
+		BackgroundColor: c.BackgroundColor(),
+		BorderWidth:     c.Border(),
+		BorderColor:     c.BorderColor(),
+		BorderRadius:    c.BorderRadius(),
+		ZIndex:          zIndex,
+	}}
+
+	var painted RenderCommandArray
+	for _, op := range c.Paint(content) {
+		switch op.Kind {
+		case ui.PaintTriangles:
+			op.Mesh.Translate(origin)
+			painted = append(painted, RenderCommand{Kind: RenderCommandDrawTriangles, Vertices: op.Mesh.Vertices, Color: op.Color, ZIndex: zIndex})
+		case ui.PaintCircle:
+			center := math.Vec2f32{X: origin.X + op.Center.X, Y: origin.Y + op.Center.Y}
+			painted = append(painted, RenderCommand{Kind: RenderCommandDrawCircle, Pos: center, Radius: op.Radius, Color: op.Color, ZIndex: zIndex})
+		}
+	}
+	clipCommands(painted, origin, content)
+	return append(commands, painted...)
+}
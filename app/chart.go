@@ -0,0 +1,286 @@
+package app
+
+import (
+	gomath "math"
+	"strconv"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/internal/bidi"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Charts
+// ——————————————————————————————————————————————————————————————————————————————
+
+// chartTooltipPadding is the space inside a chart's tooltip box.
+const chartTooltipPadding float32 = 6
+
+// handleChartHover tracks the index under the pointer while it is over the
+// plot.
+func (app *App) handleChartHover(c *ui.Chart, cursorPos math.Vec2f32) {
+	origin, _ := contentBox(c)
+	point := c.LocalPoint(cursorPos)
+	local := math.Vec2f32{X: point.X - origin.X, Y: point.Y - origin.Y}
+	c.HoveredIndex = -1
+	if c.IsPointInsideComponent(cursorPos) && c.IsOverPlot(local) {
+		c.HoveredIndex = c.IndexAt(local.X)
+		c.Pointer = local
+	}
+}
+
+// chartCommands draws a chart's grid, axes and labels, its series clipped to
+// the plot, its legend and, while hovered, a crosshair with a tooltip of the
+// values at the index under the pointer.
+func (app *App) chartCommands(c *ui.Chart, zIndex int) RenderCommandArray {
+	origin, content := contentBox(c)
+	plotPos, plotSize := c.PlotArea()
+	plot := math.Vec2f32{X: origin.X + plotPos.X, Y: origin.Y + plotPos.Y}
+	lo, hi, _ := c.Scale()
+	rtl := c.IsRTL()
+
+	commands := RenderCommandArray{{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             c.AbsolutePos(),
+		Size:            c.Size(),
+		BackgroundColor: c.BackgroundColor(),
+		BorderWidth:     c.Border(),
+		BorderColor:     c.BorderColor(),
+		BorderRadius:    c.BorderRadius(),
+		ZIndex:          zIndex,
+	}}
+
+	for _, v := range c.ValueTicks() {
+		y := origin.Y + c.ValueY(v)
+		if c.ShowGrid {
+			commands = append(commands, RenderCommand{Kind: RenderCommandDrawLine, Pos: math.Vec2f32{X: plot.X, Y: y}, End: math.Vec2f32{X: plot.X + plotSize.X, Y: y}, Thickness: 1, Color: c.GridColor, ZIndex: zIndex})
+		}
+		if c.ShowAxes {
+			label := c.TickLabel(v)
+			x := plot.X - ui.ChartLabelGap - app.le.CalculateTextWidth(label, c.FontSize)
+			if rtl {
+				x = plot.X + plotSize.X + ui.ChartLabelGap
+			}
+			commands = append(commands, labelCommand(label, math.Vec2f32{X: x, Y: y - c.FontSize/2}, c.FontSize, c.TextColor, zIndex))
+		}
+	}
+
+	if c.ShowAxes {
+		bottom := plot.Y + plotSize.Y
+		axisX := plot.X
+		if rtl {
+			axisX = plot.X + plotSize.X
+		}
+		commands = append(commands,
+			RenderCommand{Kind: RenderCommandDrawLine, Pos: math.Vec2f32{X: plot.X, Y: bottom}, End: math.Vec2f32{X: plot.X + plotSize.X, Y: bottom}, Thickness: 1, Color: c.AxisColor, ZIndex: zIndex},
+			RenderCommand{Kind: RenderCommandDrawLine, Pos: math.Vec2f32{X: axisX, Y: plot.Y}, End: math.Vec2f32{X: axisX, Y: bottom}, Thickness: 1, Color: c.AxisColor, ZIndex: zIndex},
+		)
+		for i := 0; i < c.Count(); i += c.LabelEvery() {
+			label := bidi.Visual(c.IndexLabel(i), bidi.Auto)
+			width := app.le.CalculateTextWidth(label, c.FontSize)
+			// Labels at the ends are kept inside the chart.
+			x := max(origin.X, min(origin.X+c.IndexX(i)-width/2, origin.X+content.X-width))
+			commands = append(commands, labelCommand(label, math.Vec2f32{X: x, Y: bottom + ui.ChartLabelGap}, c.FontSize, c.TextColor, zIndex))
+		}
+	}
+
+	var series RenderCommandArray
+	for s := range c.Series {
+		series = append(series, app.seriesCommands(c, s, origin, lo, hi, zIndex+1)...)
+	}
+	clipCommands(series, plot, plotSize)
+	commands = append(commands, series...)
+
+	if c.ShowLegend {
+		commands = append(commands, app.chartLegendCommands(c, origin, content, zIndex)...)
+	}
+	if c.ShowTooltip && c.HoveredIndex >= 0 {
+		commands = append(commands, app.chartHoverCommands(c, origin, content, zIndex+2)...)
+	}
+	return commands
+}
+
+// seriesCommands draws series s as a line, a line over a translucent fill,
+// or a bar per value.
+func (app *App) seriesCommands(c *ui.Chart, s int, origin math.Vec2f32, lo, hi float32, zIndex int) RenderCommandArray {
+	values := c.Series[s].Values
+	seriesColor := c.SeriesColor(s)
+	var commands RenderCommandArray
+
+	if c.Type == ui.ChartTypeBar {
+		for i := range values {
+			barPos, barSize := c.BarRect(s, i)
+			barPos = math.Vec2f32{X: origin.X + barPos.X, Y: origin.Y + barPos.Y}
+			commands = append(commands, RenderCommand{Kind: RenderCommandDrawRectangle, Pos: barPos, Size: barSize, BackgroundColor: seriesColor, ZIndex: zIndex})
+			if c.ShowTooltip && i == c.HoveredIndex {
+				// The hovered index is lit up.
+				commands = append(commands, RenderCommand{Kind: RenderCommandDrawRectangle, Pos: barPos, Size: barSize, BackgroundColor: color.RGBA{R: 1, G: 1, B: 1, A: 0.2}, ZIndex: zIndex})
+			}
+		}
+		return commands
+	}
+
+	points := make([]math.Vec2f32, len(values))
+	for i, v := range values {
+		points[i] = math.Vec2f32{X: origin.X + c.IndexX(i), Y: origin.Y + c.ValueY(max(lo, min(v, hi)))}
+	}
+	if c.Type == ui.ChartTypeArea {
+		fill := seriesColor
+		fill.A *= 0.3
+		base := origin.Y + c.ValueY(max(lo, min(0, hi)))
+		commands = append(commands, areaCommands(points, base, fill, zIndex)...)
+	}
+	return append(commands, RenderCommand{Kind: RenderCommandDrawPolyline, Points: points, Color: seriesColor, Thickness: c.Thickness, ZIndex: zIndex})
+}
+
+// areaCommands fills between a line and the horizontal line at base, one
+// convex polygon per segment. A segment that crosses base is split where it
+// crosses, so that each half stays convex.
+func areaCommands(points []math.Vec2f32, base float32, fill color.RGBA, zIndex int) RenderCommandArray {
+	var commands RenderCommandArray
+	polygon := func(points ...math.Vec2f32) {
+		commands = append(commands, RenderCommand{Kind: RenderCommandDrawPolygon, Points: points, Color: fill, ZIndex: zIndex})
+	}
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		if (a.Y-base)*(b.Y-base) < 0 {
+			t := (base - a.Y) / (b.Y - a.Y)
+			cross := math.Vec2f32{X: a.X + t*(b.X-a.X), Y: base}
+			polygon(math.Vec2f32{X: a.X, Y: base}, a, cross)
+			polygon(cross, b, math.Vec2f32{X: b.X, Y: base})
+			continue
+		}
+		polygon(math.Vec2f32{X: a.X, Y: base}, a, b, math.Vec2f32{X: b.X, Y: base})
+	}
+	return commands
+}
+
+// chartLegendCommands lays out a swatch and name for each series along the
+// top of the chart, from the start edge.
+func (app *App) chartLegendCommands(c *ui.Chart, origin, content math.Vec2f32, zIndex int) RenderCommandArray {
+	var commands RenderCommandArray
+	swatch := c.FontSize * 0.7
+	x := float32(0)
+	for s, series := range c.Series {
+		name := bidi.Visual(series.Name, bidi.Auto)
+		width := swatch + ui.ChartLabelGap/2 + app.le.CalculateTextWidth(name, c.FontSize)
+		entryX := origin.X + x
+		if c.IsRTL() {
+			entryX = origin.X + content.X - x - width
+		}
+		swatchX, nameX := entryX, entryX+swatch+ui.ChartLabelGap/2
+		if c.IsRTL() {
+			swatchX, nameX = entryX+width-swatch, entryX
+		}
+		commands = append(commands,
+			RenderCommand{
+				Kind:            RenderCommandDrawRectangle,
+				Pos:             math.Vec2f32{X: swatchX, Y: origin.Y + (c.FontSize-swatch)/2},
+				Size:            math.Vec2f32{X: swatch, Y: swatch},
+				BackgroundColor: c.SeriesColor(s),
+				BorderRadius:    2,
+				ZIndex:          zIndex,
+			},
+			labelCommand(name, math.Vec2f32{X: nameX, Y: origin.Y}, c.FontSize, c.TextColor, zIndex),
+		)
+		x += width + 2*ui.ChartLabelGap
+	}
+	return commands
+}
+
+// chartHoverCommands draws the crosshair through the hovered index and the
+// pointer, marks each series' point there and lists the values in a tooltip
+// that stays inside the chart.
+func (app *App) chartHoverCommands(c *ui.Chart, origin, content math.Vec2f32, zIndex int) RenderCommandArray {
+	i := c.HoveredIndex
+	plotPos, plotSize := c.PlotArea()
+	plot := math.Vec2f32{X: origin.X + plotPos.X, Y: origin.Y + plotPos.Y}
+	lo, hi, _ := c.Scale()
+	x := origin.X + c.IndexX(i)
+	pointerY := origin.Y + c.Pointer.Y
+
+	commands := RenderCommandArray{
+		{Kind: RenderCommandDrawLine, Pos: math.Vec2f32{X: x, Y: plot.Y}, End: math.Vec2f32{X: x, Y: plot.Y + plotSize.Y}, Thickness: 1, Color: c.CrosshairColor, ZIndex: zIndex},
+		{Kind: RenderCommandDrawLine, Pos: math.Vec2f32{X: plot.X, Y: pointerY}, End: math.Vec2f32{X: plot.X + plotSize.X, Y: pointerY}, Thickness: 1, Color: c.CrosshairColor, ZIndex: zIndex},
+	}
+
+	lines := []string{bidi.Visual(c.IndexLabel(i), bidi.Auto)}
+	var colors []color.RGBA
+	for s, series := range c.Series {
+		if i >= len(series.Values) {
+			continue
+		}
+		v := series.Values[i]
+		if c.Type != ui.ChartTypeBar {
+			center := math.Vec2f32{X: x, Y: origin.Y + c.ValueY(max(lo, min(v, hi)))}
+			commands = append(commands, RenderCommand{Kind: RenderCommandDrawPolygon, Points: markerPoints(center, c.Thickness+2), Color: c.SeriesColor(s), ZIndex: zIndex})
+		}
+		value := strconv.FormatFloat(float64(v), 'f', -1, 32)
+		if series.Name != "" {
+			value = bidi.Visual(series.Name, bidi.Auto) + ": " + value
+		}
+		lines = append(lines, value)
+		colors = append(colors, c.SeriesColor(s))
+	}
+
+	swatch := c.FontSize * 0.7
+	lineHeight := c.FontSize + 4
+	var width float32
+	for _, line := range lines {
+		width = max(width, app.le.CalculateTextWidth(line, c.FontSize))
+	}
+	size := math.Vec2f32{
+		X: width + swatch + ui.ChartLabelGap + 2*chartTooltipPadding,
+		Y: float32(len(lines))*lineHeight + 2*chartTooltipPadding - 4,
+	}
+	// Beside the pointer, on whichever side has room.
+	pos := math.Vec2f32{X: origin.X + c.Pointer.X + 12, Y: pointerY + 12}
+	if pos.X+size.X > origin.X+content.X {
+		pos.X = origin.X + c.Pointer.X - 12 - size.X
+	}
+	if pos.Y+size.Y > origin.Y+content.Y {
+		pos.Y = pointerY - 12 - size.Y
+	}
+	pos.X = max(origin.X, pos.X)
+	pos.Y = max(origin.Y, pos.Y)
+
+	commands = append(commands, RenderCommand{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             pos,
+		Size:            size,
+		BackgroundColor: c.TooltipColor,
+		BorderWidth:     math.Vec2f32{X: 1, Y: 1},
+		BorderColor:     c.GridColor,
+		BorderRadius:    4,
+		ZIndex:          zIndex,
+	})
+	for n, line := range lines {
+		lineY := pos.Y + chartTooltipPadding + float32(n)*lineHeight
+		textX := pos.X + chartTooltipPadding
+		if n > 0 {
+			commands = append(commands, RenderCommand{
+				Kind:            RenderCommandDrawRectangle,
+				Pos:             math.Vec2f32{X: textX, Y: lineY + (c.FontSize-swatch)/2},
+				Size:            math.Vec2f32{X: swatch, Y: swatch},
+				BackgroundColor: colors[n-1],
+				BorderRadius:    2,
+				ZIndex:          zIndex,
+			})
+			textX += swatch + ui.ChartLabelGap
+		}
+		commands = append(commands, labelCommand(line, math.Vec2f32{X: textX, Y: lineY}, c.FontSize, c.TextColor, zIndex))
+	}
+	return commands
+}
+
+// markerPoints returns an octagon around center, round enough for a point
+// marker.
+func markerPoints(center math.Vec2f32, radius float32) []math.Vec2f32 {
+	points := make([]math.Vec2f32, 8)
+	for k := range points {
+		angle := float64(k) * gomath.Pi / 4
+		points[k] = math.Vec2f32{X: center.X + radius*float32(gomath.Cos(angle)), Y: center.Y + radius*float32(gomath.Sin(angle))}
+	}
+	return points
+}
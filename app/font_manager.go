@@ -1,7 +1,7 @@
 package app
 
 /*
-#cgo LDFLAGS: -L../renderer/lib/Release -lrenderer -lglfw3 -lgdi32 -static
+#cgo LDFLAGS: -L../renderer/lib/Release -lglfw3 -lgdi32 -static
 #include "../renderer/include/renderer.h"
 #include <stdlib.h>
 */
@@ -13,6 +13,8 @@ import (
 	"unsafe"
 )
 
+const defaultFontPath = "JetBrainsMonoNL-Regular.ttf"
+
 // TODO: should we use a different package for font manager, renderer and texture manager?
 type FontData = C.FontData
 
@@ -0,0 +1,2 @@
+// GLAD loads the OpenGL functions the renderer calls; see renderer_windows.c.
+#include "../renderer/external/glad/glad.c"
@@ -1,7 +1,7 @@
 package app
 
 /*
-#cgo LDFLAGS: -L../renderer/lib/Release -lrenderer -lglfw3 -lgdi32 -static
+#cgo LDFLAGS: -L../renderer/lib/Release -lglfw3 -lgdi32 -static
 #include "../renderer/include/renderer.h"
 */
 import "C"
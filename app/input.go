@@ -0,0 +1,353 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Keyboard
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Key is a keyboard key, using GLFW key codes.
+type Key int
+
+const (
+	KeySpace        Key = 32
+	KeyEscape       Key = 256
+	KeyEnter        Key = 257
+	KeyTab          Key = 258
+	KeyBackspace    Key = 259
+	KeyDelete       Key = 261
+	KeyRight        Key = 262
+	KeyLeft         Key = 263
+	KeyDown         Key = 264
+	KeyUp           Key = 265
+	KeyPageUp       Key = 266
+	KeyPageDown     Key = 267
+	KeyHome         Key = 268
+	KeyEnd          Key = 269
+	KeyLeftShift    Key = 340
+	KeyLeftControl  Key = 341
+	KeyRightShift   Key = 344
+	KeyRightControl Key = 345
+
+	// Printable keys use their ASCII codes: Key0–Key9 and KeyA–KeyZ.
+	Key0 Key = 48
+	Key9 Key = 57
+	KeyA Key = 65
+	KeyZ Key = 90
+)
+
+// polledKeys are sampled once per frame so that presses can be detected as
+// edges rather than held states.
+var polledKeys = func() []Key {
+	keys := []Key{
+		KeySpace, KeyEscape, KeyEnter, KeyTab, KeyBackspace, KeyDelete,
+		KeyRight, KeyLeft, KeyDown, KeyUp, KeyPageUp, KeyPageDown, KeyHome, KeyEnd,
+		KeyLeftShift, KeyRightShift, KeyLeftControl, KeyRightControl,
+	}
+	for key := Key0; key <= Key9; key++ {
+		keys = append(keys, key)
+	}
+	for key := KeyA; key <= KeyZ; key++ {
+		keys = append(keys, key)
+	}
+	return keys
+}()
+
+type keyboard struct {
+	down map[Key]bool
+	prev map[Key]bool
+	// mouseDown and mouseWasDown hold the left button in this and the
+	// previous frame, so that drags start only on a fresh press.
+	mouseDown    bool
+	mouseWasDown bool
+	// wheel is this frame's mouse wheel movement, until a scroller uses it.
+	wheel math.Vec2f32
+}
+
+func newKeyboard() *keyboard {
+	return &keyboard{down: make(map[Key]bool), prev: make(map[Key]bool)}
+}
+
+func (app *App) pollInput() {
+	kb := app.keyboard
+	kb.prev, kb.down = kb.down, kb.prev
+	for _, key := range polledKeys {
+		kb.down[key] = app.renderer.isKeyDown(key)
+	}
+	kb.mouseWasDown, kb.mouseDown = kb.mouseDown, app.renderer.IsMousePressed(0)
+	kb.wheel = app.renderer.takeScrollDelta()
+}
+
+// mouseJustPressed reports whether the left button went down this frame.
+func (app *App) mouseJustPressed() bool {
+	return app.keyboard.mouseDown && !app.keyboard.mouseWasDown
+}
+
+// IsKeyDown reports whether key is held this frame.
+func (app *App) IsKeyDown(key Key) bool {
+	return app.keyboard.down[key]
+}
+
+// IsKeyPressed reports whether key went down this frame.
+func (app *App) IsKeyPressed(key Key) bool {
+	return app.keyboard.down[key] && !app.keyboard.prev[key]
+}
+
+func (app *App) shiftDown() bool {
+	return app.IsKeyDown(KeyLeftShift) || app.IsKeyDown(KeyRightShift)
+}
+
+func (app *App) ctrlDown() bool {
+	return app.IsKeyDown(KeyLeftControl) || app.IsKeyDown(KeyRightControl)
+}
+
+// TypedText returns the letters, digits and spaces pressed this frame.
+// Letters are upper case while Shift is held.
+func (app *App) TypedText() string {
+	var typed []byte
+	for _, key := range polledKeys {
+		printable := key == KeySpace || (key >= Key0 && key <= Key9) || (key >= KeyA && key <= KeyZ)
+		if !printable || !app.IsKeyPressed(key) {
+			continue
+		}
+		ch := byte(key)
+		if key >= KeyA && key <= KeyZ && !app.shiftDown() {
+			ch += 'a' - 'A'
+		}
+		typed = append(typed, ch)
+	}
+	return string(typed)
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Focus
+// ——————————————————————————————————————————————————————————————————————————————
+
+// focusable reports whether comp can take keyboard focus.
+func focusable(comp ui.IComponent) bool {
+	switch c := comp.(type) {
+	case *ui.Checkbox:
+		return !c.Disabled
+	case *ui.Switch:
+		return !c.Disabled
+	case *ui.RadioGroup:
+		return !c.Disabled
+	case *ui.Slider:
+		return !c.Disabled
+	case *ui.RangeSlider:
+		return !c.Disabled
+	case *ui.Select:
+		return !c.Disabled
+	case *ui.Tabs:
+		return len(c.Headers()) > 0
+	case *ui.TreeView:
+		return len(c.Rows()) > 0
+	case *ui.Table:
+		return len(c.DisplayRows()) > 0
+	}
+	return false
+}
+
+// collectFocusable lists focusable components in tree (tab) order.
+func collectFocusable(comp ui.IComponent, out []ui.IComponent) []ui.IComponent {
+	if comp == nil || comp.Display() == ui.DisplayNone {
+		return out
+	}
+	if focusable(comp) {
+		out = append(out, comp)
+	}
+	for _, child := range comp.Children() {
+		out = collectFocusable(child, out)
+	}
+	return out
+}
+
+func (app *App) focus(comp ui.IComponent) {
+	id := ""
+	if comp != nil {
+		id = comp.FullID()
+	}
+	app.le.SetFocusedID(id)
+}
+
+// HandleKeyboard moves focus with Tab/Shift+Tab, clears it with Escape and
+// forwards other keys to the focused component.
+func HandleKeyboard(app *App, root ui.IComponent) {
+	order := collectFocusable(root, nil)
+	current := -1
+	for i, comp := range order {
+		if comp.FullID() == app.le.FocusedID() {
+			current = i
+		}
+	}
+
+	// An open popup takes Escape and Tab before focus handling.
+	if current >= 0 {
+		if sel, ok := order[current].(*ui.Select); ok && sel.Open {
+			if app.IsKeyPressed(KeyEscape) {
+				sel.Close()
+				return
+			}
+			if app.IsKeyPressed(KeyTab) {
+				sel.Close()
+			}
+		}
+	}
+
+	switch {
+	case app.IsKeyPressed(KeyTab) && len(order) > 0:
+		next := 0
+		switch {
+		case current >= 0 && app.shiftDown():
+			next = (current - 1 + len(order)) % len(order)
+		case current >= 0:
+			next = (current + 1) % len(order)
+		case app.shiftDown():
+			next = len(order) - 1
+		}
+		app.focus(order[next])
+		current = next
+	case app.IsKeyPressed(KeyEscape):
+		app.focus(nil)
+		current = -1
+	}
+
+	for i, comp := range order {
+		setFocused(comp, i == current)
+	}
+	if current < 0 {
+		return
+	}
+
+	activate := app.IsKeyPressed(KeySpace) || app.IsKeyPressed(KeyEnter)
+	switch c := order[current].(type) {
+	case *ui.Checkbox:
+		if activate {
+			c.Toggle()
+		}
+	case *ui.Switch:
+		if activate {
+			c.Toggle()
+		}
+	case *ui.RadioGroup:
+		switch {
+		case app.IsKeyPressed(KeyDown) || app.IsKeyPressed(KeyRight):
+			c.Select(min(c.Selected+1, len(c.Options)-1))
+		case app.IsKeyPressed(KeyUp) || app.IsKeyPressed(KeyLeft):
+			c.Select(max(c.Selected-1, 0))
+		case activate && c.Selected < 0:
+			c.Select(0)
+		}
+	case *ui.Select:
+		app.handleSelectKeys(c)
+	case *ui.Slider:
+		if v, ok := app.sliderKeyTarget(c.Geometry(), c.IsRTL(), c.Value); ok {
+			c.Slide(v)
+		}
+	case *ui.RangeSlider:
+		if v, ok := app.sliderKeyTarget(c.Geometry(), c.IsRTL(), c.Thumb(c.ActiveThumb)); ok {
+			c.SlideThumb(c.ActiveThumb, v)
+		}
+	case *ui.Tabs:
+		app.handleTabsKeys(c)
+	case *ui.TreeView:
+		app.handleTreeKeys(c)
+	case *ui.Table:
+		app.handleTableKeys(c)
+	}
+}
+
+// handleSelectKeys opens a select with Space, Enter or the arrow keys, moves
+// the highlight while it is open and chooses with Enter. Typed text searches
+// the options.
+func (app *App) handleSelectKeys(s *ui.Select) {
+	if !s.Open {
+		switch {
+		case app.IsKeyPressed(KeyEnter), app.IsKeyPressed(KeyDown), app.IsKeyPressed(KeyUp),
+			app.IsKeyPressed(KeySpace) && !s.Searchable:
+			s.SetOpen(true)
+		default:
+			s.Type(app.TypedText(), app.totalTime)
+		}
+		return
+	}
+
+	switch {
+	case app.IsKeyPressed(KeyDown):
+		s.MoveHighlight(1)
+	case app.IsKeyPressed(KeyUp):
+		s.MoveHighlight(-1)
+	case app.IsKeyPressed(KeyPageDown):
+		s.MoveHighlight(max(1, s.MaxRows-1))
+	case app.IsKeyPressed(KeyPageUp):
+		s.MoveHighlight(-max(1, s.MaxRows-1))
+	case app.IsKeyPressed(KeyHome):
+		s.MoveHighlight(-len(s.Options))
+	case app.IsKeyPressed(KeyEnd):
+		s.MoveHighlight(len(s.Options))
+	case app.IsKeyPressed(KeyEnter), app.IsKeyPressed(KeySpace) && !s.Searchable:
+		if s.Highlighted >= 0 {
+			s.Choose(s.Highlighted)
+		} else {
+			s.Close()
+		}
+	case app.IsKeyPressed(KeyBackspace):
+		s.Backspace()
+	default:
+		s.Type(app.TypedText(), app.totalTime)
+	}
+}
+
+// sliderKeyTarget returns where the arrow, Page and Home/End keys move a
+// slider thumb currently at value. Up and the end-side arrow increase it.
+func (app *App) sliderKeyTarget(b *ui.SliderBase, rtl bool, value float32) (float32, bool) {
+	step := b.KeyStep()
+	increase, decrease := KeyRight, KeyLeft
+	if !b.IsVertical() && rtl {
+		increase, decrease = KeyLeft, KeyRight
+	}
+	switch {
+	case app.IsKeyPressed(increase) || app.IsKeyPressed(KeyUp):
+		return value + step, true
+	case app.IsKeyPressed(decrease) || app.IsKeyPressed(KeyDown):
+		return value - step, true
+	case app.IsKeyPressed(KeyPageUp):
+		return value + 10*step, true
+	case app.IsKeyPressed(KeyPageDown):
+		return value - 10*step, true
+	case app.IsKeyPressed(KeyHome):
+		return b.Min, true
+	case app.IsKeyPressed(KeyEnd):
+		return b.Max, true
+	}
+	return value, false
+}
+
+func setFocused(comp ui.IComponent, focused bool) {
+	switch c := comp.(type) {
+	case *ui.Checkbox:
+		c.IsFocused = focused
+	case *ui.Switch:
+		c.IsFocused = focused
+	case *ui.RadioGroup:
+		c.IsFocused = focused
+	case *ui.Slider:
+		c.IsFocused = focused
+	case *ui.RangeSlider:
+		c.IsFocused = focused
+	case *ui.Select:
+		c.IsFocused = focused
+		if !focused {
+			c.Close()
+		}
+	case *ui.Tabs:
+		c.IsFocused = focused
+	case *ui.TreeView:
+		c.IsFocused = focused
+	case *ui.Table:
+		c.IsFocused = focused
+	}
+}
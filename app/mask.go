@@ -0,0 +1,61 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/geometry"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Clip masks
+// ——————————————————————————————————————————————————————————————————————————————
+
+// clipMask is a rounded rectangle a command is clipped to on top of its
+// clip rectangle, for the shapes scissoring cannot cut: rounded corners and
+// turned clips. Rect is in the laid out space of the component that set the
+// mask, which Transform, if set, maps to the screen.
+//
+// The GL renderer keeps masks in its stencil buffer. A renderer drawing on
+// the CPU rasterizes outline with geometry.CoverageMask instead, and
+// intersects the masks of a command to clip to all of them.
+type clipMask struct {
+	Rect      math.Rect
+	Radius    float32
+	Transform *math.Mat3
+}
+
+// clipRadius returns the radius of the corners comp clips its children to:
+// those of the inside of its border when it is a container with hidden
+// overflow, or 0.
+func clipRadius(comp ui.IComponent) float32 {
+	c, ok := comp.(*ui.Container)
+	if !ok || c.Overflow != ui.OverflowHidden {
+		return 0
+	}
+	border := c.Border()
+	return max(0, c.BorderRadius()-max(border.X, border.Y))
+}
+
+// maskCommands clips commands to rect with corners of radius, outside any
+// masks they already have.
+func maskCommands(commands RenderCommandArray, rect math.Rect, radius float32) {
+	for i := range commands {
+		c := &commands[i]
+		c.Masks = append([]clipMask{{Rect: rect, Radius: radius}}, c.Masks...)
+	}
+}
+
+func sameMask(a, b clipMask) bool {
+	return a.Rect == b.Rect && a.Radius == b.Radius && sameTransform(a.Transform, b.Transform)
+}
+
+// outline returns the mask's shape on screen.
+func (m clipMask) outline() []math.Vec2f32 {
+	points := geometry.RoundedRect(m.Rect.Pos, m.Rect.Size, m.Radius, geometry.CornerSegments(m.Radius))
+	if m.Transform != nil {
+		for i, p := range points {
+			points[i] = m.Transform.Apply(p)
+		}
+	}
+	return points
+}
@@ -0,0 +1,152 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Modals
+// ——————————————————————————————————————————————————————————————————————————————
+
+// ModalID identifies a modal opened with OpenModal.
+type ModalID int
+
+type modalEntry struct {
+	id    ModalID
+	build func(app *App) *ui.Modal
+	// modal, root and dialog are rebuilt every frame.
+	modal  *ui.Modal
+	root   ui.IComponent
+	dialog ui.IComponent
+	// returnFocus is the FullID focused before the modal opened.
+	returnFocus string
+	focused     bool
+}
+
+// OpenModal pushes a modal on the overlay stack and returns its ID. build is
+// called every frame while the modal is open, like the Run callback, so the
+// modal always reflects current application state. It is safe to call from
+// a callback, such as a button's; the modal appears on the next frame.
+func (app *App) OpenModal(build func(app *App) *ui.Modal) ModalID {
+	app.nextModalID++
+	app.modals = append(app.modals, &modalEntry{
+		id:          app.nextModalID,
+		build:       build,
+		returnFocus: app.le.FocusedID(),
+	})
+	return app.nextModalID
+}
+
+// CloseModal closes the modal with the given ID, and any modal opened on top
+// of it, calling their OnClose callbacks and restoring keyboard focus.
+func (app *App) CloseModal(id ModalID) {
+	for i, e := range app.modals {
+		if e.id != id {
+			continue
+		}
+		for j := len(app.modals) - 1; j >= i; j-- {
+			closed := app.modals[j]
+			app.modals = app.modals[:j]
+			app.le.SetFocusedID(closed.returnFocus)
+			if closed.modal != nil && closed.modal.OnClose != nil {
+				closed.modal.OnClose()
+			}
+		}
+		return
+	}
+}
+
+// CloseTopModal closes the most recently opened modal, if any.
+func (app *App) CloseTopModal() {
+	if top := app.topModal(); top != nil {
+		app.CloseModal(top.id)
+	}
+}
+
+// IsModalOpen reports whether the modal with the given ID is open.
+func (app *App) IsModalOpen(id ModalID) bool {
+	for _, e := range app.modals {
+		if e.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (app *App) topModal() *modalEntry {
+	if len(app.modals) == 0 {
+		return nil
+	}
+	return app.modals[len(app.modals)-1]
+}
+
+// layoutModals builds and lays out the open modals, bottom first, each
+// followed by the popups opened inside it, and appends them to overlays.
+func (app *App) layoutModals(windowSize math.Vec2f32, overlays []overlay) []overlay {
+	for _, e := range app.modals {
+		e.modal = e.build(app)
+		backdrop, dialog := e.modal.Expand(windowSize)
+		root := app.le.BuildOverlay(backdrop, fmt.Sprintf("modal#%d", e.id), app.deltaTime)
+		app.le.Layout(root, math.Vec2f32{}, windowSize)
+
+		// Centre the dialog now that its size is known.
+		size := dialog.Size()
+		dialog.SetPosition(ui.Position{X: (windowSize.X - size.X) / 2, Y: (windowSize.Y - size.Y) / 2, Type: ui.PositionTypeAbsolute})
+		app.le.Layout(root, math.Vec2f32{}, windowSize)
+		app.le.AdvanceTransitions(root, app.deltaTime)
+		e.root, e.dialog = root, dialog
+
+		if !e.focused {
+			// Move focus into the modal when it first appears.
+			e.focused = true
+			if order := collectFocusable(root, nil); len(order) > 0 {
+				app.focus(order[0])
+			} else {
+				app.focus(nil)
+			}
+		}
+
+		overlays = append(overlays, overlay{root: root, modal: e})
+		overlays = app.collectPopups(root, windowSize, overlays)
+	}
+	return overlays
+}
+
+// handleModalBackdrop closes a modal when its backdrop, outside the dialog,
+// is pressed.
+func (app *App) handleModalBackdrop(e *modalEntry) {
+	if !e.modal.CloseOnBackdrop || !app.mouseJustPressed() {
+		return
+	}
+	if e.root.IsPointInsideComponent(app.pointer) && !e.dialog.IsPointInsideComponent(app.pointer) {
+		app.CloseModal(e.id)
+	}
+}
+
+// handleModalKeys closes the top modal on Escape, unless an open popup in
+// it takes the key first. It reports whether the key was used.
+func (app *App) handleModalKeys() bool {
+	top := app.topModal()
+	if top == nil || top.modal == nil || !top.modal.CloseOnEscape || !app.IsKeyPressed(KeyEscape) {
+		return false
+	}
+	for _, comp := range collectFocusable(top.root, nil) {
+		if s, ok := comp.(*ui.Select); ok && s.Open && s.FullID() == app.le.FocusedID() {
+			return false
+		}
+	}
+	app.CloseModal(top.id)
+	return true
+}
+
+// keyboardRoot is the tree that receives keyboard input: the top modal if
+// one is open, so focus cannot leave it, or the main tree.
+func (app *App) keyboardRoot(root ui.IComponent) ui.IComponent {
+	if top := app.topModal(); top != nil && top.root != nil {
+		return top.root
+	}
+	return root
+}
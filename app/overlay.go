@@ -0,0 +1,153 @@
+package app
+
+import (
+	gomath "math"
+
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Overlay layer
+// ——————————————————————————————————————————————————————————————————————————————
+
+// overlay is a component tree laid out and rendered above the main tree, so
+// it escapes the clipping and z-order of the component that opened it.
+// Pointer input over an overlay does not reach the components underneath.
+type overlay struct {
+	root  ui.IComponent
+	owner ui.IComponent // component a popup belongs to
+	modal *modalEntry   // set for modals
+}
+
+// offscreen is a pointer position that is inside no component. It replaces
+// the cursor for trees covered by an overlay.
+var offscreen = math.Vec2f32{X: float32(gomath.Inf(-1)), Y: float32(gomath.Inf(-1))}
+
+// collectPopups builds the overlays for open popups in the tree, in tree
+// order.
+func (app *App) collectPopups(comp ui.IComponent, windowSize math.Vec2f32, out []overlay) []overlay {
+	if comp == nil || comp.Display() == ui.DisplayNone {
+		return out
+	}
+	if s, ok := comp.(*ui.Select); ok && s.Open {
+		popup := s.Popup(screenRect(s).Size.X)
+		out = append(out, overlay{root: app.layoutPopup(popup, s, ui.PlacementBottom, ui.PopupAlignStart, windowSize), owner: s})
+	}
+	for _, child := range comp.Children() {
+		out = app.collectPopups(child, windowSize, out)
+	}
+	return out
+}
+
+// layoutPopup lays out an overlay tree owned by owner and places it on the
+// given side of the owner's box on screen, flipped or shifted to stay inside
+// the window, and runs its animations and transitions.
+func (app *App) layoutPopup(popup *ui.Container, owner ui.IComponent, side ui.Placement, align ui.PopupAlign, windowSize math.Vec2f32) ui.IComponent {
+	root := app.le.BuildOverlay(popup, owner.FullID(), app.deltaTime)
+	app.le.Layout(root, math.Vec2f32{}, windowSize)
+
+	anchor := screenRect(owner)
+	pos := ui.PlacePopup(side, align, anchor.Pos, anchor.Size, root.Size(), windowSize)
+	popup.SetPosition(ui.Position{X: pos.X, Y: pos.Y, Type: ui.PositionTypeAbsolute})
+	popup.SetZIndex(owner.AbsoluteZIndex() + 1)
+	app.le.Layout(root, pos, windowSize)
+	app.le.AdvanceTransitions(root, app.deltaTime)
+	return root
+}
+
+// screenRect returns the box comp covers on screen: its laid out box moved,
+// scaled or turned by its transforms and those of its ancestors.
+func screenRect(comp ui.IComponent) math.Rect {
+	return comp.AbsoluteTransform().ApplyRect(math.Rect{Pos: comp.AbsolutePos(), Size: comp.Size()})
+}
+
+// overlayAt returns the topmost overlay under point, or nil.
+func (app *App) overlayAt(point math.Vec2f32) *overlay {
+	for i := len(app.overlays) - 1; i >= 0; i-- {
+		if app.overlays[i].root.IsPointInsideComponent(point) {
+			return &app.overlays[i]
+		}
+	}
+	return nil
+}
+
+// popupOf returns the overlay owned by comp, or nil.
+func (app *App) popupOf(comp ui.IComponent) *overlay {
+	for i := range app.overlays {
+		if app.overlays[i].owner == comp {
+			return &app.overlays[i]
+		}
+	}
+	return nil
+}
+
+// handleOverlayInput runs pointer handling for the overlays, topmost first,
+// and then decides which pointer position the main tree sees.
+func (app *App) handleOverlayInput() {
+	cursorPos := app.renderer.getMousePos()
+	for i := len(app.overlays) - 1; i >= 0; i-- {
+		o := &app.overlays[i]
+		app.pointer = offscreen
+		if app.overlayAt(cursorPos) == o {
+			app.pointer = cursorPos
+		}
+		HandleOnClicks(app, o.root)
+		if s, ok := o.owner.(*ui.Select); ok {
+			app.handleSelectPopup(s, o.root)
+		}
+		if o.modal != nil {
+			app.handleModalBackdrop(o.modal)
+		}
+	}
+
+	app.pointer = cursorPos
+	if app.overlayAt(cursorPos) != nil {
+		app.pointer = offscreen
+	}
+}
+
+// handleSelectPopup highlights the option row under the pointer, chooses
+// it when a press that started on it is released and scrolls the rows with
+// the wheel.
+func (app *App) handleSelectPopup(s *ui.Select, popup ui.IComponent) {
+	first, last := s.PopupRows()
+	rows := s.VisibleOptions()[first:last]
+	hovered := -1
+	for i, row := range popup.Children() {
+		if i < len(rows) && row.IsPointInsideComponent(app.pointer) {
+			hovered = rows[i]
+		}
+	}
+	if wheel := app.keyboard.wheel.Y; wheel != 0 && popup.IsPointInsideComponent(app.pointer) {
+		// A wheel step scrolls at least a row; positive wheel moves up.
+		step := int(gomath.Round(float64(wheel)))
+		if step == 0 {
+			step = int(gomath.Copysign(1, float64(wheel)))
+		}
+		s.ScrollRows(-step)
+		app.keyboard.wheel.Y = 0
+	}
+	if hovered >= 0 {
+		s.Highlighted = hovered
+	}
+
+	if hovered >= 0 && app.mouseJustPressed() {
+		s.PressedOption = hovered
+	}
+	if s.PressedOption >= 0 && !app.renderer.IsMousePressed(0) {
+		pressed := s.PressedOption
+		s.PressedOption = -1
+		if pressed == hovered {
+			s.Choose(pressed)
+		}
+	}
+}
+
+// renderOverlays draws the overlays above the main tree, bottom first.
+func (app *App) renderOverlays() {
+	for _, o := range app.overlays {
+		overlayRenderer := &ComponentRenderer{Component: o.root}
+		overlayRenderer.Render(app)
+	}
+}
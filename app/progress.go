@@ -0,0 +1,224 @@
+package app
+
+import (
+	gomath "math"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/geometry"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Progress Bars, Spinners and Skeletons
+// ——————————————————————————————————————————————————————————————————————————————
+
+// backgroundCommand draws comp's own background and border.
+func backgroundCommand(comp ui.IComponent, zIndex int) RenderCommand {
+	return RenderCommand{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             comp.AbsolutePos(),
+		Size:            comp.Size(),
+		BackgroundColor: comp.BackgroundColor(),
+		BorderWidth:     comp.Border(),
+		BorderColor:     comp.BorderColor(),
+		BorderRadius:    comp.BorderRadius(),
+		Shadows:         comp.Shadows(),
+		Gradient:        comp.Gradient(),
+		ZIndex:          zIndex,
+	}
+}
+
+func (app *App) progressBarCommands(p *ui.ProgressBar, zIndex int) RenderCommandArray {
+	commands := RenderCommandArray{backgroundCommand(p, zIndex)}
+	if p.Shape == ui.ProgressCircular {
+		return append(commands, app.progressRingCommands(p, zIndex)...)
+	}
+
+	origin, content := contentBox(p)
+	label := p.ShowLabel && !p.Indeterminate
+	var reserve float32
+	if label {
+		reserve = ui.ProgressLabelGap + app.le.CalculateTextWidth("100%", p.FontSize)
+	}
+	length := max(0, content.X-reserve)
+	track := math.Vec2f32{X: origin.X, Y: origin.Y + (content.Y-p.Thickness)/2}
+	if p.IsRTL() {
+		track.X += reserve
+	}
+	commands = append(commands, RenderCommand{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             track,
+		Size:            math.Vec2f32{X: length, Y: p.Thickness},
+		BackgroundColor: p.TrackColor,
+		BorderRadius:    p.Thickness / 2,
+		ZIndex:          zIndex,
+	})
+
+	from, to := float32(0), p.Shown()
+	if p.Indeterminate {
+		from, to = p.Segment()
+	}
+	if p.IsRTL() {
+		// The fill grows from the right.
+		from, to = 1-to, 1-from
+	}
+	if width := (to - from) * length; width > 0 {
+		commands = append(commands, RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             math.Vec2f32{X: track.X + from*length, Y: track.Y},
+			Size:            math.Vec2f32{X: width, Y: p.Thickness},
+			BackgroundColor: p.FillColor,
+			BorderRadius:    min(p.Thickness, width) / 2,
+			ZIndex:          zIndex,
+		})
+	}
+
+	if label {
+		x := track.X + length + ui.ProgressLabelGap
+		if p.IsRTL() {
+			x = origin.X + reserve - ui.ProgressLabelGap - app.le.CalculateTextWidth(p.Label(), p.FontSize)
+		}
+		commands = append(commands, labelCommand(p.Label(), math.Vec2f32{X: x, Y: origin.Y + (content.Y-p.FontSize)/2}, p.FontSize, p.TextColor, zIndex))
+	}
+	return commands
+}
+
+// progressRingCommands draws a circular progress bar as a ring with an arc
+// of fill over it, and the percentage in the middle.
+func (app *App) progressRingCommands(p *ui.ProgressBar, zIndex int) RenderCommandArray {
+	origin, content := contentBox(p)
+	center := math.Vec2f32{X: origin.X + content.X/2, Y: origin.Y + content.Y/2}
+	radius := (min(content.X, content.Y) - p.Thickness) / 2
+	if radius <= 0 {
+		return nil
+	}
+	style := geometry.StrokeStyle{Width: p.Thickness, Cap: geometry.CapRound, AntiAlias: true}
+
+	var ring geometry.Path
+	ring.Ellipse(center, math.Vec2f32{X: radius, Y: radius})
+	commands := RenderCommandArray{{
+		Kind:     RenderCommandDrawTriangles,
+		Vertices: geometry.Stroke(ring.Contours(), style).Vertices,
+		Color:    p.TrackColor,
+		ZIndex:   zIndex,
+	}}
+
+	start, end := p.Arc()
+	if p.IsRTL() {
+		// Mirrored, so the fill runs counterclockwise.
+		start, end = gomath.Pi-start, gomath.Pi-end
+	}
+	if start != end {
+		var arc geometry.Path
+		arc.Arc(center, radius, start, end)
+		commands = append(commands, RenderCommand{
+			Kind:     RenderCommandDrawTriangles,
+			Vertices: geometry.Stroke(arc.Contours(), style).Vertices,
+			Color:    p.FillColor,
+			ZIndex:   zIndex,
+		})
+	}
+
+	if p.ShowLabel && !p.Indeterminate {
+		label := p.Label()
+		width := app.le.CalculateTextWidth(label, p.FontSize)
+		commands = append(commands, labelCommand(label, math.Vec2f32{X: center.X - width/2, Y: center.Y - p.FontSize/2}, p.FontSize, p.TextColor, zIndex))
+	}
+	return commands
+}
+
+func spinnerCommands(s *ui.Spinner, zIndex int) RenderCommandArray {
+	origin, _ := contentBox(s)
+	commands := make(RenderCommandArray, 0, s.Dots)
+	for i := range s.Dots {
+		center := s.DotCenter(i)
+		dot := s.Color
+		dot.A *= s.DotAlpha(i)
+		commands = append(commands, RenderCommand{
+			Kind:   RenderCommandDrawCircle,
+			Pos:    math.Vec2f32{X: origin.X + center.X, Y: origin.Y + center.Y},
+			Radius: s.DotRadius(),
+			Color:  dot,
+			ZIndex: zIndex,
+		})
+	}
+	return commands
+}
+
+// skeletonCommands draws a skeleton's bars with the shimmer band over them.
+// The band is placed across the whole content box, so it sweeps every line
+// of a text skeleton together.
+func skeletonCommands(s *ui.Skeleton, zIndex int) RenderCommandArray {
+	origin, content := contentBox(s)
+	commands := RenderCommandArray{backgroundCommand(s, zIndex)}
+
+	bars := s.Bars(content.X)
+	for i := 0; i+1 < len(bars); i += 2 {
+		pos := math.Vec2f32{X: origin.X + bars[i].X, Y: origin.Y + bars[i].Y}
+		size := bars[i+1]
+		if s.IsRTL() {
+			// Short last lines end at the right edge.
+			pos.X = origin.X + content.X - bars[i].X - size.X
+		}
+		radius := s.BorderRadius()
+		if s.Shape == ui.SkeletonCircle {
+			radius = size.X / 2
+			commands = append(commands, RenderCommand{
+				Kind:            RenderCommandDrawRectangle,
+				Pos:             pos,
+				Size:            size,
+				BackgroundColor: pulseColor(s.BaseColor, s.HighlightColor, s.Pulse()),
+				BorderRadius:    radius,
+				ZIndex:          zIndex,
+			})
+			continue
+		}
+		commands = append(commands, RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             pos,
+			Size:            size,
+			BackgroundColor: s.BaseColor,
+			BorderRadius:    radius,
+			ZIndex:          zIndex,
+		})
+		band := shimmerCommand(s, origin, content, zIndex)
+		band.Clip, band.ClipPos, band.ClipSize = true, pos, size
+		commands = append(commands, band)
+	}
+	return commands
+}
+
+// shimmerCommand draws the shimmer band as a mesh that fades in from its
+// leading edge to the middle and out again.
+func shimmerCommand(s *ui.Skeleton, origin, content math.Vec2f32, zIndex int) RenderCommand {
+	width := ui.SkeletonBand * content.X
+	left := origin.X + s.Band()*content.X
+	if s.IsRTL() {
+		left = origin.X + content.X - (left - origin.X) - width
+	}
+	top, bottom := origin.Y, origin.Y+content.Y
+	at := func(x, y, alpha float32) geometry.Vertex {
+		return geometry.Vertex{Pos: math.Vec2f32{X: x, Y: y}, Alpha: alpha}
+	}
+	xs := [3]float32{left, left + width/2, left + width}
+	alphas := [3]float32{0, 1, 0}
+	var vertices []geometry.Vertex
+	for i := range 2 {
+		a, b := at(xs[i], top, alphas[i]), at(xs[i+1], top, alphas[i+1])
+		c, d := at(xs[i+1], bottom, alphas[i+1]), at(xs[i], bottom, alphas[i])
+		vertices = append(vertices, a, b, c, a, c, d)
+	}
+	return RenderCommand{Kind: RenderCommandDrawTriangles, Vertices: vertices, Color: s.HighlightColor, ZIndex: zIndex}
+}
+
+// pulseColor returns base lit by highlight to the given strength, from 0 to 1.
+func pulseColor(base, highlight color.RGBA, strength float32) color.RGBA {
+	t := highlight.A * strength
+	return color.RGBA{
+		R: base.R + (highlight.R-base.R)*t,
+		G: base.G + (highlight.G-base.G)*t,
+		B: base.B + (highlight.B-base.B)*t,
+		A: base.A,
+	}
+}
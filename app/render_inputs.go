@@ -0,0 +1,234 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Render commands for boolean and choice inputs
+// ——————————————————————————————————————————————————————————————————————————————
+
+// toggleRow places an indicator and its label in a row of the given origin
+// and width. In RTL components the indicator sits at the right edge.
+func (app *App) toggleRow(origin math.Vec2f32, width float32, indicator math.Vec2f32, label string, fontSize float32, rtl bool) (indicatorPos, labelPos math.Vec2f32) {
+	rowHeight := max(indicator.Y, fontSize)
+	indicatorPos = math.Vec2f32{X: origin.X, Y: origin.Y + (rowHeight-indicator.Y)/2}
+	labelPos = math.Vec2f32{X: origin.X + indicator.X + ui.ToggleLabelGap, Y: origin.Y + (rowHeight-fontSize)/2}
+	if rtl {
+		labelWidth := app.le.CalculateTextWidth(label, fontSize)
+		indicatorPos.X = origin.X + width - indicator.X
+		labelPos.X = indicatorPos.X - ui.ToggleLabelGap - labelWidth
+	}
+	return indicatorPos, labelPos
+}
+
+func focusRing(pos, size math.Vec2f32, radius float32, ringColor color.RGBA, zIndex int) RenderCommand {
+	return RenderCommand{
+		Kind:         RenderCommandDrawRectangle,
+		Pos:          math.Vec2f32{X: pos.X - 3, Y: pos.Y - 3},
+		Size:         math.Vec2f32{X: size.X + 6, Y: size.Y + 6},
+		BorderWidth:  math.Vec2f32{X: 2, Y: 2},
+		BorderColor:  ringColor,
+		BorderRadius: radius + 3,
+		ZIndex:       zIndex,
+	}
+}
+
+func labelCommand(label string, pos math.Vec2f32, fontSize float32, textColor color.RGBA, zIndex int) RenderCommand {
+	return RenderCommand{
+		Kind:     RenderCommandDrawText,
+		Text:     label,
+		Color:    textColor,
+		Pos:      pos,
+		FontSize: fontSize,
+		ZIndex:   zIndex,
+	}
+}
+
+func (app *App) checkboxCommands(c *ui.Checkbox, pos, size math.Vec2f32, zIndex int) RenderCommandArray {
+	origin := math.Vec2f32{X: pos.X + c.Padding().X, Y: pos.Y + c.Padding().Y}
+	box := c.IndicatorSize()
+	boxPos, labelPos := app.toggleRow(origin, size.X-2*c.Padding().X, math.Vec2f32{X: box, Y: box}, c.Label, c.FontSize, c.IsRTL())
+
+	fill := color.Transparent
+	outline := c.BorderColor()
+	textColor := c.TextColor
+	if c.State != ui.CheckStateUnchecked {
+		fill = c.AccentColor
+		outline = c.AccentColor
+	}
+	if c.IsMouseOver {
+		outline = c.HoverColor
+	}
+	if c.IsPressed {
+		fill = c.HoverColor
+	}
+	if c.Disabled {
+		outline = c.DisabledColor
+		textColor = c.DisabledColor
+		if c.State != ui.CheckStateUnchecked {
+			fill = c.DisabledColor
+		}
+	}
+
+	var commands RenderCommandArray
+	if c.IsFocused {
+		commands = append(commands, focusRing(boxPos, math.Vec2f32{X: box, Y: box}, 3, c.FocusColor, zIndex))
+	}
+	commands = append(commands, RenderCommand{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             boxPos,
+		Size:            math.Vec2f32{X: box, Y: box},
+		BackgroundColor: fill,
+		BorderWidth:     math.Vec2f32{X: 1.5, Y: 1.5},
+		BorderColor:     outline,
+		BorderRadius:    3,
+		ZIndex:          zIndex,
+	})
+
+	markColor := color.White
+	thickness := max(2, box/8)
+	point := func(x, y float32) math.Vec2f32 { return math.Vec2f32{X: boxPos.X + x*box, Y: boxPos.Y + y*box} }
+	switch c.State {
+	case ui.CheckStateChecked:
+		commands = append(commands,
+			RenderCommand{Kind: RenderCommandDrawLine, Pos: point(0.22, 0.52), End: point(0.42, 0.72), Thickness: thickness, Color: markColor, ZIndex: zIndex + 1},
+			RenderCommand{Kind: RenderCommandDrawLine, Pos: point(0.42, 0.72), End: point(0.78, 0.3), Thickness: thickness, Color: markColor, ZIndex: zIndex + 1},
+		)
+	case ui.CheckStateIndeterminate:
+		commands = append(commands, RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             point(0.25, 0.5-0.05),
+			Size:            math.Vec2f32{X: box * 0.5, Y: max(2, box*0.1)},
+			BackgroundColor: markColor,
+			ZIndex:          zIndex + 1,
+		})
+	}
+
+	if c.Label != "" {
+		commands = append(commands, labelCommand(c.Label, labelPos, c.FontSize, textColor, zIndex+1))
+	}
+	return commands
+}
+
+func (app *App) switchCommands(s *ui.Switch, pos, size math.Vec2f32, zIndex int) RenderCommandArray {
+	origin := math.Vec2f32{X: pos.X + s.Padding().X, Y: pos.Y + s.Padding().Y}
+	track := s.TrackSize()
+	trackPos, labelPos := app.toggleRow(origin, size.X-2*s.Padding().X, track, s.Label, s.FontSize, s.IsRTL())
+
+	trackColor := s.TrackColor
+	textColor := s.TextColor
+	if s.On {
+		trackColor = s.AccentColor
+	}
+	if s.IsMouseOver || s.IsPressed {
+		trackColor = s.HoverColor
+		if !s.On {
+			trackColor = trackColor.BlendOver(s.TrackColor)
+			trackColor.A = 1
+		}
+	}
+	if s.Disabled {
+		trackColor = s.DisabledColor
+		textColor = s.DisabledColor
+	}
+
+	// The knob sits at the end edge when on: the right for LTR, the left for RTL.
+	knob := track.Y - 4
+	atEnd := s.On != s.IsRTL()
+	knobX := trackPos.X + 2
+	if atEnd {
+		knobX = trackPos.X + track.X - knob - 2
+	}
+
+	var commands RenderCommandArray
+	if s.IsFocused {
+		commands = append(commands, focusRing(trackPos, track, track.Y/2, s.FocusColor, zIndex))
+	}
+	commands = append(commands,
+		RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             trackPos,
+			Size:            track,
+			BackgroundColor: trackColor,
+			BorderRadius:    track.Y / 2,
+			ZIndex:          zIndex,
+		},
+		RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             math.Vec2f32{X: knobX, Y: trackPos.Y + 2},
+			Size:            math.Vec2f32{X: knob, Y: knob},
+			BackgroundColor: s.KnobColor,
+			BorderRadius:    knob / 2,
+			ZIndex:          zIndex + 1,
+		},
+	)
+	if s.Label != "" {
+		commands = append(commands, labelCommand(s.Label, labelPos, s.FontSize, textColor, zIndex+1))
+	}
+	return commands
+}
+
+func (app *App) radioGroupCommands(r *ui.RadioGroup, pos, size math.Vec2f32, zIndex int) RenderCommandArray {
+	origin := math.Vec2f32{X: pos.X + r.Padding().X, Y: pos.Y + r.Padding().Y}
+	dot := r.IndicatorSize()
+	step := r.RowHeight() + r.Gap().Y
+	focusIndex := max(r.Selected, 0)
+
+	var commands RenderCommandArray
+	for i, option := range r.Options {
+		rowOrigin := math.Vec2f32{X: origin.X, Y: origin.Y + float32(i)*step}
+		dotPos, labelPos := app.toggleRow(rowOrigin, size.X-2*r.Padding().X, math.Vec2f32{X: dot, Y: dot}, option, r.FontSize, r.IsRTL())
+
+		selected := i == r.Selected
+		fill := color.Transparent
+		outline := r.BorderColor()
+		textColor := r.TextColor
+		if selected {
+			outline = r.AccentColor
+		}
+		if i == r.HoveredOption {
+			outline = r.HoverColor
+		}
+		if i == r.PressedOption {
+			fill = r.HoverColor
+		}
+		if r.Disabled {
+			outline = r.DisabledColor
+			textColor = r.DisabledColor
+		}
+
+		if r.IsFocused && i == focusIndex {
+			commands = append(commands, focusRing(dotPos, math.Vec2f32{X: dot, Y: dot}, dot/2, r.FocusColor, zIndex))
+		}
+		commands = append(commands, RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             dotPos,
+			Size:            math.Vec2f32{X: dot, Y: dot},
+			BackgroundColor: fill,
+			BorderWidth:     math.Vec2f32{X: 1.5, Y: 1.5},
+			BorderColor:     outline,
+			BorderRadius:    dot / 2,
+			ZIndex:          zIndex,
+		})
+		if selected {
+			inner := dot * 0.5
+			innerColor := r.AccentColor
+			if r.Disabled {
+				innerColor = r.DisabledColor
+			}
+			commands = append(commands, RenderCommand{
+				Kind:            RenderCommandDrawRectangle,
+				Pos:             math.Vec2f32{X: dotPos.X + (dot-inner)/2, Y: dotPos.Y + (dot-inner)/2},
+				Size:            math.Vec2f32{X: inner, Y: inner},
+				BackgroundColor: innerColor,
+				BorderRadius:    inner / 2,
+				ZIndex:          zIndex + 1,
+			})
+		}
+		commands = append(commands, labelCommand(option, labelPos, r.FontSize, textColor, zIndex+1))
+	}
+	return commands
+}
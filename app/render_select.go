@@ -0,0 +1,96 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/internal/bidi"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Render commands for selects
+// ——————————————————————————————————————————————————————————————————————————————
+
+// selectCommands draws the closed box of a select: its label, an arrow at
+// the end edge and, for an open combo box, a caret after the search text.
+// The option list is an overlay and is rendered separately.
+func (app *App) selectCommands(s *ui.Select, zIndex int) RenderCommandArray {
+	pos, size := s.AbsolutePos(), s.Size()
+	origin, content := contentBox(s)
+
+	background := s.BackgroundColor()
+	borderColor := s.BorderColor()
+	textColor := s.TextColor
+	if s.IsMouseOver || s.Open {
+		background = s.HoverColor
+	}
+	if s.Open {
+		borderColor = s.HighlightColor
+	}
+	if s.Disabled {
+		borderColor = s.DisabledColor
+		textColor = s.DisabledColor
+	}
+
+	var commands RenderCommandArray
+	if s.IsFocused {
+		commands = append(commands, focusRing(pos, size, s.BorderRadius(), s.FocusColor, zIndex))
+	}
+	commands = append(commands, RenderCommand{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             pos,
+		Size:            size,
+		BackgroundColor: background,
+		BorderWidth:     s.Border(),
+		BorderColor:     borderColor,
+		BorderRadius:    s.BorderRadius(),
+		ZIndex:          zIndex,
+	})
+
+	label := s.Label()
+	if s.Value() == "" && !(s.Searchable && s.Open) {
+		textColor = s.PlaceholderColor
+	}
+	label = bidi.Visual(label, bidi.Auto)
+	labelWidth := app.le.CalculateTextWidth(label, s.FontSize)
+	arrowWidth := ui.SelectArrowWidth(s.FontSize)
+	labelPos := math.Vec2f32{X: origin.X, Y: origin.Y + (content.Y-s.FontSize)/2}
+	arrowX := origin.X + content.X - arrowWidth
+	if s.IsRTL() {
+		labelPos.X = origin.X + content.X - labelWidth
+		arrowX = origin.X
+	}
+	if label != "" {
+		commands = append(commands, labelCommand(label, labelPos, s.FontSize, textColor, zIndex+1))
+	}
+
+	if s.Searchable && s.Open {
+		caretX := labelPos.X + labelWidth + 1
+		if s.IsRTL() {
+			caretX = labelPos.X - 2
+		}
+		commands = append(commands, RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             math.Vec2f32{X: caretX, Y: labelPos.Y},
+			Size:            math.Vec2f32{X: 1, Y: s.FontSize},
+			BackgroundColor: s.TextColor,
+			ZIndex:          zIndex + 1,
+		})
+	}
+
+	// A chevron pointing down, or up while open.
+	half := arrowWidth * 0.25
+	mid := math.Vec2f32{X: arrowX + arrowWidth/2, Y: origin.Y + content.Y/2}
+	tip, wing := half/2, -half/2
+	if s.Open {
+		tip, wing = -tip, -wing
+	}
+	arrowColor := s.TextColor
+	if s.Disabled {
+		arrowColor = s.DisabledColor
+	}
+	commands = append(commands,
+		RenderCommand{Kind: RenderCommandDrawLine, Pos: math.Vec2f32{X: mid.X - half, Y: mid.Y + wing}, End: math.Vec2f32{X: mid.X, Y: mid.Y + tip}, Thickness: 1.5, Color: arrowColor, ZIndex: zIndex + 1},
+		RenderCommand{Kind: RenderCommandDrawLine, Pos: math.Vec2f32{X: mid.X, Y: mid.Y + tip}, End: math.Vec2f32{X: mid.X + half, Y: mid.Y + wing}, Thickness: 1.5, Color: arrowColor, ZIndex: zIndex + 1},
+	)
+	return commands
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Render commands for sliders
+// ——————————————————————————————————————————————————————————————————————————————
+
+// sliderTrackCommands draws the track, the filled part between from and to,
+// and the tick marks.
+func sliderTrackCommands(b *ui.SliderBase, origin, content math.Vec2f32, from, to float32, zIndex int) RenderCommandArray {
+	area := b.TrackContent(content)
+	trackPos, trackSize := b.TrackRect(area)
+	trackPos.Add(origin)
+
+	trackColor, fillColor := b.TrackColor, b.AccentColor
+	if b.Disabled {
+		fillColor = b.DisabledColor
+	}
+
+	start, end := b.ThumbCenter(from, area), b.ThumbCenter(to, area)
+	fillPos, fillSize := trackPos, trackSize
+	if b.IsVertical() {
+		fillPos.Y = origin.Y + min(start.Y, end.Y)
+		fillSize.Y = max(start.Y, end.Y) - min(start.Y, end.Y)
+	} else {
+		fillPos.X = origin.X + min(start.X, end.X)
+		fillSize.X = max(start.X, end.X) - min(start.X, end.X)
+	}
+
+	commands := RenderCommandArray{
+		{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             trackPos,
+			Size:            trackSize,
+			BackgroundColor: trackColor,
+			BorderRadius:    b.TrackThickness / 2,
+			ZIndex:          zIndex,
+		},
+		{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             fillPos,
+			Size:            fillSize,
+			BackgroundColor: fillColor,
+			BorderRadius:    b.TrackThickness / 2,
+			ZIndex:          zIndex,
+		},
+	}
+
+	tickColor := b.TextColor
+	tickColor.A *= 0.5
+	tickLength := b.ThumbSize * 0.75
+	for _, v := range b.TickValues() {
+		c := b.ThumbCenter(v, area)
+		tick := RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             math.Vec2f32{X: origin.X + c.X - 0.5, Y: origin.Y + c.Y - tickLength/2},
+			Size:            math.Vec2f32{X: 1, Y: tickLength},
+			BackgroundColor: tickColor,
+			ZIndex:          zIndex,
+		}
+		if b.IsVertical() {
+			tick.Pos = math.Vec2f32{X: origin.X + c.X - tickLength/2, Y: origin.Y + c.Y - 0.5}
+			tick.Size = math.Vec2f32{X: tickLength, Y: 1}
+		}
+		commands = append(commands, tick)
+	}
+	return commands
+}
+
+// sliderThumbCommands draws one thumb centred on center (absolute).
+func sliderThumbCommands(b *ui.SliderBase, center math.Vec2f32, hovered, focused bool, zIndex int) RenderCommandArray {
+	size := math.Vec2f32{X: b.ThumbSize, Y: b.ThumbSize}
+	pos := math.Vec2f32{X: center.X - b.ThumbSize/2, Y: center.Y - b.ThumbSize/2}
+
+	ring := b.AccentColor
+	if hovered {
+		ring = b.HoverColor
+	}
+	if b.Disabled {
+		ring = b.DisabledColor
+	}
+
+	var commands RenderCommandArray
+	if focused {
+		commands = append(commands, focusRing(pos, size, b.ThumbSize/2, b.FocusColor, zIndex))
+	}
+	return append(commands, RenderCommand{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             pos,
+		Size:            size,
+		BackgroundColor: b.ThumbColor,
+		BorderWidth:     math.Vec2f32{X: 2, Y: 2},
+		BorderColor:     ring,
+		BorderRadius:    b.ThumbSize / 2,
+		ZIndex:          zIndex,
+	})
+}
+
+// sliderLabelCommand draws the value label after a horizontal track or
+// below a vertical one.
+func (app *App) sliderLabelCommand(b *ui.SliderBase, label string, origin, content math.Vec2f32, zIndex int) RenderCommand {
+	textColor := b.TextColor
+	if b.Disabled {
+		textColor = b.DisabledColor
+	}
+	pos := math.Vec2f32{X: origin.X + b.Length + ui.SliderValueGap, Y: origin.Y + (content.Y-b.FontSize)/2}
+	if b.IsVertical() {
+		width := app.le.CalculateTextWidth(label, b.FontSize)
+		pos = math.Vec2f32{X: origin.X + (content.X-width)/2, Y: origin.Y + b.Length + ui.SliderValueGap}
+	}
+	return labelCommand(label, pos, b.FontSize, textColor, zIndex)
+}
+
+func (app *App) sliderCommands(s *ui.Slider, zIndex int) RenderCommandArray {
+	b := s.Geometry()
+	origin, content := contentBox(s)
+	commands := sliderTrackCommands(b, origin, content, b.Min, s.Value, zIndex)
+
+	center := b.ThumbCenter(s.Value, b.TrackContent(content))
+	center.Add(origin)
+	commands = append(commands, sliderThumbCommands(b, center, s.IsMouseOver, s.IsFocused, zIndex+1)...)
+
+	if b.ShowValue {
+		commands = append(commands, app.sliderLabelCommand(b, s.ValueLabel(), origin, content, zIndex+1))
+	}
+	return commands
+}
+
+func (app *App) rangeSliderCommands(r *ui.RangeSlider, zIndex int) RenderCommandArray {
+	b := r.Geometry()
+	origin, content := contentBox(r)
+	commands := sliderTrackCommands(b, origin, content, r.Low, r.High, zIndex)
+
+	// The active thumb is drawn last so it stays on top when they overlap.
+	for layer, i := range []int{1 - r.ActiveThumb, r.ActiveThumb} {
+		center := b.ThumbCenter(r.Thumb(i), b.TrackContent(content))
+		center.Add(origin)
+		hovered := r.HoveredThumb == i || r.DraggedThumb == i
+		commands = append(commands, sliderThumbCommands(b, center, hovered, r.IsFocused && r.ActiveThumb == i, zIndex+1+layer)...)
+	}
+
+	if b.ShowValue {
+		commands = append(commands, app.sliderLabelCommand(b, r.ValueLabel(), origin, content, zIndex+1))
+	}
+	return commands
+}
@@ -1,7 +1,7 @@
 package app
 
 /*
-#cgo LDFLAGS: -L../renderer/lib/Release -lrenderer -lglfw3 -lgdi32 -static
+#cgo LDFLAGS: -L../renderer/lib/Release -lglfw3 -lgdi32 -static
 #include "../renderer/include/renderer.h"
 #include <stdlib.h>
 */
@@ -11,6 +11,8 @@ import (
 	"unsafe"
 
 	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/geometry"
+	"github.com/aj-2000/mogi/internal/bidi"
 	"github.com/aj-2000/mogi/math"
 )
 
@@ -18,6 +20,7 @@ type renderer struct {
 	ptr            unsafe.Pointer
 	fontManager    *fontManager
 	textureManager *textureManager
+	spriteManager  *spriteManager
 }
 
 func newRenderer(width, height int, title string) *renderer {
@@ -31,6 +34,7 @@ func newRenderer(width, height int, title string) *renderer {
 		ptr:            ptr,
 		fontManager:    NewFontManager(),
 		textureManager: NewTextureManager(),
+		spriteManager:  NewSpriteManager(),
 	}
 }
 
@@ -71,14 +75,125 @@ func (r *renderer) drawRectangle(pos, size math.Vec2f32, backgroundColor color.R
 	)
 }
 
+func (r *renderer) drawLine(start, end math.Vec2f32, color color.RGBA, thickness float32) {
+	cLine := C.Line{
+		start: C.Vec2{x: C.float(start.X), y: C.float(start.Y)},
+		end:   C.Vec2{x: C.float(end.X), y: C.float(end.Y)},
+	}
+	C.draw_line_thick(r.ptr, cLine, goColorToCColorRGBA(color), C.float(thickness))
+}
+
+// cPoints converts points for the C renderer. The result holds no Go
+// pointers, so it can be passed to C directly.
+func cPoints(points []math.Vec2f32) []C.Vec2 {
+	out := make([]C.Vec2, len(points))
+	for i, p := range points {
+		out[i] = C.Vec2{x: C.float(p.X), y: C.float(p.Y)}
+	}
+	return out
+}
+
+func (r *renderer) drawPolyline(points []math.Vec2f32, color color.RGBA, thickness float32) {
+	if len(points) < 2 {
+		return
+	}
+	cp := cPoints(points)
+	C.draw_polyline(r.ptr, &cp[0], C.int(len(cp)), goColorToCColorRGBA(color), C.float(thickness))
+}
+
+func (r *renderer) drawPolygon(points []math.Vec2f32, color color.RGBA) {
+	if len(points) < 3 {
+		return
+	}
+	cp := cPoints(points)
+	C.draw_polygon_filled(r.ptr, &cp[0], C.int(len(cp)), goColorToCColorRGBA(color))
+}
+
+func (r *renderer) drawTriangles(vertices []geometry.Vertex, color color.RGBA) {
+	if len(vertices) < 3 {
+		return
+	}
+	cv := make([]C.MeshVertex, len(vertices))
+	for i, v := range vertices {
+		cv[i] = C.MeshVertex{position: C.Vec2{x: C.float(v.Pos.X), y: C.float(v.Pos.Y)}, alpha: C.float(v.Alpha)}
+	}
+	C.draw_triangles(r.ptr, &cv[0], C.int(len(cv)), goColorToCColorRGBA(color))
+}
+
+func (r *renderer) drawColoredTriangles(vertices []geometry.ColorVertex) {
+	if len(vertices) < 3 {
+		return
+	}
+	cv := make([]C.ColorVertex, len(vertices))
+	for i, v := range vertices {
+		cv[i] = C.ColorVertex{position: C.Vec2{x: C.float(v.Pos.X), y: C.float(v.Pos.Y)}, color: goColorToCColorRGBA(v.Color)}
+	}
+	C.draw_colored_triangles(r.ptr, &cv[0], C.int(len(cv)))
+}
+
+// drawGradientRectangle is drawRectangle with the inside of the border
+// filled with a gradient.
+func (r *renderer) drawGradientRectangle(pos, size math.Vec2f32, gradient *color.Gradient, borderWidth math.Vec2f32, borderColor color.RGBA, radius float32) {
+	if borderWidth.X > 0 || borderWidth.Y > 0 {
+		r.drawRectangle(pos, size, borderColor, math.Vec2f32{}, color.Transparent, radius)
+	}
+	inner := math.Vec2f32{X: pos.X + borderWidth.X, Y: pos.Y + borderWidth.Y}
+	innerSize := math.Vec2f32{X: size.X - 2*borderWidth.X, Y: size.Y - 2*borderWidth.Y}
+	if innerSize.X <= 0 || innerSize.Y <= 0 {
+		return
+	}
+	innerRadius := max(0, radius-max(borderWidth.X, borderWidth.Y))
+	shape := geometry.RoundedRect(inner, innerSize, innerRadius, geometry.CornerSegments(innerRadius))
+	r.drawColoredTriangles(geometry.GradientFill(shape, inner, innerSize, gradient).Vertices)
+}
+
+// drawShadows draws the outer or the inset shadows of a rectangle command.
+// Inset shadows are cast inside the border.
+func (r *renderer) drawShadows(command RenderCommand, inset bool) {
+	for _, s := range command.Shadows {
+		if s.Inset != inset || s.Color.A <= 0 {
+			continue
+		}
+		pos, size, radius := command.Pos, command.Size, command.BorderRadius
+		if inset {
+			border := command.BorderWidth
+			pos = math.Vec2f32{X: pos.X + border.X, Y: pos.Y + border.Y}
+			size = math.Vec2f32{X: size.X - 2*border.X, Y: size.Y - 2*border.Y}
+			radius = max(0, radius-max(border.X, border.Y))
+			if size.X <= 0 || size.Y <= 0 {
+				continue
+			}
+		}
+		mesh := geometry.BoxShadow(pos, size, radius, geometry.Shadow{Offset: s.Offset, Blur: s.Blur, Spread: s.Spread, Inset: s.Inset})
+		r.drawTriangles(mesh.Vertices, s.Color)
+	}
+}
+
+func (r *renderer) drawCircle(center math.Vec2f32, radius float32, color color.RGBA) {
+	cCircle := C.Circle{
+		position: C.Vec2{x: C.float(center.X), y: C.float(center.Y)},
+		radius:   C.float(radius),
+	}
+	C.draw_circle_filled(r.ptr, cCircle, goColorToCColorRGBA(color))
+}
+
 // should we expose this to public?
-func (r *renderer) drawTexture(textureID C.GLuint, pos, size math.Vec2f32) {
+func (r *renderer) drawTexture(textureID C.GLuint, pos, size math.Vec2f32, tint color.RGBA) {
+	cRect := C.Rect{
+		position: C.Vec2{x: C.float(pos.X), y: C.float(pos.Y)},
+		width:    C.float(size.X),
+		height:   C.float(size.Y),
+	}
+	C.draw_texture(r.ptr, textureID, cRect, goColorToCColorRGBA(tint))
+}
+
+func (r *renderer) drawSprite(s sprite, pos, size math.Vec2f32, tint color.RGBA) {
 	cRect := C.Rect{
 		position: C.Vec2{x: C.float(pos.X), y: C.float(pos.Y)},
 		width:    C.float(size.X),
 		height:   C.float(size.Y),
 	}
-	C.draw_texture(r.ptr, textureID, cRect, goColorToCColorRGBA(color.White))
+	C.draw_texture_region(r.ptr, s.texture, cRect, s.uv, goColorToCColorRGBA(tint))
 }
 
 func (r *renderer) loadTextureFromMemory(data []byte, w, h, ch int) C.GLuint {
@@ -93,8 +208,8 @@ func (r *renderer) loadTextureFromMemory(data []byte, w, h, ch int) C.GLuint {
 	return tex
 }
 
-func (r *renderer) clear() {
-	C.clear_screen(r.ptr, goColorToCColorRGBA(color.Transparent))
+func (r *renderer) clear(background color.RGBA) {
+	C.clear_screen(r.ptr, goColorToCColorRGBA(background))
 }
 
 func (r *renderer) present() {
@@ -110,6 +225,10 @@ func (r *renderer) getWindowSize() math.Vec2f32 {
 	return math.Vec2f32{X: float32(sz.x), Y: float32(sz.y)}
 }
 
+func (r *renderer) isKeyDown(key Key) bool {
+	return C.is_key_pressed(r.ptr, C.int(key)) != 0
+}
+
 func (r *renderer) windowShouldClose() bool {
 	return C.window_should_close(r.ptr) != 0
 }
@@ -118,11 +237,14 @@ func (r *renderer) destroy() {
 	C.destroy_renderer(r.ptr)
 	r.fontManager.destroy()
 	r.textureManager.destroy()
+	r.spriteManager.destroy()
 	r.ptr = nil
 }
 
+// calculateTextWidth measures text as it will be drawn, i.e. with Arabic
+// letters in their contextual forms. Reordering does not change the width.
 func (r *renderer) calculateTextWidth(fontData *FontData, text string) float32 {
-	cText := C.CString(text)
+	cText := C.CString(bidi.Shape(text))
 	defer C.free(unsafe.Pointer(cText))
 	return float32(C.calculate_text_width(fontData, cText))
 }
@@ -136,6 +258,26 @@ func (r *renderer) getMousePos() math.Vec2f32 {
 	return math.Vec2f32{X: float32(pos.x), Y: float32(pos.y)}
 }
 
+// takeScrollDelta returns the wheel movement since the last call; positive
+// Y scrolls up.
+func (r *renderer) takeScrollDelta() math.Vec2f32 {
+	delta := C.take_scroll_delta(r.ptr)
+	return math.Vec2f32{X: float32(delta.x), Y: float32(delta.y)}
+}
+
+// setClip restricts drawing to a rectangle until clearClip.
+func (r *renderer) setClip(pos, size math.Vec2f32) {
+	C.set_clip_rect(r.ptr, C.Rect{
+		position: C.Vec2{x: C.float(pos.X), y: C.float(pos.Y)},
+		width:    C.float(size.X),
+		height:   C.float(size.Y),
+	})
+}
+
+func (r *renderer) clearClip() {
+	C.clear_clip_rect(r.ptr)
+}
+
 func (r *renderer) IsMousePressed(button int) bool {
 	return C.is_mouse_button_pressed(r.ptr, C.int(button)) != 0
 }
@@ -143,3 +285,36 @@ func (r *renderer) IsMousePressed(button int) bool {
 func (r *renderer) IsMouseReleased(button int) bool {
 	return C.is_mouse_button_released(r.ptr, C.int(button)) != 0
 }
+
+// setTransform draws everything with m until clearTransform.
+func (r *renderer) setTransform(m math.Mat3) {
+	affine := [6]C.float{
+		C.float(m[0][0]), C.float(m[0][1]), C.float(m[0][2]),
+		C.float(m[1][0]), C.float(m[1][1]), C.float(m[1][2]),
+	}
+	C.set_transform(r.ptr, &affine[0])
+}
+
+func (r *renderer) clearTransform() {
+	C.clear_transform(r.ptr)
+}
+
+// setMasks changes the clip mask stack from current to next, keeping the
+// masks at the bottom they share.
+func (r *renderer) setMasks(current, next []clipMask) {
+	shared := 0
+	for shared < len(current) && shared < len(next) && sameMask(current[shared], next[shared]) {
+		shared++
+	}
+	for range len(current) - shared {
+		C.pop_clip_mask(r.ptr)
+	}
+	for _, m := range next[shared:] {
+		outline := m.outline()
+		points := make([]C.Vec2, len(outline))
+		for i, p := range outline {
+			points[i] = C.Vec2{x: C.float(p.X), y: C.float(p.Y)}
+		}
+		C.push_clip_mask(r.ptr, &points[0], C.int(len(points)))
+	}
+}
@@ -0,0 +1,4 @@
+// The C renderer is compiled with the package rather than linked from a
+// prebuilt archive, so its entry points and struct layouts always match
+// renderer.h.
+#include "../renderer/renderer.c"
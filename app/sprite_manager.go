@@ -0,0 +1,101 @@
+package app
+
+/*
+#cgo LDFLAGS: -L../renderer/lib/Release -lglfw3 -lgdi32 -static
+#include "../renderer/include/renderer.h"
+*/
+import "C"
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aj-2000/mogi/atlas"
+)
+
+// sprite is an atlas entry resolved to the GL texture of its page.
+type sprite struct {
+	texture C.GLuint
+	uv      C.Rect
+}
+
+type spriteManager struct {
+	mu      sync.Mutex
+	sprites map[string]sprite
+	// pages holds the textures uploaded for each atlas key.
+	pages map[string][]C.GLuint
+}
+
+func NewSpriteManager() *spriteManager {
+	return &spriteManager{sprites: make(map[string]sprite), pages: make(map[string][]C.GLuint)}
+}
+
+// add uploads every page of the atlas and registers its entries by name.
+// key identifies the atlas in the texture cache; adding an atlas under a key
+// again replaces the pages uploaded before, so a rebuilt atlas is not drawn
+// from stale textures. Later atlases override entries with the same name.
+func (sm *spriteManager) add(tm *textureManager, key string, a *atlas.Atlas) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.release(tm, key)
+
+	textures := make([]C.GLuint, 0, len(a.Pages))
+	for i, page := range a.Pages {
+		tex, err := tm.loadImage(pageKey(key, i), page)
+		if err != nil {
+			sm.pages[key] = textures
+			sm.release(tm, key)
+			return err
+		}
+		textures = append(textures, tex)
+	}
+	sm.pages[key] = textures
+
+	for _, e := range a.Entries() {
+		u0, v0, u1, v1 := a.UV(e)
+		sm.sprites[e.Name] = sprite{
+			texture: textures[e.Page],
+			uv: C.Rect{
+				position: C.Vec2{x: C.float(u0), y: C.float(v0)},
+				width:    C.float(u1 - u0),
+				height:   C.float(v1 - v0),
+			},
+		}
+	}
+	return nil
+}
+
+// release frees the pages uploaded for key and forgets the entries drawn
+// from them.
+func (sm *spriteManager) release(tm *textureManager, key string) {
+	textures, ok := sm.pages[key]
+	if !ok {
+		return
+	}
+	for i, tex := range textures {
+		for name, s := range sm.sprites {
+			if s.texture == tex {
+				delete(sm.sprites, name)
+			}
+		}
+		tm.unload(pageKey(key, i))
+	}
+	delete(sm.pages, key)
+}
+
+func pageKey(key string, page int) string {
+	return fmt.Sprintf("%s#%d", key, page)
+}
+
+func (sm *spriteManager) lookup(name string) (sprite, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	s, ok := sm.sprites[name]
+	return s, ok
+}
+
+func (sm *spriteManager) destroy() {
+	sm.mu.Lock()
+	sm.sprites = nil
+	sm.pages = nil
+	sm.mu.Unlock()
+}
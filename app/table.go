@@ -0,0 +1,323 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/aj-2000/mogi/internal/bidi"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Tables
+// ——————————————————————————————————————————————————————————————————————————————
+
+// handleTableClick resizes a column from the end edge of its header, sorts by
+// a header when it is clicked, selects rows on press and turns pages from the
+// pager.
+func (app *App) handleTableClick(t *ui.Table, cursorPos math.Vec2f32, mouseDown bool) {
+	app.handleScrollbar(t, cursorPos, mouseDown)
+	origin, _ := contentBox(t)
+	point := t.LocalPoint(cursorPos)
+	local := math.Vec2f32{X: point.X - origin.X, Y: point.Y - origin.Y}
+	inside := t.IsPointInsideComponent(cursorPos) && !t.IsDraggingThumb
+
+	column, onResize, row := -1, false, -1
+	if inside {
+		column, onResize = t.HeaderAt(local)
+		row = t.RowAt(local)
+	}
+	if _, _, ok := t.Thumb(); ok && local.X >= t.ScrollbarX() && local.X <= t.ScrollbarX()+t.ScrollbarWidth {
+		row = -1
+	}
+	t.HoveredHeader, t.HoveredRow = column, row
+
+	if app.mouseJustPressed() && inside {
+		switch dir := t.PagerButtonAt(local); {
+		case dir != 0:
+			t.SetPageIndex(t.Page + dir)
+		case onResize:
+			t.StartResize(column, local.X)
+		case column >= 0:
+			t.PressedHeader = column
+		case row >= 0:
+			app.focus(t)
+			t.SelectRow(row, app.shiftDown(), app.ctrlDown())
+		}
+	}
+
+	if t.ResizingColumn >= 0 {
+		if mouseDown {
+			t.ResizeTo(local.X)
+		} else {
+			t.ResizingColumn = -1
+		}
+	}
+	// Sorting fires on release over the same header, like a button.
+	if t.PressedHeader >= 0 && !mouseDown {
+		if column == t.PressedHeader && !onResize {
+			t.ToggleSort(column)
+		}
+		t.PressedHeader = -1
+	}
+}
+
+// handleTableKeys moves the cursor row with the arrow keys, Page Up/Down and
+// Home/End, extending a multiple selection while Shift is held; Space toggles
+// the cursor row in a multiple selection. Page Up/Down turn the page of a
+// paged table instead.
+func (app *App) handleTableKeys(t *ui.Table) {
+	rows := len(t.DisplayRows())
+	if rows == 0 {
+		return
+	}
+	cursor := t.DisplayRowIndex(t.Cursor)
+	extend := app.shiftDown()
+	moveTo := func(r int) {
+		t.SelectRow(max(0, min(r, rows-1)), extend, false)
+	}
+	switch {
+	case t.PageSize > 0 && app.IsKeyPressed(KeyPageDown):
+		t.SetPageIndex(t.Page + 1)
+	case t.PageSize > 0 && app.IsKeyPressed(KeyPageUp):
+		t.SetPageIndex(t.Page - 1)
+	case app.IsKeyPressed(KeyHome):
+		moveTo(0)
+	case app.IsKeyPressed(KeyEnd):
+		moveTo(rows - 1)
+	case cursor < 0:
+		if app.IsKeyPressed(KeyDown) || app.IsKeyPressed(KeyUp) {
+			moveTo(0)
+		}
+	case app.IsKeyPressed(KeyDown):
+		moveTo(cursor + 1)
+	case app.IsKeyPressed(KeyUp):
+		moveTo(cursor - 1)
+	case app.IsKeyPressed(KeyPageDown):
+		moveTo(cursor + 10)
+	case app.IsKeyPressed(KeyPageUp):
+		moveTo(cursor - 10)
+	case app.IsKeyPressed(KeySpace):
+		t.SelectRow(cursor, false, t.MultiSelect)
+	}
+}
+
+// tableCommands draws the background of each row in view, striped, hovered
+// or selected, the header above them with each column's title and sort arrow,
+// and the footer below them with the aggregates and the pager. The cells are
+// children and render themselves.
+func (app *App) tableCommands(t *ui.Table, zIndex int) RenderCommandArray {
+	pos, size := t.AbsolutePos(), t.Size()
+	origin, content := contentBox(t)
+	bodyPos, bodySize, _ := clipBox(t)
+	headerHeight := t.HeaderHeight()
+
+	commands := RenderCommandArray{{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             pos,
+		Size:            size,
+		BackgroundColor: t.BackgroundColor(),
+		BorderWidth:     t.Border(),
+		BorderColor:     t.BorderColor(),
+		BorderRadius:    t.BorderRadius(),
+		ZIndex:          zIndex,
+	}}
+
+	var rows RenderCommandArray
+	for r := range t.DisplayRows() {
+		y, height := t.RowRect(r)
+		top := bodyPos.Y + y - t.Offset
+		if top+height < bodyPos.Y || top > bodyPos.Y+bodySize.Y {
+			continue
+		}
+		rowPos := math.Vec2f32{X: bodyPos.X, Y: top}
+		rowSize := math.Vec2f32{X: bodySize.X, Y: height}
+		key := t.DisplayRowKey(r)
+		background := t.RowColor
+		switch {
+		case t.IsSelected(key):
+			background = t.SelectedColor
+		case t.HoveredRow == r:
+			background = t.HoverColor
+		case t.Striped && r%2 == 1:
+			background = t.StripeColor
+		}
+		rows = append(rows, RenderCommand{Kind: RenderCommandDrawRectangle, Pos: rowPos, Size: rowSize, BackgroundColor: background, ZIndex: zIndex})
+		if t.IsFocused && key == t.Cursor {
+			rows = append(rows, RenderCommand{
+				Kind:        RenderCommandDrawRectangle,
+				Pos:         rowPos,
+				Size:        rowSize,
+				BorderWidth: math.Vec2f32{X: 1, Y: 1},
+				BorderColor: t.FocusColor,
+				ZIndex:      zIndex + 1,
+			})
+		}
+	}
+	clipCommands(rows, bodyPos, bodySize)
+	commands = append(commands, rows...)
+
+	commands = append(commands, RenderCommand{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             origin,
+		Size:            math.Vec2f32{X: content.X, Y: headerHeight},
+		BackgroundColor: t.HeaderColor,
+		ZIndex:          zIndex + 1,
+	}, RenderCommand{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             math.Vec2f32{X: origin.X, Y: origin.Y + headerHeight - 1},
+		Size:            math.Vec2f32{X: content.X, Y: 1},
+		BackgroundColor: t.GridColor,
+		ZIndex:          zIndex + 2,
+	})
+	for c, column := range t.Columns {
+		commands = append(commands, app.tableHeaderCommands(t, c, column, origin, zIndex+2)...)
+	}
+
+	footerTop := bodyPos.Y + bodySize.Y
+	if t.HasAggregates() {
+		commands = append(commands, tableBarCommands(t, math.Vec2f32{X: origin.X, Y: footerTop}, content.X, zIndex+1)...)
+		for c, column := range t.Columns {
+			text := bidi.Visual(t.AggregateText(c), bidi.Auto)
+			if text == "" {
+				continue
+			}
+			x, width := t.ColumnRect(c)
+			cellPos := math.Vec2f32{X: origin.X + x, Y: footerTop}
+			textX := cellTextX(column.Align, t.IsRTL(), cellPos.X+ui.TableCellPaddingX, cellPos.X+width-ui.TableCellPaddingX, app.le.CalculateTextWidth(text, t.FontSize))
+			label := labelCommand(text, math.Vec2f32{X: textX, Y: footerTop + ui.TableCellPaddingY}, t.FontSize, t.HeaderTextColor, zIndex+3)
+			label.Clip, label.ClipPos, label.ClipSize = true, cellPos, math.Vec2f32{X: width, Y: headerHeight}
+			commands = append(commands, label)
+		}
+		footerTop += headerHeight
+	}
+	if t.PageSize > 0 {
+		commands = append(commands, app.pagerCommands(t, math.Vec2f32{X: origin.X, Y: footerTop}, content.X, zIndex+1)...)
+	}
+
+	commands = append(commands, scrollbarCommands(t, zIndex+3)...)
+	return commands
+}
+
+// tableBarCommands draws a footer row: a strip in the header color with a
+// line along its top.
+func tableBarCommands(t *ui.Table, pos math.Vec2f32, width float32, zIndex int) RenderCommandArray {
+	return RenderCommandArray{
+		{Kind: RenderCommandDrawRectangle, Pos: pos, Size: math.Vec2f32{X: width, Y: t.HeaderHeight()}, BackgroundColor: t.HeaderColor, ZIndex: zIndex},
+		{Kind: RenderCommandDrawRectangle, Pos: pos, Size: math.Vec2f32{X: width, Y: 1}, BackgroundColor: t.GridColor, ZIndex: zIndex + 1},
+	}
+}
+
+// pagerCommands draws the pager: which page is shown at the start and the
+// previous and next page buttons at the end. A button that cannot turn the
+// page is drawn in the grid color.
+func (app *App) pagerCommands(t *ui.Table, pos math.Vec2f32, width float32, zIndex int) RenderCommandArray {
+	side := t.HeaderHeight()
+	commands := tableBarCommands(t, pos, width, zIndex)
+
+	text := fmt.Sprintf("Page %d of %d, %d rows", t.Page+1, t.PageCount(), t.MatchCount())
+	left, right := pos.X+ui.TableCellPaddingX, pos.X+width-2*side-ui.TableCellPaddingX
+	if t.IsRTL() {
+		left, right = pos.X+2*side+ui.TableCellPaddingX, pos.X+width-ui.TableCellPaddingX
+	}
+	textX := cellTextX(ui.TextAlignStart, t.IsRTL(), left, right, app.le.CalculateTextWidth(text, t.FontSize))
+	commands = append(commands, labelCommand(text, math.Vec2f32{X: textX, Y: pos.Y + ui.TableCellPaddingY}, t.FontSize, t.HeaderTextColor, zIndex+2))
+
+	prev, next := t.PagerButtons()
+	prevColor, nextColor := t.HeaderTextColor, t.HeaderTextColor
+	if t.Page == 0 {
+		prevColor = t.GridColor
+	}
+	if t.Page >= t.PageCount()-1 {
+		nextColor = t.GridColor
+	}
+	// The previous button points towards the start edge.
+	dir := float32(1)
+	if t.IsRTL() {
+		dir = -1
+	}
+	button := math.Vec2f32{X: side, Y: side}
+	commands = append(commands, scrollButtonCommands(math.Vec2f32{X: pos.X + prev, Y: pos.Y}, button, -dir, t.HeaderColor, prevColor, zIndex+1)...)
+	commands = append(commands, scrollButtonCommands(math.Vec2f32{X: pos.X + next, Y: pos.Y}, button, dir, t.HeaderColor, nextColor, zIndex+1)...)
+	return commands
+}
+
+// cellTextX returns the x of text of the given width aligned between left
+// and right.
+func cellTextX(align ui.TextAlign, rtl bool, left, right, width float32) float32 {
+	switch align.Resolve(rtl) {
+	case ui.TextAlignCenter:
+		return left + (right-left-width)/2
+	case ui.TextAlignRight:
+		return right - width
+	}
+	return left
+}
+
+// tableHeaderCommands draws the header cell of column c: its title, aligned
+// like the column and clipped to it, the sort arrow of the sorted column and
+// a divider on the edge that resizes it.
+func (app *App) tableHeaderCommands(t *ui.Table, c int, column *ui.TableColumn, origin math.Vec2f32, zIndex int) RenderCommandArray {
+	x, width := t.ColumnRect(c)
+	headerHeight := t.HeaderHeight()
+	cellPos := math.Vec2f32{X: origin.X + x, Y: origin.Y}
+	cellSize := math.Vec2f32{X: width, Y: headerHeight}
+	rtl := t.IsRTL()
+
+	var commands RenderCommandArray
+	if column.Sortable && (t.HoveredHeader == c || t.PressedHeader == c) && t.ResizingColumn < 0 {
+		commands = append(commands, RenderCommand{Kind: RenderCommandDrawRectangle, Pos: cellPos, Size: cellSize, BackgroundColor: t.HoverColor, ZIndex: zIndex})
+	}
+
+	// The title goes in the cell's padding box, less the sort arrow's
+	// space at the end edge.
+	left, right := cellPos.X+ui.TableCellPaddingX, cellPos.X+width-ui.TableCellPaddingX
+	arrowX := right - t.SortIndicatorWidth()/2
+	if column.Sortable {
+		if rtl {
+			arrowX = left + t.SortIndicatorWidth()/2
+			left += t.SortIndicatorWidth()
+		} else {
+			right -= t.SortIndicatorWidth()
+		}
+	}
+	title := bidi.Visual(column.Title, bidi.Auto)
+	titleWidth := app.le.CalculateTextWidth(title, t.FontSize)
+	titleX := cellTextX(column.Align, rtl, left, right, titleWidth)
+	label := labelCommand(title, math.Vec2f32{X: titleX, Y: cellPos.Y + ui.TableCellPaddingY}, t.FontSize, t.HeaderTextColor, zIndex+1)
+	label.Clip, label.ClipPos, label.ClipSize = true, cellPos, cellSize
+	commands = append(commands, label)
+
+	if column.Sortable && c == t.SortColumn && t.SortDir != ui.SortNone {
+		half := t.FontSize * 0.2
+		mid := cellPos.Y + headerHeight/2
+		dir := float32(1) // the tip points down for descending
+		if t.SortDir == ui.SortAscending {
+			dir = -1
+		}
+		tip := math.Vec2f32{X: arrowX, Y: mid + dir*half/2}
+		commands = append(commands,
+			RenderCommand{Kind: RenderCommandDrawLine, Pos: math.Vec2f32{X: arrowX - half, Y: mid - dir*half/2}, End: tip, Thickness: 1.5, Color: t.HeaderTextColor, ZIndex: zIndex + 1},
+			RenderCommand{Kind: RenderCommandDrawLine, Pos: tip, End: math.Vec2f32{X: arrowX + half, Y: mid - dir*half/2}, Thickness: 1.5, Color: t.HeaderTextColor, ZIndex: zIndex + 1},
+		)
+	}
+
+	if column.Resizable {
+		edge := cellPos.X + width - 1
+		if rtl {
+			edge = cellPos.X
+		}
+		divider := t.GridColor
+		if t.ResizingColumn == c {
+			divider = t.FocusColor
+		}
+		commands = append(commands, RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             math.Vec2f32{X: edge, Y: cellPos.Y + 4},
+			Size:            math.Vec2f32{X: 1, Y: headerHeight - 8},
+			BackgroundColor: divider,
+			ZIndex:          zIndex + 1,
+		})
+	}
+	return commands
+}
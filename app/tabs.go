@@ -0,0 +1,211 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/internal/bidi"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Tabs
+// ——————————————————————————————————————————————————————————————————————————————
+
+// handleTabsClick activates a tab when its header is pressed, closes it from
+// its close button, drags it to reorder and scrolls an overflowing strip.
+func (app *App) handleTabsClick(t *ui.Tabs, cursorPos math.Vec2f32, mouseDown, mouseReleased bool) {
+	origin, _ := contentBox(t)
+	point := t.LocalPoint(cursorPos)
+	local := math.Vec2f32{X: point.X - origin.X, Y: point.Y - origin.Y}
+	inside := t.IsPointInsideComponent(cursorPos)
+	visible := t.VisibleTabs()
+
+	hovered, onClose := -1, false
+	if inside {
+		hovered, onClose = t.HeaderAt(local)
+	}
+	if hovered >= 0 && t.Tabs[visible[hovered]].Disabled {
+		hovered, onClose = -1, false
+	}
+	t.HoveredTab = hovered
+	t.HoveredClose = -1
+	if onClose {
+		t.HoveredClose = hovered
+	}
+
+	if app.mouseJustPressed() && inside && local.Y >= 0 && local.Y <= t.StripHeight() {
+		if dir := t.ScrollButtonAt(local.X); dir != 0 {
+			t.ScrollBy(dir)
+		} else if hovered >= 0 {
+			app.focus(t)
+			if onClose {
+				t.PressedClose = hovered
+			} else {
+				t.PressedTab = hovered
+				t.Activate(visible[hovered])
+			}
+		}
+	}
+
+	// A dragged header moves once the pointer is over its new place.
+	if t.PressedTab >= 0 {
+		if !mouseDown {
+			t.PressedTab = -1
+		} else if t.Reorderable {
+			if to := t.DropTarget(t.PressedTab, local); to != t.PressedTab {
+				t.MoveTab(t.PressedTab, to)
+				t.PressedTab = to
+			}
+		}
+	}
+
+	// Closing fires on release, like a button.
+	if t.PressedClose >= 0 {
+		if t.HoveredClose != t.PressedClose {
+			t.PressedClose = -1
+		} else if mouseReleased {
+			index := visible[t.PressedClose]
+			t.PressedClose = -1
+			t.CloseTab(index)
+		}
+	}
+}
+
+// handleTabsKeys switches tabs with the arrow keys, Home and End, and closes
+// the active tab with Delete.
+func (app *App) handleTabsKeys(t *ui.Tabs) {
+	next, prev := KeyRight, KeyLeft
+	if t.IsRTL() {
+		next, prev = KeyLeft, KeyRight
+	}
+	switch {
+	case app.IsKeyPressed(next):
+		t.ActivateNext(1)
+	case app.IsKeyPressed(prev):
+		t.ActivateNext(-1)
+	case app.IsKeyPressed(KeyHome):
+		t.ActivateNext(-len(t.Tabs))
+	case app.IsKeyPressed(KeyEnd):
+		t.ActivateNext(len(t.Tabs))
+	case app.IsKeyPressed(KeyDelete):
+		t.CloseTab(t.Active)
+	}
+}
+
+// tabsCommands draws the header strip of a tabs component: a header per
+// visible tab, with an indicator under the active one, and the scroll
+// buttons of an overflowing strip. The active panel is a child and renders
+// itself.
+func (app *App) tabsCommands(t *ui.Tabs, zIndex int) RenderCommandArray {
+	pos, size := t.AbsolutePos(), t.Size()
+	origin, _ := contentBox(t)
+	stripHeight := t.StripHeight()
+
+	commands := RenderCommandArray{{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             pos,
+		Size:            size,
+		BackgroundColor: t.BackgroundColor(),
+		BorderWidth:     t.Border(),
+		BorderColor:     t.BorderColor(),
+		BorderRadius:    t.BorderRadius(),
+		ZIndex:          zIndex,
+	}}
+
+	closeSize := t.CloseSize()
+	closeTop := origin.Y + (stripHeight-closeSize)/2
+	for i, h := range t.Headers() {
+		x, width, ok := t.HeaderRect(i)
+		if !ok {
+			continue
+		}
+		tab := t.Tabs[h.Index]
+		headerPos := math.Vec2f32{X: origin.X + x, Y: origin.Y}
+		headerSize := math.Vec2f32{X: width, Y: stripHeight}
+		active := h.Index == t.Active
+
+		background := t.HeaderColor
+		switch {
+		case active:
+			background = t.ActiveColor
+		case t.HoveredTab == i:
+			background = t.HoverColor
+		}
+		textColor := t.TextColor
+		if tab.Disabled {
+			textColor = t.DisabledColor
+		}
+
+		if active && t.IsFocused {
+			commands = append(commands, focusRing(headerPos, headerSize, 0, t.FocusColor, zIndex))
+		}
+		commands = append(commands, RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             headerPos,
+			Size:            headerSize,
+			BackgroundColor: background,
+			ZIndex:          zIndex,
+		})
+		if active {
+			commands = append(commands, RenderCommand{
+				Kind:            RenderCommandDrawRectangle,
+				Pos:             math.Vec2f32{X: headerPos.X, Y: headerPos.Y + stripHeight - 2},
+				Size:            math.Vec2f32{X: width, Y: 2},
+				BackgroundColor: t.IndicatorColor,
+				ZIndex:          zIndex + 1,
+			})
+		}
+
+		titleX := headerPos.X + t.HeaderPadding.X
+		if closeX, closable := t.CloseRect(i); closable {
+			if t.IsRTL() {
+				titleX = headerPos.X + width - t.HeaderPadding.X - app.le.CalculateTextWidth(tab.Title, t.FontSize)
+			}
+			commands = append(commands, closeCommands(math.Vec2f32{X: origin.X + closeX, Y: closeTop}, closeSize, t.HoveredClose == i, t.HoverColor, textColor, zIndex+1)...)
+		}
+		title := bidi.Visual(tab.Title, bidi.Auto)
+		commands = append(commands, labelCommand(title, math.Vec2f32{X: titleX, Y: origin.Y + t.HeaderPadding.Y}, t.FontSize, textColor, zIndex+1))
+	}
+
+	if t.Overflowing() {
+		button := math.Vec2f32{X: t.ScrollButtonWidth(), Y: stripHeight}
+		commands = append(commands, scrollButtonCommands(origin, button, -1, t.HeaderColor, t.TextColor, zIndex+2)...)
+		end := math.Vec2f32{X: origin.X + t.StripWidth() - button.X, Y: origin.Y}
+		commands = append(commands, scrollButtonCommands(end, button, 1, t.HeaderColor, t.TextColor, zIndex+2)...)
+	}
+	return commands
+}
+
+// closeCommands draws a cross in a square, on a highlight when hovered.
+func closeCommands(pos math.Vec2f32, side float32, hovered bool, hoverColor, lineColor color.RGBA, zIndex int) RenderCommandArray {
+	var commands RenderCommandArray
+	if hovered {
+		commands = append(commands, RenderCommand{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             math.Vec2f32{X: pos.X - 2, Y: pos.Y - 2},
+			Size:            math.Vec2f32{X: side + 4, Y: side + 4},
+			BackgroundColor: hoverColor,
+			BorderRadius:    2,
+			ZIndex:          zIndex,
+		})
+	}
+	end := math.Vec2f32{X: pos.X + side, Y: pos.Y + side}
+	return append(commands,
+		RenderCommand{Kind: RenderCommandDrawLine, Pos: pos, End: end, Thickness: 1.5, Color: lineColor, ZIndex: zIndex + 1},
+		RenderCommand{Kind: RenderCommandDrawLine, Pos: math.Vec2f32{X: pos.X, Y: end.Y}, End: math.Vec2f32{X: end.X, Y: pos.Y}, Thickness: 1.5, Color: lineColor, ZIndex: zIndex + 1},
+	)
+}
+
+// scrollButtonCommands draws a button with a chevron pointing left (dir -1)
+// or right (dir 1).
+func scrollButtonCommands(pos, size math.Vec2f32, dir float32, background, lineColor color.RGBA, zIndex int) RenderCommandArray {
+	half := size.Y * 0.15
+	mid := math.Vec2f32{X: pos.X + size.X/2, Y: pos.Y + size.Y/2}
+	tip := math.Vec2f32{X: mid.X + dir*half/2, Y: mid.Y}
+	back := mid.X - dir*half/2
+	return RenderCommandArray{
+		{Kind: RenderCommandDrawRectangle, Pos: pos, Size: size, BackgroundColor: background, ZIndex: zIndex},
+		{Kind: RenderCommandDrawLine, Pos: math.Vec2f32{X: back, Y: mid.Y - half}, End: tip, Thickness: 1.5, Color: lineColor, ZIndex: zIndex + 1},
+		{Kind: RenderCommandDrawLine, Pos: tip, End: math.Vec2f32{X: back, Y: mid.Y + half}, Thickness: 1.5, Color: lineColor, ZIndex: zIndex + 1},
+	}
+}
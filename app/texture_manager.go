@@ -1,13 +1,14 @@
 package app
 
 /*
-#cgo LDFLAGS: -L../renderer/lib/Release -lrenderer -lglfw3 -lgdi32 -static
+#cgo LDFLAGS: -L../renderer/lib/Release -lglfw3 -lgdi32 -static
 #include "../renderer/include/renderer.h"
 #include <stdlib.h>
 */
 import "C"
 import (
 	"fmt"
+	"image"
 	"sync"
 	"unsafe"
 )
@@ -36,6 +37,27 @@ func (tm *textureManager) load(path string) (C.GLuint, error) {
 		return 0, fmt.Errorf("failed to load texture: %s", path)
 
 	}
+	tm.cache[path] = tex
+	return tex, nil
+}
+
+// loadImage uploads an in-memory RGBA image and caches it under key.
+func (tm *textureManager) loadImage(key string, img *image.NRGBA) (C.GLuint, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if tex, ok := tm.cache[key]; ok {
+		return tex, nil
+	}
+
+	bounds := img.Bounds()
+	if len(img.Pix) == 0 {
+		return 0, fmt.Errorf("failed to load texture: %s is empty", key)
+	}
+	tex := C.load_texture_from_memory((*C.uchar)(unsafe.Pointer(&img.Pix[0])), C.int(bounds.Dx()), C.int(bounds.Dy()), 4)
+	if tex == 0 {
+		return 0, fmt.Errorf("failed to load texture: %s", key)
+	}
+	tm.cache[key] = tex
 	return tex, nil
 }
 
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/internal/ui"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Theme
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Theme returns the theme the tree is built with.
+func (app *App) Theme() ui.Theme { return app.theme }
+
+// SetTheme switches the theme from the next frame on, when the tree is
+// built again with it. Switching between ui.DarkTheme and ui.LightTheme
+// toggles dark mode.
+func (app *App) SetTheme(theme ui.Theme) {
+	app.theme = theme
+}
+
+// WithTheme builds a subtree with theme instead of the app's, for a part of
+// the window themed apart from the rest. Start from app.Theme() to override
+// only some tokens.
+func (app *App) WithTheme(theme ui.Theme, build func() ui.IComponent) ui.IComponent {
+	return ui.WithTheme(theme, build)
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Tooltips
+// ——————————————————————————————————————————————————————————————————————————————
+
+// tooltipTarget returns the topmost component with a tooltip under point.
+// Later children are drawn above earlier ones, so they are checked first.
+func tooltipTarget(comp ui.IComponent, point math.Vec2f32) ui.IComponent {
+	if comp == nil || comp.Display() == ui.DisplayNone {
+		return nil
+	}
+	children := comp.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if target := tooltipTarget(children[i], point); target != nil {
+			return target
+		}
+	}
+	if comp.Tooltip() != nil && comp.IsPointInsideComponent(point) {
+		return comp
+	}
+	return nil
+}
+
+// trackTooltipHover updates the hover time of every component with a
+// tooltip in the tree and returns how long target has been hovered.
+func (app *App) trackTooltipHover(comp, target ui.IComponent, elapsed float64) float64 {
+	if comp == nil || comp.Display() == ui.DisplayNone {
+		return elapsed
+	}
+	if comp.Tooltip() != nil {
+		if d := app.le.TrackHover(comp.FullID(), comp == target, app.totalTime); comp == target {
+			elapsed = d
+		}
+	}
+	for _, child := range comp.Children() {
+		elapsed = app.trackTooltipHover(child, target, elapsed)
+	}
+	return elapsed
+}
+
+// showTooltip finds the component with a tooltip under the cursor in the
+// topmost tree there and, once it has been hovered for the tooltip's delay,
+// adds the tooltip as an overlay. Tooltips are added after input handling,
+// so they never take the pointer. Pressing a mouse button hides them.
+func (app *App) showTooltip(root ui.IComponent, windowSize math.Vec2f32) {
+	cursorPos := app.renderer.getMousePos()
+	var target ui.IComponent
+	if !app.renderer.IsMousePressed(0) {
+		if o := app.overlayAt(cursorPos); o != nil {
+			target = tooltipTarget(o.root, cursorPos)
+		} else {
+			target = tooltipTarget(root, cursorPos)
+		}
+	}
+
+	elapsed := app.trackTooltipHover(root, target, 0)
+	for _, o := range app.overlays {
+		elapsed = app.trackTooltipHover(o.root, target, elapsed)
+	}
+	if target == nil {
+		return
+	}
+	tooltip := target.Tooltip()
+	if elapsed < float64(tooltip.Delay) {
+		return
+	}
+	bubble := app.layoutPopup(tooltip.Bubble(), target, tooltip.Placement, ui.PopupAlignCenter, windowSize)
+	// The bubble is built after the other trees saved their state, so it
+	// saves its own, such as how far a spinner in it has turned.
+	app.le.CopyStateFromComponentsRecursive(bubble)
+	app.overlays = append(app.overlays, overlay{root: bubble, owner: target})
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Transforms
+// ——————————————————————————————————————————————————————————————————————————————
+
+// transformCommands draws the first own of commands, the component's own,
+// with m, its absolute transform. Clips set on any of the commands by the
+// component are in its laid out space too, so they and its masks take m as
+// well; those set by transformed children already have theirs.
+func transformCommands(commands RenderCommandArray, own int, m math.Mat3) {
+	if m.IsIdentity() {
+		return
+	}
+	for i := range commands {
+		c := &commands[i]
+		if i < own {
+			c.Transform = &m
+		}
+		if c.Clip && c.ClipTransform == nil {
+			c.ClipTransform = &m
+		}
+		for j := range c.Masks {
+			if c.Masks[j].Transform == nil {
+				c.Masks[j].Transform = &m
+			}
+		}
+	}
+}
+
+// sameTransform reports whether two command transforms, nil for none, are
+// equal.
+func sameTransform(a, b *math.Mat3) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// screenClip returns the screen rectangle a command's clip covers. The
+// renderer can only clip to upright rectangles, so a turned clip is
+// widened to the box around it.
+func screenClip(c RenderCommand) (math.Vec2f32, math.Vec2f32) {
+	if c.ClipTransform == nil {
+		return c.ClipPos, c.ClipSize
+	}
+	clip := c.ClipTransform.ApplyRect(math.Rect{Pos: c.ClipPos, Size: c.ClipSize})
+	return clip.Pos, clip.Size
+}
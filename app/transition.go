@@ -0,0 +1,49 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/internal/ui"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Animation
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Animate returns a value keyed by id as it moves towards target by
+// transition t, advanced by the frame's delta time. Call it once a frame
+// while building the tree, for animating anything that is not a component
+// property with a transition of its own.
+func (app *App) Animate(id string, target float32, t *ui.Transition) float32 {
+	return app.le.Animate(id, target, t, app.deltaTime)
+}
+
+// AnimateColor is Animate for colors, which are mixed with color.RGBA.Lerp.
+func (app *App) AnimateColor(id string, target color.RGBA, t *ui.Transition) color.RGBA {
+	return app.le.AnimateColor(id, target, t, app.deltaTime)
+}
+
+// fadeCommands scales the alpha of everything commands draw by opacity.
+// Each component's commands are faded by its absolute opacity, so overlapping
+// children of a faded container show through each other.
+func fadeCommands(commands RenderCommandArray, opacity float32) {
+	if opacity >= 1 {
+		return
+	}
+	for i := range commands {
+		c := &commands[i]
+		c.Color.A *= opacity
+		c.BackgroundColor.A *= opacity
+		c.BorderColor.A *= opacity
+		if c.Gradient != nil {
+			c.Gradient = c.Gradient.Faded(opacity)
+		}
+		if len(c.Shadows) > 0 {
+			shadows := make([]ui.BoxShadow, len(c.Shadows))
+			for j, s := range c.Shadows {
+				s.Color.A *= opacity
+				shadows[j] = s
+			}
+			c.Shadows = shadows
+		}
+	}
+}
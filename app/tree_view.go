@@ -0,0 +1,224 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/internal/bidi"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Tree views
+// ——————————————————————————————————————————————————————————————————————————————
+
+// handleTreeClick toggles a node from its arrow, selects rows on press and
+// drags a pressed row to reorder once it has moved far enough.
+func (app *App) handleTreeClick(t *ui.TreeView, cursorPos math.Vec2f32, mouseDown bool) {
+	origin, _ := contentBox(t)
+	point := t.LocalPoint(cursorPos)
+	local := math.Vec2f32{X: point.X - origin.X, Y: point.Y - origin.Y}
+	hovered := -1
+	if t.IsPointInsideComponent(cursorPos) {
+		hovered = t.RowAt(local)
+	}
+	t.HoveredRow = hovered
+
+	if app.mouseJustPressed() && hovered >= 0 {
+		app.focus(t)
+		if t.IsOnToggle(hovered, local.X) {
+			t.Toggle(hovered)
+		} else {
+			t.SelectRow(hovered, app.shiftDown(), app.ctrlDown())
+			if t.Reorderable {
+				t.StartPress(hovered, local)
+			}
+		}
+	}
+
+	if t.PressedRow < 0 {
+		return
+	}
+	if mouseDown {
+		if !t.IsDragging && t.PressMoved(local) {
+			t.IsDragging = true
+		}
+		if t.IsDragging {
+			t.DropRow, t.Drop = t.DropAt(local)
+		}
+		return
+	}
+	if t.IsDragging {
+		t.DropDragged()
+	}
+	t.PressedRow, t.IsDragging, t.DropRow = -1, false, -1
+}
+
+// handleTreeKeys moves the cursor with the arrow keys, Page Up/Down and
+// Home/End, extending a multiple selection while Shift is held. The end-side
+// arrow expands a node or enters it, the other collapses it or goes to its
+// parent; Enter toggles a node and Space toggles it in a multiple selection.
+func (app *App) handleTreeKeys(t *ui.TreeView) {
+	rows := t.Rows()
+	if len(rows) == 0 {
+		return
+	}
+	cursor := t.RowIndex(t.Cursor)
+	extend := app.shiftDown()
+	moveTo := func(i int) {
+		t.SelectRow(max(0, min(i, len(rows)-1)), extend, false)
+	}
+	expand, collapse := KeyRight, KeyLeft
+	if t.IsRTL() {
+		expand, collapse = KeyLeft, KeyRight
+	}
+
+	switch {
+	case app.IsKeyPressed(KeyHome):
+		moveTo(0)
+	case app.IsKeyPressed(KeyEnd):
+		moveTo(len(rows) - 1)
+	case cursor < 0:
+		if app.IsKeyPressed(KeyDown) || app.IsKeyPressed(KeyUp) {
+			moveTo(0)
+		}
+	case app.IsKeyPressed(KeyDown):
+		moveTo(cursor + 1)
+	case app.IsKeyPressed(KeyUp):
+		moveTo(cursor - 1)
+	case app.IsKeyPressed(KeyPageDown):
+		moveTo(cursor + 10)
+	case app.IsKeyPressed(KeyPageUp):
+		moveTo(cursor - 10)
+	case app.IsKeyPressed(expand):
+		row := rows[cursor]
+		if !t.IsExpanded(row.Key) {
+			t.SetNodeExpanded(cursor, true)
+		} else if cursor+1 < len(rows) && rows[cursor+1].Depth > row.Depth {
+			t.SelectRow(cursor+1, false, false)
+		}
+	case app.IsKeyPressed(collapse):
+		row := rows[cursor]
+		if t.IsExpanded(row.Key) {
+			t.SetNodeExpanded(cursor, false)
+			break
+		}
+		for parent := cursor - 1; parent >= 0; parent-- {
+			if rows[parent].Depth < row.Depth {
+				t.SelectRow(parent, false, false)
+				break
+			}
+		}
+	case app.IsKeyPressed(KeyEnter):
+		t.Toggle(cursor)
+	case app.IsKeyPressed(KeySpace):
+		t.SelectRow(cursor, false, t.MultiSelect)
+	}
+}
+
+// treeViewCommands draws each row of a tree view with its selection, an
+// expand/collapse arrow for nodes with children and, while a row is dragged,
+// where it would be dropped.
+func (app *App) treeViewCommands(t *ui.TreeView, zIndex int) RenderCommandArray {
+	pos, size := t.AbsolutePos(), t.Size()
+	origin, content := contentBox(t)
+	rowHeight := t.RowHeight()
+	rtl := t.IsRTL()
+
+	commands := RenderCommandArray{{
+		Kind:            RenderCommandDrawRectangle,
+		Pos:             pos,
+		Size:            size,
+		BackgroundColor: t.BackgroundColor(),
+		BorderWidth:     t.Border(),
+		BorderColor:     t.BorderColor(),
+		BorderRadius:    t.BorderRadius(),
+		ZIndex:          zIndex,
+	}}
+
+	for i, row := range t.Rows() {
+		rowPos := math.Vec2f32{X: origin.X, Y: origin.Y + float32(i)*rowHeight}
+		rowSize := math.Vec2f32{X: content.X, Y: rowHeight}
+		switch {
+		case t.IsSelected(row.Key):
+			commands = append(commands, RenderCommand{Kind: RenderCommandDrawRectangle, Pos: rowPos, Size: rowSize, BackgroundColor: t.SelectedColor, ZIndex: zIndex})
+		case t.HoveredRow == i:
+			commands = append(commands, RenderCommand{Kind: RenderCommandDrawRectangle, Pos: rowPos, Size: rowSize, BackgroundColor: t.HoverColor, ZIndex: zIndex})
+		}
+		if t.IsFocused && row.Key == t.Cursor {
+			commands = append(commands, RenderCommand{
+				Kind:        RenderCommandDrawRectangle,
+				Pos:         rowPos,
+				Size:        rowSize,
+				BorderWidth: math.Vec2f32{X: 1, Y: 1},
+				BorderColor: t.FocusColor,
+				ZIndex:      zIndex + 1,
+			})
+		}
+
+		indent := float32(row.Depth) * t.Indent
+		toggleX := origin.X + indent
+		label := bidi.Visual(row.Node.Label, bidi.Auto)
+		labelX := toggleX + t.Indent
+		if rtl {
+			toggleX = origin.X + content.X - indent - t.Indent
+			labelX = toggleX - app.le.CalculateTextWidth(label, t.FontSize)
+		}
+		if t.HasChildren(row) {
+			center := math.Vec2f32{X: toggleX + t.Indent/2, Y: rowPos.Y + rowHeight/2}
+			commands = append(commands, treeArrowCommands(center, t.FontSize*0.25, t.IsExpanded(row.Key), rtl, t, zIndex+1)...)
+		}
+		commands = append(commands, labelCommand(label, math.Vec2f32{X: labelX, Y: rowPos.Y + (rowHeight-t.FontSize)/2}, t.FontSize, t.TextColor, zIndex+1))
+	}
+
+	if t.IsDragging && t.DropRow >= 0 {
+		rowPos := math.Vec2f32{X: origin.X, Y: origin.Y + float32(t.DropRow)*rowHeight}
+		switch t.Drop {
+		case ui.DropInside:
+			commands = append(commands, RenderCommand{
+				Kind:         RenderCommandDrawRectangle,
+				Pos:          rowPos,
+				Size:         math.Vec2f32{X: content.X, Y: rowHeight},
+				BorderWidth:  math.Vec2f32{X: 2, Y: 2},
+				BorderColor:  t.DropColor,
+				BorderRadius: 2,
+				ZIndex:       zIndex + 2,
+			})
+		default:
+			y := rowPos.Y
+			if t.Drop == ui.DropAfter {
+				y += rowHeight
+			}
+			commands = append(commands, RenderCommand{
+				Kind:            RenderCommandDrawRectangle,
+				Pos:             math.Vec2f32{X: origin.X, Y: y - 1},
+				Size:            math.Vec2f32{X: content.X, Y: 2},
+				BackgroundColor: t.DropColor,
+				ZIndex:          zIndex + 2,
+			})
+		}
+	}
+	return commands
+}
+
+// treeArrowCommands draws a chevron around center: pointing down when
+// expanded, otherwise towards the end edge.
+func treeArrowCommands(center math.Vec2f32, half float32, expanded, rtl bool, t *ui.TreeView, zIndex int) RenderCommandArray {
+	var a, tip, b math.Vec2f32
+	switch {
+	case expanded:
+		a = math.Vec2f32{X: center.X - half, Y: center.Y - half/2}
+		tip = math.Vec2f32{X: center.X, Y: center.Y + half/2}
+		b = math.Vec2f32{X: center.X + half, Y: center.Y - half/2}
+	default:
+		dir := float32(1)
+		if rtl {
+			dir = -1
+		}
+		a = math.Vec2f32{X: center.X - dir*half/2, Y: center.Y - half}
+		tip = math.Vec2f32{X: center.X + dir*half/2, Y: center.Y}
+		b = math.Vec2f32{X: center.X - dir*half/2, Y: center.Y + half}
+	}
+	return RenderCommandArray{
+		{Kind: RenderCommandDrawLine, Pos: a, End: tip, Thickness: 1.5, Color: t.TextColor, ZIndex: zIndex},
+		{Kind: RenderCommandDrawLine, Pos: tip, End: b, Thickness: 1.5, Color: t.TextColor, ZIndex: zIndex},
+	}
+}
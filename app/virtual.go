@@ -0,0 +1,132 @@
+package app
+
+import (
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Virtualized lists and grids
+// ——————————————————————————————————————————————————————————————————————————————
+
+// scroller is a component with a VirtualScroll: a VirtualList or a
+// VirtualGrid.
+type scroller interface {
+	ui.IComponent
+	ScrollState() *ui.VirtualScroll
+	ScrollTo(offset float32)
+	ScrollbarX() float32
+}
+
+// clipBox returns the area comp's children are drawn and hit-tested in, if
+// comp clips them. For a scroller it is also where its scrollbar goes.
+func clipBox(comp ui.IComponent) (math.Vec2f32, math.Vec2f32, bool) {
+	switch c := comp.(type) {
+	case *ui.VirtualList, *ui.VirtualGrid:
+		origin, size := contentBox(comp)
+		return origin, size, true
+	case *ui.Table:
+		// Rows scroll between the header and the footer, which stay in
+		// place.
+		origin, size := contentBox(comp)
+		header := c.HeaderHeight()
+		return math.Vec2f32{X: origin.X, Y: origin.Y + header}, math.Vec2f32{X: size.X, Y: max(0, size.Y-header-c.FooterHeight())}, true
+	case *ui.Container:
+		// Hidden overflow is cut at the inside of the border, so children
+		// scroll under the padding.
+		if c.Overflow == ui.OverflowHidden {
+			border := c.Border()
+			box := math.Rect{Pos: c.AbsolutePos(), Size: c.Size()}.Inset(border.X, border.Y, border.X, border.Y)
+			return box.Pos, box.Size, true
+		}
+	}
+	return math.Vec2f32{}, math.Vec2f32{}, false
+}
+
+// handleScrollbar drags the scrollbar thumb. Pressing the track outside the
+// thumb centres the thumb on the pointer and keeps dragging it.
+func (app *App) handleScrollbar(s scroller, cursorPos math.Vec2f32, mouseDown bool) {
+	st := s.ScrollState()
+	thumbY, length, ok := st.Thumb()
+	if !ok {
+		st.IsDraggingThumb = false
+		return
+	}
+	origin, _, _ := clipBox(s)
+	point := s.LocalPoint(cursorPos)
+	local := math.Vec2f32{X: point.X - origin.X, Y: point.Y - origin.Y}
+	barPos := math.Vec2f32{X: s.ScrollbarX()}
+	barSize := math.Vec2f32{X: st.ScrollbarWidth, Y: st.Viewport().Y}
+	if app.mouseJustPressed() && (math.Rect{Pos: barPos, Size: barSize}).Contains(local) {
+		st.IsDraggingThumb = true
+		st.ThumbGrab = length / 2
+		if local.Y >= thumbY && local.Y <= thumbY+length {
+			st.ThumbGrab = local.Y - thumbY
+		}
+	}
+	if st.IsDraggingThumb {
+		if mouseDown {
+			s.ScrollTo(st.OffsetForThumb(local.Y - st.ThumbGrab))
+		} else {
+			st.IsDraggingThumb = false
+		}
+	}
+}
+
+// handleWheel scrolls s by this frame's wheel movement if the pointer is
+// over it and it can scroll. The movement is then used up, so that a
+// scroller containing s does not scroll as well.
+func (app *App) handleWheel(s scroller) {
+	st := s.ScrollState()
+	wheel := app.keyboard.wheel.Y
+	if wheel == 0 || st.MaxOffset() == 0 || !s.IsPointInsideComponent(app.pointer) {
+		return
+	}
+	s.ScrollTo(st.Offset - wheel*st.WheelStep)
+	app.keyboard.wheel.Y = 0
+}
+
+// scrollbarCommands draws the track and thumb of a scroller that has more
+// content than fits.
+func scrollbarCommands(s scroller, zIndex int) RenderCommandArray {
+	st := s.ScrollState()
+	thumbY, length, ok := st.Thumb()
+	if !ok {
+		return nil
+	}
+	origin, _, _ := clipBox(s)
+	x := origin.X + s.ScrollbarX()
+	radius := st.ScrollbarWidth / 2
+	return RenderCommandArray{
+		{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             math.Vec2f32{X: x, Y: origin.Y},
+			Size:            math.Vec2f32{X: st.ScrollbarWidth, Y: st.Viewport().Y},
+			BackgroundColor: st.TrackColor,
+			BorderRadius:    radius,
+			ZIndex:          zIndex,
+		},
+		{
+			Kind:            RenderCommandDrawRectangle,
+			Pos:             math.Vec2f32{X: x, Y: origin.Y + thumbY},
+			Size:            math.Vec2f32{X: st.ScrollbarWidth, Y: length},
+			BackgroundColor: st.ThumbColor,
+			BorderRadius:    radius,
+			ZIndex:          zIndex,
+		},
+	}
+}
+
+// clipCommands restricts commands to the rectangle at pos of the given
+// size, within any clip they already have.
+func clipCommands(commands RenderCommandArray, pos, size math.Vec2f32) {
+	for i := range commands {
+		c := &commands[i]
+		if !c.Clip {
+			c.Clip, c.ClipPos, c.ClipSize = true, pos, size
+			continue
+		}
+		clip := math.Rect{Pos: c.ClipPos, Size: c.ClipSize}.Intersect(math.Rect{Pos: pos, Size: size})
+		c.ClipPos, c.ClipSize = clip.Pos, clip.Size
+	}
+}
@@ -0,0 +1,197 @@
+package atlas
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Atlas
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Entry describes where a named sprite lives inside an atlas.
+type Entry struct {
+	Name string `json:"name"`
+	Page int    `json:"page"`
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+	W    int    `json:"w"`
+	H    int    `json:"h"`
+}
+
+// Atlas is a set of pages (shared textures) and the entries packed into them.
+type Atlas struct {
+	PageWidth  int
+	PageHeight int
+	Pages      []*image.NRGBA
+	entries    map[string]Entry
+}
+
+func (a *Atlas) Lookup(name string) (Entry, bool) {
+	e, ok := a.entries[name]
+	return e, ok
+}
+
+// Entries returns all entries sorted by name.
+func (a *Atlas) Entries() []Entry {
+	entries := make([]Entry, 0, len(a.entries))
+	for _, e := range a.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// UV returns the normalized texture coordinates (u0, v0, u1, v1) of an entry.
+func (a *Atlas) UV(e Entry) (u0, v0, u1, v1 float32) {
+	w := float32(a.PageWidth)
+	h := float32(a.PageHeight)
+	return float32(e.X) / w, float32(e.Y) / h, float32(e.X+e.W) / w, float32(e.Y+e.H) / h
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Builder
+// ——————————————————————————————————————————————————————————————————————————————
+
+type sprite struct {
+	name  string
+	image image.Image
+}
+
+// Builder collects images and packs them into one or more atlas pages.
+type Builder struct {
+	pageWidth  int
+	pageHeight int
+	padding    int
+	sprites    []sprite
+	names      map[string]bool
+}
+
+func NewBuilder(pageWidth, pageHeight int) *Builder {
+	return &Builder{
+		pageWidth:  pageWidth,
+		pageHeight: pageHeight,
+		padding:    1,
+		names:      make(map[string]bool),
+	}
+}
+
+// SetPadding sets the empty border kept around every sprite to avoid
+// bleeding between neighbours when the texture is sampled with filtering.
+func (b *Builder) SetPadding(padding int) *Builder {
+	if padding < 0 {
+		padding = 0
+	}
+	b.padding = padding
+	return b
+}
+
+func (b *Builder) Add(name string, img image.Image) error {
+	if b.names[name] {
+		return fmt.Errorf("duplicate atlas entry: %s", name)
+	}
+	b.names[name] = true
+	b.sprites = append(b.sprites, sprite{name: name, image: img})
+	return nil
+}
+
+// AddFile decodes an image file and adds it under its base name without extension.
+func (b *Builder) AddFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return fmt.Errorf("failed to decode image %s: %w", path, err)
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return b.Add(name, img)
+}
+
+// AddDir adds every PNG and JPEG file in dir (non-recursive).
+func (b *Builder) AddDir(dir string) error {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(file.Name())) {
+		case ".png", ".jpg", ".jpeg":
+			if err := b.AddFile(filepath.Join(dir, file.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Builder) Build() (*Atlas, error) {
+	// Packing tallest first gives the skyline packer much flatter contours.
+	sprites := make([]sprite, len(b.sprites))
+	copy(sprites, b.sprites)
+	sort.SliceStable(sprites, func(i, j int) bool {
+		hi, hj := sprites[i].image.Bounds().Dy(), sprites[j].image.Bounds().Dy()
+		if hi != hj {
+			return hi > hj
+		}
+		return sprites[i].image.Bounds().Dx() > sprites[j].image.Bounds().Dx()
+	})
+
+	a := &Atlas{
+		PageWidth:  b.pageWidth,
+		PageHeight: b.pageHeight,
+		entries:    make(map[string]Entry, len(sprites)),
+	}
+	var packers []*skylinePacker
+
+	for _, s := range sprites {
+		bounds := s.image.Bounds()
+		w := bounds.Dx() + 2*b.padding
+		h := bounds.Dy() + 2*b.padding
+		if w > b.pageWidth || h > b.pageHeight {
+			return nil, fmt.Errorf("image %s (%dx%d) does not fit in a %dx%d atlas page",
+				s.name, bounds.Dx(), bounds.Dy(), b.pageWidth, b.pageHeight)
+		}
+
+		page, x, y := -1, 0, 0
+		for i, p := range packers {
+			if px, py, ok := p.pack(w, h); ok {
+				page, x, y = i, px, py
+				break
+			}
+		}
+		if page < 0 {
+			p := newSkylinePacker(b.pageWidth, b.pageHeight)
+			packers = append(packers, p)
+			a.Pages = append(a.Pages, image.NewNRGBA(image.Rect(0, 0, b.pageWidth, b.pageHeight)))
+			page = len(packers) - 1
+			x, y, _ = p.pack(w, h)
+		}
+
+		e := Entry{
+			Name: s.name,
+			Page: page,
+			X:    x + b.padding,
+			Y:    y + b.padding,
+			W:    bounds.Dx(),
+			H:    bounds.Dy(),
+		}
+		draw.Draw(a.Pages[page], image.Rect(e.X, e.Y, e.X+e.W, e.Y+e.H), s.image, bounds.Min, draw.Src)
+		a.entries[s.name] = e
+	}
+
+	return a, nil
+}
@@ -0,0 +1,119 @@
+package atlas
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Manifest
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Manifest is the on-disk description of an atlas. Page paths are relative
+// to the manifest file.
+type Manifest struct {
+	PageWidth  int      `json:"pageWidth"`
+	PageHeight int      `json:"pageHeight"`
+	Pages      []string `json:"pages"`
+	Entries    []Entry  `json:"entries"`
+}
+
+// Save writes every page as <name>_<page>.png and the manifest as <name>.json
+// into dir, returning the manifest path.
+func (a *Atlas) Save(dir, name string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	m := Manifest{
+		PageWidth:  a.PageWidth,
+		PageHeight: a.PageHeight,
+		Entries:    a.Entries(),
+	}
+	for i, page := range a.Pages {
+		pageName := fmt.Sprintf("%s_%d.png", name, i)
+		if err := writePNG(filepath.Join(dir, pageName), page); err != nil {
+			return "", err
+		}
+		m.Pages = append(m.Pages, pageName)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	manifestPath := filepath.Join(dir, name+".json")
+	if err := os.WriteFile(manifestPath, data, 0o644); err != nil {
+		return "", err
+	}
+	return manifestPath, nil
+}
+
+// Load reads a manifest and the page images it references.
+func Load(manifestPath string) (*Atlas, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid atlas manifest %s: %w", manifestPath, err)
+	}
+
+	a := &Atlas{
+		PageWidth:  m.PageWidth,
+		PageHeight: m.PageHeight,
+		entries:    make(map[string]Entry, len(m.Entries)),
+	}
+	dir := filepath.Dir(manifestPath)
+	for _, pageName := range m.Pages {
+		page, err := readPNG(filepath.Join(dir, pageName))
+		if err != nil {
+			return nil, err
+		}
+		a.Pages = append(a.Pages, page)
+	}
+	for _, e := range m.Entries {
+		if e.Page < 0 || e.Page >= len(a.Pages) {
+			return nil, fmt.Errorf("atlas entry %s references missing page %d", e.Name, e.Page)
+		}
+		a.entries[e.Name] = e
+	}
+	return a, nil
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readPNG(path string) (*image.NRGBA, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode atlas page %s: %w", path, err)
+	}
+	if nrgba, ok := img.(*image.NRGBA); ok {
+		return nrgba, nil
+	}
+	nrgba := image.NewNRGBA(img.Bounds())
+	draw.Draw(nrgba, nrgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return nrgba, nil
+}
@@ -0,0 +1,113 @@
+package atlas
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Skyline packer
+// ——————————————————————————————————————————————————————————————————————————————
+
+// skylinePacker places rectangles on a single page using the skyline
+// bottom-left heuristic. The skyline is the upper contour of everything
+// packed so far, stored as horizontal segments ordered by x.
+type skylinePacker struct {
+	width, height int
+	skyline       []skylineNode
+}
+
+type skylineNode struct {
+	x, y, width int
+}
+
+func newSkylinePacker(width, height int) *skylinePacker {
+	return &skylinePacker{
+		width:   width,
+		height:  height,
+		skyline: []skylineNode{{x: 0, y: 0, width: width}},
+	}
+}
+
+// pack finds a spot for a w×h rectangle and reserves it.
+// ok is false if the rectangle does not fit anywhere on the page.
+func (p *skylinePacker) pack(w, h int) (x, y int, ok bool) {
+	if w <= 0 || h <= 0 || w > p.width || h > p.height {
+		return 0, 0, false
+	}
+
+	bestIndex := -1
+	bestTop := p.height + 1
+	bestWidth := p.width + 1
+
+	for i := range p.skyline {
+		top, fits := p.fit(i, w, h)
+		if !fits {
+			continue
+		}
+		// Prefer the lowest resulting top edge, then the narrowest segment
+		// to keep wide gaps available for wide sprites.
+		if top+h < bestTop || (top+h == bestTop && p.skyline[i].width < bestWidth) {
+			bestIndex = i
+			bestTop = top + h
+			bestWidth = p.skyline[i].width
+			x = p.skyline[i].x
+			y = top
+		}
+	}
+
+	if bestIndex < 0 {
+		return 0, 0, false
+	}
+	p.addLevel(bestIndex, x, y, w, h)
+	return x, y, true
+}
+
+// fit returns the y at which a w×h rectangle would rest if its left edge
+// were placed at the start of skyline segment i.
+func (p *skylinePacker) fit(i, w, h int) (int, bool) {
+	x := p.skyline[i].x
+	if x+w > p.width {
+		return 0, false
+	}
+	y := p.skyline[i].y
+	remaining := w
+	for j := i; remaining > 0; j++ {
+		if j >= len(p.skyline) {
+			return 0, false
+		}
+		y = max(y, p.skyline[j].y)
+		if y+h > p.height {
+			return 0, false
+		}
+		remaining -= p.skyline[j].width
+	}
+	return y, true
+}
+
+func (p *skylinePacker) addLevel(index, x, y, w, h int) {
+	node := skylineNode{x: x, y: y + h, width: w}
+	p.skyline = append(p.skyline, skylineNode{})
+	copy(p.skyline[index+1:], p.skyline[index:])
+	p.skyline[index] = node
+
+	// Trim or remove the segments now covered by the new node.
+	for i := index + 1; i < len(p.skyline); i++ {
+		prev := p.skyline[i-1]
+		overlap := prev.x + prev.width - p.skyline[i].x
+		if overlap <= 0 {
+			break
+		}
+		p.skyline[i].x += overlap
+		p.skyline[i].width -= overlap
+		if p.skyline[i].width > 0 {
+			break
+		}
+		p.skyline = append(p.skyline[:i], p.skyline[i+1:]...)
+		i--
+	}
+
+	// Merge neighbouring segments at the same height.
+	for i := 0; i < len(p.skyline)-1; i++ {
+		if p.skyline[i].y == p.skyline[i+1].y {
+			p.skyline[i].width += p.skyline[i+1].width
+			p.skyline = append(p.skyline[:i+1], p.skyline[i+2:]...)
+			i--
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package atlas
+
+import (
+	"image"
+	"math/rand"
+	"testing"
+)
+
+type placed struct{ x, y, w, h int }
+
+func (a placed) overlaps(b placed) bool {
+	return a.x < b.x+b.w && b.x < a.x+a.w && a.y < b.y+b.h && b.y < a.y+a.h
+}
+
+// checkSkyline fails unless the skyline runs from 0 to the page width in
+// order, without gaps, with neighbours at different heights.
+func checkSkyline(t *testing.T, p *skylinePacker) {
+	t.Helper()
+	x := 0
+	for i, n := range p.skyline {
+		if n.x != x || n.width <= 0 || n.y < 0 || n.y > p.height {
+			t.Fatalf("bad skyline node %d %+v in %+v", i, n, p.skyline)
+		}
+		if i > 0 && p.skyline[i-1].y == n.y {
+			t.Fatalf("unmerged skyline nodes at %d in %+v", i, p.skyline)
+		}
+		x += n.width
+	}
+	if x != p.width {
+		t.Fatalf("skyline %+v spans %d, want %d", p.skyline, x, p.width)
+	}
+}
+
+func TestSkylinePackerPlacements(t *testing.T) {
+	type rect struct{ w, h int }
+	tests := []struct {
+		name  string
+		rects []rect
+		want  []placed // x, y, and ok as w > 0
+	}{
+		{
+			name:  "row along the bottom",
+			rects: []rect{{10, 10}, {10, 10}, {12, 10}},
+			want:  []placed{{0, 0, 10, 10}, {10, 0, 10, 10}, {20, 0, 12, 10}},
+		},
+		{
+			name:  "next row when the first is full",
+			rects: []rect{{16, 8}, {16, 8}, {16, 8}},
+			want:  []placed{{0, 0, 16, 8}, {16, 0, 16, 8}, {0, 8, 16, 8}},
+		},
+		{
+			name:  "lowest top wins",
+			rects: []rect{{8, 20}, {8, 4}, {8, 8}},
+			want:  []placed{{0, 0, 8, 20}, {8, 0, 8, 4}, {16, 0, 8, 8}},
+		},
+		{
+			name:  "rests on the highest segment under it",
+			rects: []rect{{16, 4}, {16, 12}, {20, 4}},
+			want:  []placed{{0, 0, 16, 4}, {16, 0, 16, 12}, {0, 12, 20, 4}},
+		},
+		{
+			name:  "narrowest segment on a tie",
+			rects: []rect{{8, 8}, {4, 12}, {20, 8}, {4, 4}},
+			want:  []placed{{0, 0, 8, 8}, {8, 0, 4, 12}, {12, 0, 20, 8}, {0, 8, 4, 4}},
+		},
+		{
+			name:  "whole page",
+			rects: []rect{{32, 32}, {1, 1}},
+			want:  []placed{{0, 0, 32, 32}, {}},
+		},
+		{
+			name:  "too big, empty or negative",
+			rects: []rect{{33, 1}, {1, 33}, {0, 5}, {5, 0}, {-1, 4}},
+			want:  []placed{{}, {}, {}, {}, {}},
+		},
+		{
+			name:  "too tall for what is left",
+			rects: []rect{{32, 30}, {4, 4}, {32, 2}},
+			want:  []placed{{0, 0, 32, 30}, {}, {0, 30, 32, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newSkylinePacker(32, 32)
+			for i, r := range tt.rects {
+				x, y, ok := p.pack(r.w, r.h)
+				want := tt.want[i]
+				if ok != (want.w > 0) || (ok && (x != want.x || y != want.y)) {
+					t.Fatalf("pack(%d, %d) = %d, %d, %v, want %d, %d, %v", r.w, r.h, x, y, ok, want.x, want.y, want.w > 0)
+				}
+				checkSkyline(t, p)
+			}
+		})
+	}
+}
+
+func TestSkylinePackerFillsPage(t *testing.T) {
+	p := newSkylinePacker(64, 64)
+	for i := range 64 {
+		if _, _, ok := p.pack(8, 8); !ok {
+			t.Fatalf("tile %d did not fit", i)
+		}
+	}
+	if _, _, ok := p.pack(1, 1); ok {
+		t.Error("packed into a full page")
+	}
+	if len(p.skyline) != 1 || p.skyline[0].y != 64 {
+		t.Errorf("skyline of a full page = %+v", p.skyline)
+	}
+}
+
+func TestSkylinePackerNeverOverlaps(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := range 20 {
+		p := newSkylinePacker(128, 128)
+		var rects []placed
+		for range 200 {
+			w, h := 1+rng.Intn(24), 1+rng.Intn(24)
+			x, y, ok := p.pack(w, h)
+			if !ok {
+				continue
+			}
+			r := placed{x, y, w, h}
+			if x < 0 || y < 0 || x+w > 128 || y+h > 128 {
+				t.Fatalf("round %d: %+v is off the page", round, r)
+			}
+			for _, o := range rects {
+				if r.overlaps(o) {
+					t.Fatalf("round %d: %+v overlaps %+v", round, r, o)
+				}
+			}
+			rects = append(rects, r)
+			checkSkyline(t, p)
+		}
+		if len(rects) < 20 {
+			t.Errorf("round %d: only %d rects fit", round, len(rects))
+		}
+	}
+}
+
+func TestBuilderPagesAndPadding(t *testing.T) {
+	b := NewBuilder(32, 32).SetPadding(1)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		if err := b.Add(name, image.NewNRGBA(image.Rect(0, 0, 14, 14))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := a.Entries()
+	if len(entries) != 5 {
+		t.Fatalf("%d entries, want 5", len(entries))
+	}
+	for i, e := range entries {
+		for _, o := range entries[i+1:] {
+			if e.Page != o.Page {
+				continue
+			}
+			// Padding keeps a pixel between sprites on the same page.
+			padded := placed{e.X - 1, e.Y - 1, e.W + 2, e.H + 2}
+			if padded.overlaps(placed{o.X, o.Y, o.W, o.H}) {
+				t.Errorf("%s at %d,%d touches %s at %d,%d", e.Name, e.X, e.Y, o.Name, o.X, o.Y)
+			}
+		}
+	}
+	if last := entries[len(entries)-1]; last.Page == 0 {
+		t.Error("five 16×16 padded sprites fit on one 32×32 page")
+	}
+}
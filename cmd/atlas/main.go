@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/aj-2000/mogi/atlas"
+)
+
+// atlas packs a directory of small images (icons, UI sprites) into shared
+// texture pages and writes a JSON manifest that App.LoadAtlas can read.
+//
+//	go run ./cmd/atlas -in assets/icons -out assets -name icons
+func main() {
+	in := flag.String("in", "", "directory containing PNG/JPEG images")
+	out := flag.String("out", ".", "output directory for pages and manifest")
+	name := flag.String("name", "atlas", "base name of the generated files")
+	width := flag.Int("width", 1024, "page width in pixels")
+	height := flag.Int("height", 1024, "page height in pixels")
+	padding := flag.Int("padding", 1, "empty pixels around each image")
+	flag.Parse()
+
+	if *in == "" {
+		flag.Usage()
+		log.Fatalln("missing -in directory")
+	}
+
+	b := atlas.NewBuilder(*width, *height).SetPadding(*padding)
+	if err := b.AddDir(*in); err != nil {
+		log.Fatalf("failed to read images: %v", err)
+	}
+	a, err := b.Build()
+	if err != nil {
+		log.Fatalf("failed to build atlas: %v", err)
+	}
+	manifestPath, err := a.Save(*out, *name)
+	if err != nil {
+		log.Fatalf("failed to write atlas: %v", err)
+	}
+	log.Printf("Packed %d images into %d page(s): %s", len(a.Entries()), len(a.Pages), manifestPath)
+}
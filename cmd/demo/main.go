@@ -55,109 +55,46 @@ func main() {
 
 		app.LoadFont("JetBrainsMonoNL-Regular.ttf", 24.0)
 		// TODO: fix app is not a type issue
-		flag := false
 		app.Run(func(app *mogiApp.App) ui.IComponent {
-			children := []ui.IComponent{
-				examples.ChessboardComponent(app),
-				examples.BuyNowCardComponent(app),
-				examples.BoxesOneComponent(app),
-				// examples.BoxesNLevelComponent(app, 3, 3, 100),
-				examples.NestedContainersComponent(app),
-				// examples.ClayDemoComponent(app),
-				examples.ExampleMarginPaddingBorder(app),
-			}
-
-			setTabIndex := func(index int) {
-				for i, comp := range children {
-					display := ui.DisplayNone
-					if i == index {
-						display = ui.DisplayBlock
+			theme := app.Theme()
+			themeSwitch := app.Switch("Light mode").
+				SetID("theme_switch").
+				SetOn(theme.Mode == ui.ThemeLight).
+				SetOnChange(func(_ *ui.Switch, on bool) {
+					if on {
+						app.SetTheme(ui.LightTheme())
+					} else {
+						app.SetTheme(ui.DarkTheme())
 					}
-					switch c := comp.(type) {
-					case *ui.Button:
-						c.SetDisplay(display)
-					case *ui.Text:
-						c.SetDisplay(display)
-					case *ui.Image:
-						c.SetDisplay(display)
-					case *ui.Container:
-						c.SetDisplay(display)
-					default:
-						log.Printf("Unknown component type: %T", c)
-
-					}
-
-				}
-			}
-			if !flag {
-				setTabIndex(0)
-				flag = true
-			}
-
-			_ = app.Container().
+				})
+
+			// Examples are built only while their tab is active.
+			tabs := app.Tabs(
+				ui.NewLazyTab("Chessboard", func() ui.IComponent { return examples.ChessboardComponent(app) }),
+				ui.NewLazyTab("Buy Now", func() ui.IComponent { return examples.BuyNowCardComponent(app) }),
+				ui.NewLazyTab("Boxes One", func() ui.IComponent { return examples.BoxesOneComponent(app) }),
+				// ui.NewLazyTab("Boxes N Level", func() ui.IComponent { return examples.BoxesNLevelComponent(app, 3, 3, 100) }),
+				ui.NewLazyTab("Nested Containers", func() ui.IComponent { return examples.NestedContainersComponent(app) }),
+				// ui.NewLazyTab("Clay Demo", func() ui.IComponent { return examples.ClayDemoComponent(app) }),
+				ui.NewLazyTab("Margin Padding Border", func() ui.IComponent { return examples.ExampleMarginPaddingBorder(app) }).
+					SetClosable(true),
+				ui.NewLazyTab("Log View", func() ui.IComponent { return examples.LogViewComponent(app) }),
+				ui.NewLazyTab("File Tree", func() ui.IComponent { return examples.FileTreeComponent(app) }),
+				ui.NewLazyTab("Employees", func() ui.IComponent { return examples.EmployeesComponent(app) }),
+				ui.NewLazyTab("Charts", func() ui.IComponent { return examples.ChartsComponent(app) }),
+				ui.NewLazyTab("Canvas", func() ui.IComponent { return examples.CanvasComponent(app) }),
+				ui.NewLazyTab("Loading", func() ui.IComponent { return examples.LoadingComponent(app) }),
+				ui.NewLazyTab("Animation", func() ui.IComponent { return examples.AnimationComponent(app) }),
+			).
 				SetID("tabs").
-				SetBackgroundColor(color.Magenta).
-				SetBorderRadius(5).
+				SetReorderable(true).
+				SetBackgroundColor(theme.SurfaceRaised).
+				SetBorderRadius(theme.RadiusSmall).
 				SetPadding(math.Vec2f32{X: 4, Y: 4}).
-				AddChildren(
-					app.Container().
-						SetID("tab_bar").
-						// TODO: fix percent precendence
-						SetWidthPercent(100).
-						SetZIndex(999).
-						SetBackgroundColor(color.Gray).
-						SetBorderRadius(5).
-						SetBorder(math.Vec2f32{X: 1, Y: 1}).
-						SetBorderColor(color.Black).
-						SetPadding(math.Vec2f32{X: 4, Y: 4}).
-						SetGap(math.Vec2f32{X: 3, Y: 3}).
-						SetMargin(math.Vec2f32{X: 3, Y: 3}).
-						SetDisplay(ui.DisplayBlock).
-						AddChildren(
-							app.Button("Chessboard").
-								SetDisplay(ui.DisplayInline).
-								SetOnClick(func(self *ui.Button) {
-									setTabIndex(0)
-								}),
-							app.Button("Buy Now").
-								SetDisplay(ui.DisplayInline).
-								SetOnClick(func(self *ui.Button) {
-									setTabIndex(1)
-									log.Println("Buy Now clicked")
-								}),
-							app.Button("Boxes One").
-								SetDisplay(ui.DisplayInline).
-								SetOnClick(func(self *ui.Button) {
-									setTabIndex(2)
-								}),
-							// app.Button("Boxes N Level").
-							// 	SetDisplay(ui.DisplayInline).
-							// 	SetOnClick(func(self *ui.Button) {
-							// 		setTabIndex(3)
-							// 	}),
-							app.Button("Nested Containers").
-								SetDisplay(ui.DisplayInline).
-								SetOnClick(func(self *ui.Button) {
-									setTabIndex(3)
-								}),
-							// app.Button("Clay Demo").
-							// 	SetDisplay(ui.DisplayInline).
-							// 	SetOnClick(func(self *ui.Button) {
-							// 		setTabIndex(5)
-							// 	}),
-							app.Button("Margin Padding Border").
-								SetDisplay(ui.DisplayInline).
-								SetOnClick(func(self *ui.Button) {
-									setTabIndex(4)
-								}),
-						),
-					app.Container().
-						SetID("tabs_container").
-						SetBackgroundColor(color.Pink).
-						AddChildren(children...),
-				)
-
-			bgColor := color.Transparent
+				SetOnTabChange(func(self *ui.Tabs, index int) {
+					log.Printf("Tab changed: %s", self.Tabs[index].Title)
+				})
+
 			// cursorSize := float32(30)
 			// cursorSize1 := float32(25)
 
@@ -180,25 +117,45 @@ func main() {
 				},
 			}
 
-			table := app.Table().
-				SetID("table").
-				SetHeader([]string{
-					"Country",
-					"Active Personnel",
-					"Total Aircraft",
-					"Fighter Aircraft",
-					"Attack Aircraft",
-					"Helicopters",
-					"Attack Helicopters",
-					"Combat Tanks",
-					"Self-Propelled Artillery",
-					"Summary",
-				}).
-				SetBackgroundColor(color.Red).
-				AddRows(rows)
+			columns := []*ui.TableColumn{ui.NewColumn("Country").SetWidth(ui.FixedWidth(110))}
+			for _, title := range []string{
+				"Active Personnel",
+				"Total Aircraft",
+				"Fighter Aircraft",
+				"Attack Aircraft",
+				"Helicopters",
+				"Attack Helicopters",
+				"Combat Tanks",
+				"Self-Propelled Artillery",
+			} {
+				columns = append(columns, ui.NewColumn(title).SetAlign(ui.TextAlignEnd))
+			}
+			columns = append(columns, ui.NewColumn("Summary").
+				SetWidth(ui.FractionWidth(1)).
+				SetMinWidth(240).
+				SetSortable(false).
+				SetResizable(false))
+
+			// The table keeps the dark theme in either mode, with a green
+			// accent.
+			tableTheme := ui.DarkTheme()
+			tableTheme.Primary = color.RGBA{R: 0.15, G: 0.6, B: 0.4, A: 1}
+			table := app.WithTheme(tableTheme, func() ui.IComponent {
+				return app.Table().
+					SetID("table").
+					SetColumns(columns...).
+					SetMultiSelect(true).
+					SetSize(math.Vec2f32{Y: 320}).
+					SetWidthPercent(100).
+					SetBorderRadius(6).
+					SetBackgroundColor(tableTheme.Surface).
+					SetOnSelect(func(self *ui.Table, keys []string) {
+						log.Printf("Rows selected: %v", keys)
+					}).
+					AddRows(rows)
+			})
 			r := app.Container().
 				SetID("app_container").
-				SetBackgroundColor(bgColor).
 				AddChildren( // Add all children at once
 					// examples.FPSCounterComponent(app),
 					// app.Container().
@@ -215,7 +172,8 @@ func main() {
 					// 	SetBorderRadius(cursorSize1/2).
 					// 	SetSize(math.Vec2f32{X: cursorSize1, Y: cursorSize1}).
 					// 	SetPosition(ui.Position{X: mousePos.X - (cursorSize1 / 2), Y: mousePos.Y - (cursorSize1 / 2), Type: ui.PositionTypeAbsolute}),
-					// tabs,
+					themeSwitch,
+					tabs,
 					table,
 					// app.Text("Lorem Ipsum is simply dummy text of the printing and typesetting industry.").
 					// 	SetColor(color.Red).
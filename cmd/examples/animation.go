@@ -0,0 +1,122 @@
+package examples
+
+import (
+	"fmt"
+	"math/rand"
+
+	mogiApp "github.com/aj-2000/mogi/app"
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+var (
+	// expanded toggles the size, color and corners of the morphing box.
+	expanded bool
+	// cardOrder is the order the cards are shown in.
+	cardOrder = []int{0, 1, 2, 3, 4}
+	// hidden fades the message out.
+	hidden bool
+	// tilted turns the transformed panel; spins counts clicks on its button.
+	tilted bool
+	spins  int
+)
+
+// AnimationComponent shows transitions: buttons fading between their
+// colors, a box that springs to a new size, cards that glide when shuffled,
+// a fading message, a free-standing animated value and a panel turned by a
+// transform, whose button still takes clicks where it is drawn.
+func AnimationComponent(app *mogiApp.App) ui.IComponent {
+	buttons := app.Container().
+		SetID("anim_buttons").
+		SetDisplay(ui.DisplayFlex).
+		SetGap(math.Vec2f32{X: 8}).
+		AddChildren(
+			app.Button("Morph").SetID("anim_morph").SetOnClick(func(*ui.Button) { expanded = !expanded }),
+			app.Button("Shuffle").SetID("anim_shuffle").SetOnClick(func(*ui.Button) {
+				rand.Shuffle(len(cardOrder), func(i, j int) { cardOrder[i], cardOrder[j] = cardOrder[j], cardOrder[i] })
+			}),
+			app.Button("Fade").SetID("anim_fade").SetOnClick(func(*ui.Button) { hidden = !hidden }),
+			app.Button("Tilt").SetID("anim_tilt").SetOnClick(func(*ui.Button) { tilted = !tilted }),
+			app.Button("Snaps").SetID("anim_snap").SetTransition(ui.NewTransition(ui.PropertyBackgroundColor, 0)),
+		)
+
+	size, fill, radius := math.Vec2f32{X: 80, Y: 80}, color.RGBA{R: 0.3, G: 0.6, B: 1, A: 1}, float32(4)
+	if expanded {
+		size, fill, radius = math.Vec2f32{X: 240, Y: 120}, color.RGBA{R: 1, G: 0.5, B: 0.2, A: 1}, 40
+	}
+	box := app.Container().
+		SetID("anim_box").
+		SetSize(size).
+		SetBackgroundColor(fill).
+		SetBorderRadius(radius).
+		SetTransition(
+			ui.NewSpringTransition(ui.PropertySize, ui.DefaultSpring),
+			ui.NewTransition(ui.PropertyBackgroundColor, 0.4).SetEasing(ui.EaseInOut),
+			ui.NewTransition(ui.PropertyBorderRadius, 0.4).SetDelay(0.1),
+		)
+
+	cards := app.Container().
+		SetID("anim_cards").
+		SetDisplay(ui.DisplayFlex).
+		SetGap(math.Vec2f32{X: 8})
+	for _, n := range cardOrder {
+		cards.AddChild(app.Container().
+			SetID(fmt.Sprintf("anim_card_%d", n)).
+			SetSize(math.Vec2f32{X: 56, Y: 56}).
+			SetBackgroundColor(ui.DefaultChartPalette[n%len(ui.DefaultChartPalette)]).
+			SetBorderRadius(8).
+			SetTransition(ui.NewSpringTransition(ui.PropertyOffset, ui.Spring{Stiffness: 120, Damping: 14})).
+			AddChild(app.Text(fmt.Sprint(n + 1)).SetID(fmt.Sprintf("anim_card_label_%d", n)).SetFontSize(24).SetColor(color.White)))
+	}
+
+	opacity := float32(1)
+	if hidden {
+		opacity = 0
+	}
+	message := app.Text("Now you see me").
+		SetID("anim_message").
+		SetFontSize(20).
+		SetColor(color.White).
+		SetOpacity(opacity).
+		SetTransition(ui.NewTransition(ui.PropertyOpacity, 0.5))
+
+	// The box's area, counting to the new one when it morphs.
+	area := app.Animate("anim_area", size.X*size.Y, ui.NewTransition(ui.PropertyValue, 0.8).SetEasing(ui.EaseOut))
+	counter := app.Text(fmt.Sprintf("Box area %.0f px²", area)).
+		SetID("anim_counter").
+		SetFontSize(20).
+		SetColor(color.White)
+
+	angle := float32(0)
+	if tilted {
+		angle = -20
+	}
+	angle = app.Animate("anim_tilt_angle", angle, ui.NewSpringTransition(ui.PropertyValue, ui.DefaultSpring))
+	panel := app.Container().
+		SetID("anim_panel").
+		SetDisplay(ui.DisplayFlex).
+		SetGap(math.Vec2f32{X: 12}).
+		SetPadding(math.Vec2f32{X: 12, Y: 12}).
+		SetBackgroundColor(color.RGBA{R: 0.2, G: 0.2, B: 0.25, A: 1}).
+		SetBorderRadius(8).
+		SetTransform(ui.NewTransform().SetRotate(angle)).
+		AddChildren(
+			app.Button(fmt.Sprintf("Clicked %d", spins)).
+				SetID("anim_panel_button").
+				SetOnClick(func(*ui.Button) { spins++ }).
+				SetTransform(ui.NewTransform().SetScale(1.2, 1.2)),
+			app.Text("Skewed").
+				SetID("anim_panel_text").
+				SetFontSize(20).
+				SetColor(color.White).
+				SetTransform(ui.NewTransform().SetSkew(-15, 0)),
+		)
+
+	return app.Container().
+		SetID("animation").
+		SetDisplay(ui.DisplayBlock).
+		SetGap(math.Vec2f32{X: 8, Y: 16}).
+		SetPadding(math.Vec2f32{X: 8, Y: 8}).
+		AddChildren(buttons, box, cards, message, counter, panel)
+}
@@ -14,11 +14,28 @@ import (
 func BuyNowCardComponent(app *mogiApp.App) ui.IComponent {
 	return app.Container().
 		SetID("buy_now_card").
-		SetBackgroundColor(color.Black).
+		SetBackgroundColor(color.RGBA{R: 0.1, G: 0.1, B: 0.12, A: 1}).
+		SetBorderRadius(12).
+		SetOverflow(ui.OverflowHidden).
+		SetShadow(
+			ui.BoxShadow{Offset: math.Vec2f32{Y: 8}, Blur: 24, Color: color.RGBA{A: 0.5}},
+			ui.BoxShadow{Blur: 2, Spread: 1, Color: color.RGBA{R: 1, G: 1, B: 1, A: 0.08}, Inset: true},
+		).
 		AddChild(
-			app.Container().SetBackgroundColor(color.Green).
+			app.Container().
 				SetID("green_rectangle").
-				SetSize(math.Vec2f32{X: 200, Y: 200}),
+				SetGradient(color.NewLinearGradient(135,
+					color.GradientStop{Offset: 0, Color: color.RGBA{R: 0.3, G: 0.9, B: 0.5, A: 1}},
+					color.GradientStop{Offset: 1, Color: color.RGBA{R: 0.05, G: 0.45, B: 0.35, A: 1}},
+				)).
+				SetSize(math.Vec2f32{X: 200, Y: 200}).
+				AddChild(app.Container().
+					SetID("green_rectangle_shine").
+					SetGradient(color.NewRadialGradient(
+						color.GradientStop{Offset: 0, Color: color.RGBA{R: 1, G: 1, B: 1, A: 0.35}},
+						color.GradientStop{Offset: 0.6, Color: color.RGBA{R: 1, G: 1, B: 1, A: 0}},
+					).SetCenter(0.3, 0.25)).
+					SetSize(math.Vec2f32{X: 200, Y: 200})),
 		).
 		AddChild(
 			app.Text("Green Rectangle").
@@ -36,7 +53,12 @@ func BuyNowCardComponent(app *mogiApp.App) ui.IComponent {
 			app.Button("Buy Now").
 				SetID("buy_button").
 				SetOnClick(func(_ *ui.Button) { log.Println("Buy Now Clicked!") }).
-				SetBackgroundColor(color.Blue),
+				SetBackgroundColor(color.Blue).
+				SetGradient(color.NewLinearGradient(90,
+					color.GradientStop{Offset: 0, Color: color.RGBA{R: 0.25, G: 0.45, B: 1, A: 1}},
+					color.GradientStop{Offset: 1, Color: color.RGBA{R: 0.55, G: 0.3, B: 0.95, A: 1}},
+				)).
+				SetShadow(ui.BoxShadow{Offset: math.Vec2f32{Y: 3}, Blur: 8, Color: color.RGBA{R: 0.25, G: 0.35, B: 1, A: 0.4}}),
 		).
 		SetPosition(ui.Position{
 			X:    320,
@@ -0,0 +1,82 @@
+package examples
+
+import (
+	gomath "math"
+
+	mogiApp "github.com/aj-2000/mogi/app"
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/geometry"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// CanvasComponent draws with the painter: filled shapes, a star filled by
+// each fill rule, curves, arcs and strokes with each kind of join, cap and a
+// dash pattern.
+func CanvasComponent(app *mogiApp.App) ui.IComponent {
+	accent := color.RGBA{R: 0.3, G: 0.6, B: 1, A: 1}
+	warm := color.RGBA{R: 1, G: 0.6, B: 0.2, A: 1}
+
+	return app.Canvas(func(p *ui.Painter, size math.Vec2f32) {
+		// A hexagon and an ellipse.
+		p.BeginPath()
+		for i := range 6 {
+			angle := float64(i) * gomath.Pi / 3
+			point := math.Vec2f32{X: 70 + 50*float32(gomath.Cos(angle)), Y: 70 + 50*float32(gomath.Sin(angle))}
+			if i == 0 {
+				p.MoveTo(point)
+			} else {
+				p.LineTo(point)
+			}
+		}
+		p.Close().Fill(accent)
+		p.BeginPath().Ellipse(math.Vec2f32{X: 200, Y: 70}, math.Vec2f32{X: 60, Y: 35}).Fill(warm)
+		p.FillCircle(math.Vec2f32{X: 320, Y: 70}, 30, color.White)
+
+		// Five-pointed stars: the middle is a hole by the even-odd rule.
+		for i, rule := range []geometry.FillRule{geometry.NonZero, geometry.EvenOdd} {
+			p.BeginPath().SetFillRule(rule)
+			for k := range 5 {
+				angle := float64(k)*4*gomath.Pi/5 - gomath.Pi/2
+				point := math.Vec2f32{X: 420 + float32(i)*110 + 45*float32(gomath.Cos(angle)), Y: 70 + 45*float32(gomath.Sin(angle))}
+				if k == 0 {
+					p.MoveTo(point)
+				} else {
+					p.LineTo(point)
+				}
+			}
+			p.Close().Fill(warm)
+		}
+
+		// One zigzag per join, with the matching cap.
+		joins := []geometry.LineJoin{geometry.JoinMiter, geometry.JoinBevel, geometry.JoinRound}
+		caps := []geometry.LineCap{geometry.CapButt, geometry.CapSquare, geometry.CapRound}
+		p.SetLineWidth(12)
+		for i, join := range joins {
+			x := 30 + float32(i)*130
+			p.SetLineJoin(join).SetLineCap(caps[i])
+			p.BeginPath().
+				MoveTo(math.Vec2f32{X: x, Y: 200}).
+				LineTo(math.Vec2f32{X: x + 35, Y: 150}).
+				LineTo(math.Vec2f32{X: x + 70, Y: 200}).
+				LineTo(math.Vec2f32{X: x + 105, Y: 150}).
+				Stroke(accent)
+		}
+
+		// Curves and an arc.
+		p.SetLineWidth(4).SetLineCap(geometry.CapRound).SetLineJoin(geometry.JoinRound)
+		p.BeginPath().
+			MoveTo(math.Vec2f32{X: 30, Y: 300}).
+			CubicTo(math.Vec2f32{X: 90, Y: 220}, math.Vec2f32{X: 150, Y: 380}, math.Vec2f32{X: 210, Y: 300}).
+			QuadTo(math.Vec2f32{X: 260, Y: 240}, math.Vec2f32{X: 300, Y: 300}).
+			Stroke(warm)
+		p.BeginPath().Arc(math.Vec2f32{X: 380, Y: 300}, 40, 0, 1.5*gomath.Pi).Stroke(color.White)
+
+		// A dashed frame around everything.
+		p.SetLineWidth(2).SetLineCap(geometry.CapButt).SetLineJoin(geometry.JoinMiter).SetDash(0, 10, 6)
+		p.BeginPath().Rect(math.Vec2f32{X: 4, Y: 4}, math.Vec2f32{X: size.X - 8, Y: size.Y - 8}).Stroke(color.Gray)
+	}).
+		SetID("painter").
+		SetSize(math.Vec2f32{X: 580, Y: 380}).
+		SetBackgroundColor(color.RGBA{R: 0.12, G: 0.12, B: 0.12, A: 1})
+}
@@ -0,0 +1,71 @@
+package examples
+
+import (
+	"fmt"
+
+	mogiApp "github.com/aj-2000/mogi/app"
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+var months = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+
+// fpsHistory holds the frame rate of the last frames, newest last.
+var fpsHistory []float32
+
+// ChartsComponent shows line, bar and area charts of made-up monthly figures
+// and a sparkline of the frame rate.
+func ChartsComponent(app *mogiApp.App) ui.IComponent {
+	fpsHistory = append(fpsHistory, float32(app.GetFPS()))
+	if len(fpsHistory) > 120 {
+		fpsHistory = fpsHistory[len(fpsHistory)-120:]
+	}
+
+	background := color.RGBA{R: 0.12, G: 0.12, B: 0.12, A: 1}
+	padding := math.Vec2f32{X: 8, Y: 8}
+
+	revenue := app.Chart(ui.ChartTypeLine,
+		ui.ChartSeries{Name: "Revenue", Values: []float32{12, 15, 14, 18, 22, 27, 25, 29, 31, 28, 34, 38}},
+		ui.ChartSeries{Name: "Costs", Values: []float32{10, 11, 13, 13, 15, 17, 18, 18, 21, 22, 23, 25}},
+	).
+		SetID("revenue").
+		SetLabels(months...).
+		SetSize(math.Vec2f32{X: 420, Y: 220}).
+		SetPadding(padding).
+		SetBackgroundColor(background)
+
+	signups := app.Chart(ui.ChartTypeBar,
+		ui.ChartSeries{Name: "Web", Values: []float32{320, 410, 380, 450, 520, 610}},
+		ui.ChartSeries{Name: "Mobile", Values: []float32{180, 240, 310, 360, 420, 530}},
+	).
+		SetID("signups").
+		SetLabels(months[:6]...).
+		SetSize(math.Vec2f32{X: 420, Y: 220}).
+		SetPadding(padding).
+		SetBackgroundColor(background)
+
+	balance := app.Chart(ui.ChartTypeArea,
+		ui.ChartSeries{Name: "Balance", Values: []float32{4.5, 3.2, 1.1, -0.8, -2.3, -1.2, 0.6, 2.9, 4.1, 3.3, 5.2, 6.8}},
+	).
+		SetID("balance").
+		SetLabels(months...).
+		SetSize(math.Vec2f32{X: 420, Y: 180}).
+		SetPadding(padding).
+		SetBackgroundColor(background)
+
+	fps := app.Container().
+		SetID("fps_row").
+		SetDisplay(ui.DisplayBlock).
+		SetGap(math.Vec2f32{X: 8}).
+		AddChildren(
+			app.Text(fmt.Sprintf("FPS %.0f", app.GetFPS())).SetID("fps_label").SetFontSize(16).SetColor(color.White),
+			app.Sparkline(fpsHistory...).SetID("fps_sparkline"),
+		)
+
+	return app.Container().
+		SetID("charts").
+		SetDisplay(ui.DisplayBlock).
+		SetGap(math.Vec2f32{X: 8, Y: 8}).
+		AddChildren(fps, revenue, signups, balance)
+}
@@ -0,0 +1,72 @@
+package examples
+
+import (
+	"fmt"
+
+	mogiApp "github.com/aj-2000/mogi/app"
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+type employee struct {
+	ID         string  `table:"ID,key,width=80"`
+	Name       string  `table:"Name,fraction=1,min=140"`
+	Department string  `table:"Department,agg=count"`
+	Age        int     `table:"Age,agg=avg"`
+	Salary     float64 `table:"Salary,agg=sum"`
+}
+
+var departments = []string{"Engineering", "Sales", "Support", "Finance"}
+
+// employeeDepartment is the department picked to filter by; empty for all.
+var employeeDepartment string
+
+// employees is generated once so that every frame shows the same data.
+var employees = func() []employee {
+	first := []string{"Asha", "Ben", "Chen", "Dana", "Eli", "Fatima", "Goran", "Hana"}
+	last := []string{"Kumar", "Lopez", "Nakamura", "Okafor", "Petrov", "Quinn"}
+	rows := make([]employee, 240)
+	for i := range rows {
+		rows[i] = employee{
+			ID:         fmt.Sprintf("E%04d", i+1),
+			Name:       first[i%len(first)] + " " + last[i*7%len(last)],
+			Department: departments[i*3%len(departments)],
+			Age:        22 + i*13%40,
+			Salary:     float64(42000 + i*977%60000),
+		}
+	}
+	return rows
+}()
+
+// EmployeesComponent shows a slice of structs in a paged table, filtered by
+// the department picked above it, with totals in the footer.
+func EmployeesComponent(app *mogiApp.App) ui.IComponent {
+	picker := app.Select(append([]string{"All departments"}, departments...)...).
+		SetID("department").
+		SetOnChange(func(self *ui.Select, index int, value string) {
+			employeeDepartment = ""
+			if index > 0 {
+				employeeDepartment = value
+			}
+		})
+
+	table := app.Table().
+		SetID("employees").
+		SetData(ui.NewSliceData(employees)).
+		SetFilterFunc(func(self *ui.Table, row int) bool {
+			return employeeDepartment == "" || employees[row].Department == employeeDepartment
+		}).
+		SetPageSize(20).
+		SetMultiSelect(true).
+		SetSize(math.Vec2f32{Y: 480}).
+		SetWidthPercent(100).
+		SetBackgroundColor(color.RGBA{R: 0.12, G: 0.12, B: 0.12, A: 1})
+
+	return app.Container().
+		SetID("employees_view").
+		SetDisplay(ui.DisplayBlock).
+		SetWidthPercent(100).
+		SetGap(math.Vec2f32{Y: 8}).
+		AddChildren(picker.SetDisplay(ui.DisplayBlock), table)
+}
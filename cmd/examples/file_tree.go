@@ -0,0 +1,53 @@
+package examples
+
+import (
+	"fmt"
+
+	mogiApp "github.com/aj-2000/mogi/app"
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// fileTree outlives the frame so that nodes moved by dragging stay moved.
+var fileTree = []*ui.TreeNode{
+	ui.NewTreeNode("app",
+		ui.NewTreeNode("app.go"),
+		ui.NewTreeNode("input.go"),
+		ui.NewTreeNode("renderer.go"),
+	),
+	ui.NewTreeNode("internal",
+		ui.NewTreeNode("ui",
+			ui.NewTreeNode("container.go"),
+			ui.NewTreeNode("layout.go"),
+			ui.NewTreeNode("tree_view.go"),
+		),
+		ui.NewTreeNode("bidi"),
+	),
+	{Label: "generated", Lazy: true},
+	ui.NewTreeNode("go.mod"),
+	ui.NewTreeNode("README.md"),
+}
+
+// FileTreeComponent shows a reorderable file tree with multiple selection.
+// The "generated" folder loads its children when first expanded.
+func FileTreeComponent(app *mogiApp.App) ui.IComponent {
+	return app.TreeView(fileTree...).
+		SetID("file_tree").
+		SetLoadChildren(func(node *ui.TreeNode) []*ui.TreeNode {
+			children := make([]*ui.TreeNode, 20)
+			for i := range children {
+				children[i] = ui.NewTreeNode(fmt.Sprintf("file_%02d.go", i))
+			}
+			return children
+		}).
+		SetExpanded("/app").
+		SetMultiSelect(true).
+		SetReorderable(true).
+		SetOnMove(func(self *ui.TreeView, node, parent *ui.TreeNode, index int) {
+			fileTree = ui.MoveTreeNode(fileTree, node, parent, index)
+		}).
+		SetSize(math.Vec2f32{X: 320}).
+		SetBackgroundColor(color.Black).
+		SetPadding(math.Vec2f32{X: 4, Y: 4})
+}
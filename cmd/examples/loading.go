@@ -0,0 +1,69 @@
+package examples
+
+import (
+	mogiApp "github.com/aj-2000/mogi/app"
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+// download is how far the pretend download has got, from 0 to 1.
+var download float32
+
+// LoadingComponent shows progress bars for a pretend download, busy
+// indicators and a card that stays a skeleton until the download finishes.
+func LoadingComponent(app *mogiApp.App) ui.IComponent {
+	download = min(1, download+app.GetDeltaTime()*0.15)
+	// Reported in steps of a tenth, so the bars can be seen easing between
+	// values.
+	reported := float32(int(download*10)) / 10
+
+	restart := app.Button("Restart").
+		SetID("loading_restart").
+		SetOnClick(func(self *ui.Button) { download = 0 })
+
+	bars := app.Container().
+		SetID("loading_bars").
+		SetDisplay(ui.DisplayFlex).
+		SetGap(math.Vec2f32{X: 16, Y: 8}).
+		AddChildren(
+			app.ProgressBar(reported).SetID("loading_linear").SetShowLabel(true).SetLength(240),
+			app.ProgressBar(reported).SetID("loading_ring").SetShape(ui.ProgressCircular).SetShowLabel(true).SetFontSize(12),
+			restart,
+		)
+
+	busy := app.Container().
+		SetID("loading_busy").
+		SetDisplay(ui.DisplayFlex).
+		SetGap(math.Vec2f32{X: 16, Y: 8}).
+		AddChildren(
+			ui.NewIndeterminateProgressBar().SetID("loading_sweep").SetLength(240),
+			ui.NewIndeterminateProgressBar().SetID("loading_arc").SetShape(ui.ProgressCircular).SetDiameter(32).SetThickness(4),
+			app.Spinner().SetID("loading_spinner"),
+			app.Spinner().SetID("loading_spinner_slow").SetDiameter(36).SetDots(12).SetSpeed(0.5).SetColor(color.RGBA{R: 0.3, G: 0.6, B: 1, A: 1}),
+		)
+
+	var card ui.IComponent
+	if download < 1 {
+		card = app.Container().
+			SetID("loading_card").
+			SetDisplay(ui.DisplayFlex).
+			SetGap(math.Vec2f32{X: 12}).
+			AddChildren(
+				app.Skeleton(ui.SkeletonCircle).SetID("loading_avatar"),
+				app.Skeleton(ui.SkeletonText).SetID("loading_lines").SetSize(260, 12),
+			)
+	} else {
+		card = app.Text("Download complete. The card's content would be shown here.").
+			SetID("loading_done").
+			SetFontSize(16).
+			SetColor(color.White)
+	}
+
+	return app.Container().
+		SetID("loading").
+		SetDisplay(ui.DisplayBlock).
+		SetGap(math.Vec2f32{X: 8, Y: 16}).
+		SetPadding(math.Vec2f32{X: 8, Y: 8}).
+		AddChildren(bars, busy, card)
+}
@@ -0,0 +1,38 @@
+package examples
+
+import (
+	"fmt"
+
+	mogiApp "github.com/aj-2000/mogi/app"
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/internal/ui"
+	"github.com/aj-2000/mogi/math"
+)
+
+var logLevels = []string{"INFO", "DEBUG", "WARN", "ERROR"}
+
+// LogViewComponent shows 100k log lines; only those on screen are built.
+// Every tenth line wraps, so its height differs from the estimate.
+func LogViewComponent(app *mogiApp.App) ui.IComponent {
+	const lines = 100_000
+
+	return app.VirtualList(lines,
+		func(i int) float32 { return 24 },
+		func(i int) ui.IComponent {
+			message := fmt.Sprintf("[%06d] %-5s request handled", i, logLevels[i%len(logLevels)])
+			if i%10 == 0 {
+				message += " after retrying the upstream connection, which had been reset by the peer while the response was being streamed"
+			}
+			return app.Container().
+				SetPadding(math.Vec2f32{X: 4, Y: 4}).
+				SetDisplay(ui.DisplayBlock).
+				AddChild(app.Text(message).
+					SetTextWrapped(true).
+					SetFontSize(16).
+					SetColor(color.White))
+		}).
+		SetID("log_view").
+		SetSize(math.Vec2f32{Y: 500}).
+		SetBackgroundColor(color.Black).
+		SetPadding(math.Vec2f32{X: 4, Y: 4})
+}
@@ -0,0 +1,80 @@
+package color
+
+// GradientKind is the shape of a gradient.
+type GradientKind int
+
+const (
+	// GradientLinear changes color along a line through the box.
+	GradientLinear GradientKind = iota
+	// GradientRadial changes color outward from a center, in ellipses
+	// reaching the farthest corner of the box.
+	GradientRadial
+)
+
+// GradientStop is a color at an offset along a gradient, from 0 to 1.
+type GradientStop struct {
+	Offset float32
+	Color  RGBA
+}
+
+// Gradient is a background that blends between colors, like CSS
+// linear-gradient() and radial-gradient().
+type Gradient struct {
+	Kind GradientKind
+	// Angle is the direction of a linear gradient in degrees, as in CSS:
+	// 0 runs bottom to top and 90 left to right.
+	Angle float32
+	// CenterX and CenterY are the center of a radial gradient, as fractions
+	// of the box's width and height.
+	CenterX, CenterY float32
+	// Stops are in order of offset.
+	Stops []GradientStop
+}
+
+func NewLinearGradient(angle float32, stops ...GradientStop) *Gradient {
+	return &Gradient{Kind: GradientLinear, Angle: angle, Stops: stops}
+}
+
+func NewRadialGradient(stops ...GradientStop) *Gradient {
+	return &Gradient{Kind: GradientRadial, CenterX: 0.5, CenterY: 0.5, Stops: stops}
+}
+
+// SetCenter moves the center of a radial gradient, in fractions of the box.
+func (g *Gradient) SetCenter(x, y float32) *Gradient {
+	g.CenterX, g.CenterY = x, y
+	return g
+}
+
+// At returns the color at offset t along the gradient. Before the first
+// stop and after the last the color is theirs; between two stops it is
+// mixed with Lerp.
+func (g *Gradient) At(t float32) RGBA {
+	if len(g.Stops) == 0 {
+		return Transparent
+	}
+	if t <= g.Stops[0].Offset {
+		return g.Stops[0].Color
+	}
+	for i := 1; i < len(g.Stops); i++ {
+		a, b := g.Stops[i-1], g.Stops[i]
+		if t <= b.Offset {
+			if b.Offset <= a.Offset {
+				return b.Color
+			}
+			return a.Color.Lerp(b.Color, (t-a.Offset)/(b.Offset-a.Offset))
+		}
+	}
+	return g.Stops[len(g.Stops)-1].Color
+}
+
+// Faded returns a copy of the gradient with every stop's alpha scaled by
+// opacity.
+func (g *Gradient) Faded(opacity float32) *Gradient {
+	faded := *g
+	faded.Stops = make([]GradientStop, len(g.Stops))
+	for i, s := range g.Stops {
+		s.Color.A *= opacity
+		faded.Stops[i] = s
+	}
+	return &faded
+}
@@ -33,6 +33,25 @@ func (h HSLA) ToRGBA() RGBA {
 	}
 	return RGBA{r, g, b, a}
 }
+
+// Lerp returns the color a fraction t of the way from h to to, turning the
+// hue the shorter way round the color wheel.
+func (h HSLA) Lerp(to HSLA, t float32) HSLA {
+	turn := to.H - h.H
+	if turn > 180 {
+		turn -= 360
+	} else if turn < -180 {
+		turn += 360
+	}
+	hue := h.H + turn*t
+	if hue < 0 {
+		hue += 360
+	} else if hue >= 360 {
+		hue -= 360
+	}
+	return HSLA{H: hue, S: h.S + (to.S-h.S)*t, L: h.L + (to.L-h.L)*t, A: h.A + (to.A-h.A)*t}
+}
+
 func (h HSLA) String() string {
 	var sb strings.Builder
 	sb.Grow(40) // rough upper bound for "hsla(360.00,1.00,1.00,1.00)"
@@ -28,6 +28,27 @@ func (c RGBA) BlendOver(dst RGBA) RGBA {
 	}
 }
 
+// Lerp returns the color a fraction t of the way from c to to. The channels
+// are mixed premultiplied by alpha, so fading to or from a transparent color
+// does not pass through its (invisible) hue: red fading to transparent black
+// stays red as it fades.
+func (c RGBA) Lerp(to RGBA, t float32) RGBA {
+	a := c.A + (to.A-c.A)*t
+	if a == 0 {
+		return RGBA{}
+	}
+	mix := func(x, y float32) float32 {
+		return (x*c.A + (y*to.A-x*c.A)*t) / a
+	}
+	return RGBA{R: mix(c.R, to.R), G: mix(c.G, to.G), B: mix(c.B, to.B), A: a}
+}
+
+// WithAlpha returns c with its alpha replaced by a.
+func (c RGBA) WithAlpha(a float32) RGBA {
+	c.A = a
+	return c
+}
+
 // String returns "rgba(r, g, b, a)", with two decimal places.
 // NOTE: it loses precision for large values (e.g. 1.23456789 becomes 1.23).
 // It is not intended for high-precision use, but for human-readable output.
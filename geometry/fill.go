@@ -0,0 +1,155 @@
+package geometry
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fills
+// ——————————————————————————————————————————————————————————————————————————————
+
+// FillRule decides which areas enclosed by a path are inside it.
+type FillRule int
+
+const (
+	// NonZero fills wherever the contours wind around a point a nonzero
+	// number of times, counting clockwise turns against counterclockwise
+	// ones.
+	NonZero FillRule = iota
+	// EvenOdd fills wherever a ray from a point crosses an odd number of
+	// edges, so that nested contours make holes whichever way they run.
+	EvenOdd
+)
+
+func (r FillRule) inside(winding int) bool {
+	if r == EvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// edge is a non-horizontal edge of a contour, top to bottom, with the
+// direction it ran in: 1 downwards, -1 upwards.
+type edge struct {
+	top, bottom math.Vec2f32
+	winding     int
+}
+
+func (e edge) xAt(y float32) float32 {
+	t := (y - e.top.Y) / (e.bottom.Y - e.top.Y)
+	return e.top.X + t*(e.bottom.X-e.top.X)
+}
+
+// Fill tessellates the area inside contours by rule into triangles. Open
+// contours are filled as if closed. The contours may be concave, cross
+// themselves and each other, and nest to make holes.
+//
+// The area is cut into horizontal bands at every vertex and crossing, so
+// that no edges cross within a band; the spans between edges that are
+// inside are then trapezoids.
+func Fill(contours []Contour, rule FillRule) Mesh {
+	var edges []edge
+	var ys []float32
+	for _, c := range contours {
+		n := len(c.Points)
+		if n < 3 {
+			continue
+		}
+		for i := range n {
+			a, b := c.Points[i], c.Points[(i+1)%n]
+			if a.Y == b.Y {
+				// Horizontal edges bound no span.
+				continue
+			}
+			winding := 1
+			if a.Y > b.Y {
+				a, b, winding = b, a, -1
+			}
+			edges = append(edges, edge{top: a, bottom: b, winding: winding})
+			ys = append(ys, a.Y, b.Y)
+		}
+	}
+	if len(edges) < 2 {
+		return Mesh{}
+	}
+
+	slices.SortFunc(edges, func(a, b edge) int { return cmp.Compare(a.top.Y, b.top.Y) })
+	for i, a := range edges {
+		for _, b := range edges[i+1:] {
+			if b.top.Y >= a.bottom.Y {
+				break
+			}
+			if y, ok := crossing(a, b); ok {
+				ys = append(ys, y)
+			}
+		}
+	}
+	slices.Sort(ys)
+	ys = slices.Compact(ys)
+
+	var mesh Mesh
+	type span struct {
+		x0, x1, mid float32
+		winding     int
+	}
+	var active []edge
+	var spans []span
+	next := 0
+	for k := 0; k+1 < len(ys); k++ {
+		y0, y1 := ys[k], ys[k+1]
+		if y1-y0 < 1e-5 {
+			continue
+		}
+		mid := (y0 + y1) / 2
+		for next < len(edges) && edges[next].top.Y < mid {
+			active = append(active, edges[next])
+			next++
+		}
+		active = slices.DeleteFunc(active, func(e edge) bool { return e.bottom.Y <= mid })
+
+		spans = spans[:0]
+		for _, e := range active {
+			spans = append(spans, span{x0: e.xAt(y0), x1: e.xAt(y1), mid: e.xAt(mid), winding: e.winding})
+		}
+		slices.SortFunc(spans, func(a, b span) int { return cmp.Compare(a.mid, b.mid) })
+
+		winding := 0
+		for i := 0; i+1 < len(spans); i++ {
+			winding += spans[i].winding
+			if !rule.inside(winding) {
+				continue
+			}
+			left, right := spans[i], spans[i+1]
+			mesh.quad(
+				opaque(math.Vec2f32{X: left.x0, Y: y0}),
+				opaque(math.Vec2f32{X: right.x0, Y: y0}),
+				opaque(math.Vec2f32{X: right.x1, Y: y1}),
+				opaque(math.Vec2f32{X: left.x1, Y: y1}),
+			)
+		}
+	}
+	return mesh
+}
+
+// crossing returns the y at which edges a and b cross, if they cross
+// between their ends.
+func crossing(a, b edge) (float32, bool) {
+	// Solved in float64 so that nearly parallel edges stay accurate.
+	ax, ay := float64(a.top.X), float64(a.top.Y)
+	dax, day := float64(a.bottom.X)-ax, float64(a.bottom.Y)-ay
+	bx, by := float64(b.top.X), float64(b.top.Y)
+	dbx, dby := float64(b.bottom.X)-bx, float64(b.bottom.Y)-by
+	denom := dax*dby - day*dbx
+	if denom == 0 {
+		return 0, false
+	}
+	t := ((bx-ax)*dby - (by-ay)*dbx) / denom
+	u := ((bx-ax)*day - (by-ay)*dax) / denom
+	if t <= 0 || t >= 1 || u <= 0 || u >= 1 {
+		return 0, false
+	}
+	return float32(ay + t*day), true
+}
@@ -0,0 +1,187 @@
+package geometry
+
+import (
+	gomath "math"
+	"testing"
+
+	"github.com/aj-2000/mogi/math"
+)
+
+func points(xy ...float32) []math.Vec2f32 {
+	out := make([]math.Vec2f32, len(xy)/2)
+	for i := range out {
+		out[i] = math.Vec2f32{X: xy[2*i], Y: xy[2*i+1]}
+	}
+	return out
+}
+
+// meshArea sums the areas of the mesh's triangles.
+func meshArea(m Mesh) float64 {
+	var area float64
+	for i := 0; i+2 < len(m.Vertices); i += 3 {
+		a, b, c := m.Vertices[i].Pos, m.Vertices[i+1].Pos, m.Vertices[i+2].Pos
+		area += gomath.Abs(float64((b.X-a.X)*(c.Y-a.Y)-(c.X-a.X)*(b.Y-a.Y))) / 2
+	}
+	return area
+}
+
+// covers reports whether any triangle of the mesh contains p.
+func covers(m Mesh, p math.Vec2f32) bool {
+	side := func(a, b math.Vec2f32) float32 { return (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X) }
+	for i := 0; i+2 < len(m.Vertices); i += 3 {
+		a, b, c := m.Vertices[i].Pos, m.Vertices[i+1].Pos, m.Vertices[i+2].Pos
+		d1, d2, d3 := side(a, b), side(b, c), side(c, a)
+		if (d1 >= 0 && d2 >= 0 && d3 >= 0) || (d1 <= 0 && d2 <= 0 && d3 <= 0) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFill(t *testing.T) {
+	square := func(x, y, size float32, clockwise bool) Contour {
+		c := Contour{Points: points(x, y, x+size, y, x+size, y+size, x, y+size), Closed: true}
+		if !clockwise {
+			c.Points = points(x, y, x, y+size, x+size, y+size, x+size, y)
+		}
+		return c
+	}
+	// A five-pointed star drawn in one stroke; its middle is wound twice.
+	var star Contour
+	for i := range 5 {
+		angle := -gomath.Pi/2 + float64(i)*4*gomath.Pi/5
+		star.Points = append(star.Points, math.Vec2f32{X: 50 + 40*float32(gomath.Cos(angle)), Y: 50 + 40*float32(gomath.Sin(angle))})
+	}
+
+	tests := []struct {
+		name     string
+		contours []Contour
+		rule     FillRule
+		area     float64
+		inside   []math.Vec2f32
+		outside  []math.Vec2f32
+	}{
+		{
+			name:     "convex square",
+			contours: []Contour{square(0, 0, 10, true)},
+			area:     100,
+			inside:   points(5, 5, 0.5, 9.5),
+			outside:  points(-1, 5, 11, 5),
+		},
+		{
+			name:     "convex triangle, open",
+			contours: []Contour{{Points: points(0, 0, 10, 0, 0, 10)}},
+			area:     50,
+			inside:   points(2, 2),
+			outside:  points(8, 8),
+		},
+		{
+			name:     "concave L",
+			contours: []Contour{{Points: points(0, 0, 10, 0, 10, 4, 4, 4, 4, 10, 0, 10), Closed: true}},
+			area:     64,
+			inside:   points(8, 2, 2, 8),
+			outside:  points(7, 7),
+		},
+		{
+			name:     "concave comb",
+			contours: []Contour{{Points: points(0, 0, 30, 0, 30, 20, 25, 20, 20, 5, 15, 20, 10, 5, 5, 20, 0, 20), Closed: true}},
+			area:     600 - 2*75,
+			inside:   points(2, 18, 15, 4),
+			outside:  points(10, 15, 20, 15),
+		},
+		{
+			name:     "self-intersecting bowtie",
+			contours: []Contour{{Points: points(0, 0, 10, 10, 10, 0, 0, 10), Closed: true}},
+			area:     50,
+			inside:   points(2, 5, 8, 5),
+			outside:  points(5, 2, 5, 8),
+		},
+		{
+			name:     "star, non-zero",
+			contours: []Contour{star},
+			rule:     NonZero,
+			inside:   points(50, 50),
+		},
+		{
+			name:     "star, even-odd",
+			contours: []Contour{star},
+			rule:     EvenOdd,
+			outside:  points(50, 50),
+		},
+		{
+			name:     "hole wound the same way, non-zero",
+			contours: []Contour{square(0, 0, 10, true), square(3, 3, 4, true)},
+			rule:     NonZero,
+			area:     100,
+			inside:   points(5, 5),
+		},
+		{
+			name:     "hole wound the same way, even-odd",
+			contours: []Contour{square(0, 0, 10, true), square(3, 3, 4, true)},
+			rule:     EvenOdd,
+			area:     84,
+			outside:  points(5, 5),
+		},
+		{
+			name:     "hole wound the other way, non-zero",
+			contours: []Contour{square(0, 0, 10, true), square(3, 3, 4, false)},
+			rule:     NonZero,
+			area:     84,
+			outside:  points(5, 5),
+		},
+		{
+			name:     "overlapping squares",
+			contours: []Contour{square(0, 0, 10, true), square(5, 5, 10, true)},
+			area:     175,
+			inside:   points(7, 7, 12, 12),
+		},
+		{
+			name:     "too few points",
+			contours: []Contour{{Points: points(0, 0, 10, 10)}},
+		},
+		{
+			name:     "collinear",
+			contours: []Contour{{Points: points(0, 0, 5, 5, 10, 10)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mesh := Fill(tt.contours, tt.rule)
+			if len(mesh.Vertices)%3 != 0 {
+				t.Fatalf("mesh has %d vertices, not whole triangles", len(mesh.Vertices))
+			}
+			if tt.area != 0 || len(tt.inside)+len(tt.outside) == 0 {
+				if got := meshArea(mesh); gomath.Abs(got-tt.area) > 1e-3*max(1, tt.area) {
+					t.Errorf("area = %v, want %v", got, tt.area)
+				}
+			}
+			for _, p := range tt.inside {
+				if !covers(mesh, p) {
+					t.Errorf("%v is not filled", p)
+				}
+			}
+			for _, p := range tt.outside {
+				if covers(mesh, p) {
+					t.Errorf("%v is filled", p)
+				}
+			}
+		})
+	}
+}
+
+func TestFillStarRules(t *testing.T) {
+	var star Contour
+	for i := range 5 {
+		angle := -gomath.Pi/2 + float64(i)*4*gomath.Pi/5
+		star.Points = append(star.Points, math.Vec2f32{X: 40 * float32(gomath.Cos(angle)), Y: 40 * float32(gomath.Sin(angle))})
+	}
+	nonZero := meshArea(Fill([]Contour{star}, NonZero))
+	evenOdd := meshArea(Fill([]Contour{star}, EvenOdd))
+	// The rules differ by the pentagon in the middle, whose circumradius is
+	// the star's times sin(18°)/sin(126°).
+	r := 40 * gomath.Sin(gomath.Pi/10) / gomath.Sin(7*gomath.Pi/10)
+	pentagon := 5.0 / 2 * r * r * gomath.Sin(2*gomath.Pi/5)
+	if diff := nonZero - evenOdd; gomath.Abs(diff-pentagon) > 0.05 {
+		t.Errorf("non-zero area %v - even-odd area %v = %v, want the pentagon's %v", nonZero, evenOdd, diff, pentagon)
+	}
+}
@@ -0,0 +1,136 @@
+package geometry
+
+import (
+	"cmp"
+	gomath "math"
+	"slices"
+
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Coverage masks
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Coverage is how much of each pixel in a box a shape covers, as an alpha
+// mask. A backend that draws on the CPU clips to a shape by scaling what it
+// draws by the coverage, where GL tests its stencil buffer instead.
+type Coverage struct {
+	// X, Y, W and H are the box of pixels the mask covers.
+	X, Y, W, H int
+	// Alpha holds the coverage of the pixels row by row, from 0 outside the
+	// shape to 255 inside it.
+	Alpha []uint8
+}
+
+// coverageSubrows is how many rows each pixel is sampled at. Across a row
+// coverage is exact.
+const coverageSubrows = 4
+
+// CoverageMask rasterizes the area inside contours by rule into a coverage
+// mask over the pixels within bounds, with anti-aliased edges. Open
+// contours are filled as if closed, as by Fill.
+func CoverageMask(contours []Contour, rule FillRule, bounds math.Rect) Coverage {
+	var edges []edge
+	lo, hi := bounds.Max(), bounds.Pos
+	for _, c := range contours {
+		n := len(c.Points)
+		if n < 3 {
+			continue
+		}
+		for i := range n {
+			a, b := c.Points[i], c.Points[(i+1)%n]
+			lo, hi = lo.Min(a), hi.Max(a)
+			if a.Y == b.Y {
+				continue
+			}
+			winding := 1
+			if a.Y > b.Y {
+				a, b, winding = b, a, -1
+			}
+			edges = append(edges, edge{top: a, bottom: b, winding: winding})
+		}
+	}
+	lo, hi = lo.Max(bounds.Pos).Floor(), hi.Min(bounds.Max())
+	x0, y0 := int(lo.X), int(lo.Y)
+	x1, y1 := int(gomath.Ceil(float64(hi.X))), int(gomath.Ceil(float64(hi.Y)))
+	if len(edges) < 2 || x1 <= x0 || y1 <= y0 {
+		return Coverage{}
+	}
+
+	mask := Coverage{X: x0, Y: y0, W: x1 - x0, H: y1 - y0}
+	mask.Alpha = make([]uint8, mask.W*mask.H)
+	row := make([]float32, mask.W)
+	// crossing is where an edge crosses a sample row, relative to x0.
+	type crossing struct {
+		x       float32
+		winding int
+	}
+	var crossings []crossing
+	for y := range mask.H {
+		clear(row)
+		for k := range coverageSubrows {
+			sy := float32(y0+y) + (float32(k)+0.5)/coverageSubrows
+			crossings = crossings[:0]
+			for _, e := range edges {
+				if e.top.Y <= sy && sy < e.bottom.Y {
+					crossings = append(crossings, crossing{x: e.xAt(sy) - float32(x0), winding: e.winding})
+				}
+			}
+			slices.SortFunc(crossings, func(a, b crossing) int { return cmp.Compare(a.x, b.x) })
+
+			winding := 0
+			for i := 0; i+1 < len(crossings); i++ {
+				winding += crossings[i].winding
+				if rule.inside(winding) {
+					addSpan(row, crossings[i].x, crossings[i+1].x, 1.0/coverageSubrows)
+				}
+			}
+		}
+		for x, covered := range row {
+			mask.Alpha[y*mask.W+x] = uint8(min(1, covered)*255 + 0.5)
+		}
+	}
+	return mask
+}
+
+// addSpan adds weight times how much of each pixel of row the span from a
+// to b covers.
+func addSpan(row []float32, a, b, weight float32) {
+	a, b = max(a, 0), min(b, float32(len(row)))
+	if b <= a {
+		return
+	}
+	first, last := int(a), int(b)
+	if first == last {
+		row[first] += (b - a) * weight
+		return
+	}
+	row[first] += (float32(first+1) - a) * weight
+	for x := first + 1; x < last; x++ {
+		row[x] += weight
+	}
+	if last < len(row) {
+		row[last] += (b - float32(last)) * weight
+	}
+}
+
+// At returns the coverage of the pixel at x, y, 0 outside the mask.
+func (c *Coverage) At(x, y int) uint8 {
+	x, y = x-c.X, y-c.Y
+	if x < 0 || y < 0 || x >= c.W || y >= c.H {
+		return 0
+	}
+	return c.Alpha[y*c.W+x]
+}
+
+// Intersect scales c by o, so that c clips to both shapes, as nested masks
+// do.
+func (c *Coverage) Intersect(o *Coverage) {
+	for y := range c.H {
+		for x := range c.W {
+			i := y*c.W + x
+			c.Alpha[i] = uint8((int(c.Alpha[i])*int(o.At(c.X+x, c.Y+y)) + 127) / 255)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/aj-2000/mogi/math"
+)
+
+func square(x0, y0, x1, y1 float32) Contour {
+	return Contour{Points: points(x0, y0, x1, y0, x1, y1, x0, y1), Closed: true}
+}
+
+func TestCoverageMask(t *testing.T) {
+	screen := math.Rect{Size: math.Vec2f32{X: 100, Y: 100}}
+	tests := []struct {
+		name     string
+		contours []Contour
+		rule     FillRule
+		bounds   math.Rect
+		box      [4]int // X, Y, W, H
+		want     map[[2]int]uint8
+	}{
+		{
+			name:     "pixel aligned square",
+			contours: []Contour{square(1, 1, 3, 3)},
+			bounds:   screen,
+			box:      [4]int{1, 1, 2, 2},
+			want:     map[[2]int]uint8{{1, 1}: 255, {2, 2}: 255, {0, 0}: 0, {3, 3}: 0},
+		},
+		{
+			name:     "half covered pixels",
+			contours: []Contour{square(0.5, 0, 2, 1.5)},
+			bounds:   screen,
+			box:      [4]int{0, 0, 2, 2},
+			want:     map[[2]int]uint8{{0, 0}: 128, {1, 0}: 255, {1, 1}: 128, {0, 1}: 64},
+		},
+		{
+			name:     "counter-clockwise",
+			contours: []Contour{{Points: points(1, 1, 1, 3, 3, 3, 3, 1), Closed: true}},
+			bounds:   screen,
+			box:      [4]int{1, 1, 2, 2},
+			want:     map[[2]int]uint8{{1, 1}: 255, {2, 2}: 255},
+		},
+		{
+			name:     "even-odd hole",
+			contours: []Contour{square(0, 0, 4, 4), square(1, 1, 3, 3)},
+			rule:     EvenOdd,
+			bounds:   screen,
+			box:      [4]int{0, 0, 4, 4},
+			want:     map[[2]int]uint8{{0, 0}: 255, {1, 1}: 0, {2, 2}: 0, {3, 3}: 255},
+		},
+		{
+			name:     "non-zero fills the hole",
+			contours: []Contour{square(0, 0, 4, 4), square(1, 1, 3, 3)},
+			bounds:   screen,
+			box:      [4]int{0, 0, 4, 4},
+			want:     map[[2]int]uint8{{0, 0}: 255, {1, 1}: 255, {2, 2}: 255},
+		},
+		{
+			name:     "clipped to the bounds",
+			contours: []Contour{square(-1e6, -1e6, 1e6, 1e6)},
+			bounds:   math.Rect{Pos: math.Vec2f32{X: 10, Y: 20}, Size: math.Vec2f32{X: 30, Y: 5}},
+			box:      [4]int{10, 20, 30, 5},
+			want:     map[[2]int]uint8{{10, 20}: 255, {39, 24}: 255, {40, 24}: 0},
+		},
+		{
+			name:     "outside the bounds",
+			contours: []Contour{square(200, 200, 300, 300)},
+			bounds:   screen,
+			want:     map[[2]int]uint8{{250, 250}: 0},
+		},
+		{name: "nothing", bounds: screen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mask := CoverageMask(tt.contours, tt.rule, tt.bounds)
+			if box := [4]int{mask.X, mask.Y, mask.W, mask.H}; box != tt.box {
+				t.Fatalf("mask covers %v, want %v", box, tt.box)
+			}
+			if len(mask.Alpha) != mask.W*mask.H {
+				t.Fatalf("%d alpha values for %d×%d pixels", len(mask.Alpha), mask.W, mask.H)
+			}
+			for p, want := range tt.want {
+				if got := mask.At(p[0], p[1]); got != want {
+					t.Errorf("At(%d, %d) = %d, want %d", p[0], p[1], got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCoverageMaskRoundedRect(t *testing.T) {
+	outline := RoundedRect(math.Vec2f32{}, math.Vec2f32{X: 40, Y: 20}, 8, CornerSegments(8))
+	mask := CoverageMask([]Contour{{Points: outline, Closed: true}}, NonZero, math.Rect{Size: math.Vec2f32{X: 100, Y: 100}})
+	if got := mask.At(0, 0); got != 0 {
+		t.Errorf("corner pixel = %d, want 0", got)
+	}
+	if got := mask.At(20, 10); got != 255 {
+		t.Errorf("centre pixel = %d, want 255", got)
+	}
+	if got := mask.At(20, 0); got != 255 {
+		t.Errorf("top edge pixel = %d, want 255", got)
+	}
+	// The arc cuts through the pixels around the corner.
+	if got := mask.At(2, 2); got == 0 || got == 255 {
+		t.Errorf("pixel on the arc = %d, want partly covered", got)
+	}
+}
+
+func TestCoverageIntersect(t *testing.T) {
+	screen := math.Rect{Size: math.Vec2f32{X: 10, Y: 10}}
+	mask := CoverageMask([]Contour{square(0, 0, 4, 4)}, NonZero, screen)
+	mask.Intersect(&Coverage{X: 2, Y: 0, W: 4, H: 4, Alpha: []uint8{
+		255, 128, 0, 0,
+		255, 128, 0, 0,
+		255, 128, 0, 0,
+		255, 128, 0, 0,
+	}})
+	for x, want := range []uint8{0, 0, 255, 128} {
+		if got := mask.At(x, 1); got != want {
+			t.Errorf("At(%d, 1) = %d, want %d", x, got, want)
+		}
+	}
+}
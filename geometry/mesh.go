@@ -0,0 +1,47 @@
+package geometry
+
+import "github.com/aj-2000/mogi/math"
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Meshes
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Vertex is a corner of a triangle. Alpha scales the opacity of the color
+// the mesh is drawn in; it falls to zero across the fringes that smooth the
+// edges of strokes.
+type Vertex struct {
+	Pos   math.Vec2f32
+	Alpha float32
+}
+
+// Mesh is a list of triangles, three vertices each.
+type Mesh struct {
+	Vertices []Vertex
+}
+
+// Triangles returns the number of triangles in the mesh.
+func (m *Mesh) Triangles() int { return len(m.Vertices) / 3 }
+
+// Append adds the triangles of other.
+func (m *Mesh) Append(other Mesh) {
+	m.Vertices = append(m.Vertices, other.Vertices...)
+}
+
+// Translate moves every vertex by offset.
+func (m *Mesh) Translate(offset math.Vec2f32) {
+	for i := range m.Vertices {
+		m.Vertices[i].Pos = math.Vec2f32{X: m.Vertices[i].Pos.X + offset.X, Y: m.Vertices[i].Pos.Y + offset.Y}
+	}
+}
+
+func (m *Mesh) triangle(a, b, c Vertex) {
+	m.Vertices = append(m.Vertices, a, b, c)
+}
+
+// quad adds the quadrilateral a, b, c, d (in order around it) as two
+// triangles.
+func (m *Mesh) quad(a, b, c, d Vertex) {
+	m.Vertices = append(m.Vertices, a, b, c, a, c, d)
+}
+
+func opaque(p math.Vec2f32) Vertex { return Vertex{Pos: p, Alpha: 1} }
@@ -0,0 +1,234 @@
+// Package geometry turns vector paths into triangles: it flattens Bézier
+// curves and arcs into polylines, tessellates fills and strokes outlines,
+// producing triangle lists that any backend can draw.
+package geometry
+
+import (
+	gomath "math"
+
+	"github.com/aj-2000/mogi/math"
+)
+
+// DefaultTolerance is how far, in pixels, a flattened curve may stray from
+// the true curve.
+const DefaultTolerance float32 = 0.25
+
+// MinTolerance is the smallest tolerance curves are flattened with; smaller
+// ones, including zero, negative and NaN, are raised to it.
+const MinTolerance float32 = 0.01
+
+// maxSubdivisions bounds the recursion of curve flattening, and maxArcSegments
+// the chords of an arc.
+const (
+	maxSubdivisions = 16
+	maxArcSegments  = 1024
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Paths
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Contour is a polyline, closed back to its first point if Closed.
+type Contour struct {
+	Points []math.Vec2f32
+	Closed bool
+}
+
+// Path is built from lines, curves and arcs like an HTML canvas path, and
+// keeps them flattened into contours. Each MoveTo (or shape such as Rect)
+// starts a new contour.
+type Path struct {
+	// Tolerance is how closely curves are followed; zero means
+	// DefaultTolerance.
+	Tolerance float32
+	contours  []Contour
+}
+
+// Contours returns the path's contours.
+func (p *Path) Contours() []Contour { return p.contours }
+
+// Reset empties the path.
+func (p *Path) Reset() *Path {
+	p.contours = p.contours[:0]
+	return p
+}
+
+func (p *Path) tolerance() float32 {
+	if p.Tolerance > 0 {
+		return p.Tolerance
+	}
+	return DefaultTolerance
+}
+
+// MoveTo starts a new contour at point.
+func (p *Path) MoveTo(point math.Vec2f32) *Path {
+	p.contours = append(p.contours, Contour{Points: []math.Vec2f32{point}})
+	return p
+}
+
+// current returns the open contour to add to, starting one at point if
+// there is none.
+func (p *Path) current(point math.Vec2f32) *Contour {
+	if n := len(p.contours); n > 0 && !p.contours[n-1].Closed {
+		return &p.contours[n-1]
+	}
+	p.MoveTo(point)
+	return &p.contours[len(p.contours)-1]
+}
+
+// last returns the last point of the current contour.
+func (c *Contour) last() math.Vec2f32 { return c.Points[len(c.Points)-1] }
+
+// LineTo adds a straight line to point.
+func (p *Path) LineTo(point math.Vec2f32) *Path {
+	c := p.current(point)
+	if c.last() != point {
+		c.Points = append(c.Points, point)
+	}
+	return p
+}
+
+// QuadTo adds a quadratic Bézier curve through control to point.
+func (p *Path) QuadTo(control, point math.Vec2f32) *Path {
+	c := p.current(control)
+	c.Points = FlattenQuad(c.Points, c.last(), control, point, p.tolerance())
+	return p
+}
+
+// CubicTo adds a cubic Bézier curve through control points c1 and c2 to
+// point.
+func (p *Path) CubicTo(c1, c2, point math.Vec2f32) *Path {
+	c := p.current(c1)
+	c.Points = FlattenCubic(c.Points, c.last(), c1, c2, point, p.tolerance())
+	return p
+}
+
+// Arc adds an arc of the circle around center from angle start to angle end,
+// in radians clockwise from the positive x axis, sweeping counterclockwise
+// when end is less than start. A line joins the current point to the start
+// of the arc.
+func (p *Path) Arc(center math.Vec2f32, radius, start, end float32) *Path {
+	p.LineTo(pointOnCircle(center, radius, float64(start)))
+	c := &p.contours[len(p.contours)-1]
+	c.Points = FlattenArc(c.Points, center, radius, start, end, p.tolerance())
+	return p
+}
+
+// Close closes the current contour.
+func (p *Path) Close() *Path {
+	if n := len(p.contours); n > 0 {
+		c := &p.contours[n-1]
+		if len(c.Points) > 1 && c.Points[0] == c.last() {
+			c.Points = c.Points[:len(c.Points)-1]
+		}
+		c.Closed = true
+	}
+	return p
+}
+
+// Rect adds a closed rectangle at pos of size.
+func (p *Path) Rect(pos, size math.Vec2f32) *Path {
+	p.MoveTo(pos)
+	p.LineTo(math.Vec2f32{X: pos.X + size.X, Y: pos.Y})
+	p.LineTo(math.Vec2f32{X: pos.X + size.X, Y: pos.Y + size.Y})
+	p.LineTo(math.Vec2f32{X: pos.X, Y: pos.Y + size.Y})
+	return p.Close()
+}
+
+// Ellipse adds a closed ellipse around center with the horizontal and
+// vertical radii.
+func (p *Path) Ellipse(center, radii math.Vec2f32) *Path {
+	n := arcSegments(max(radii.X, radii.Y), 2*gomath.Pi, p.tolerance())
+	for i := range n {
+		angle := 2 * gomath.Pi * float64(i) / float64(n)
+		point := math.Vec2f32{X: center.X + radii.X*float32(gomath.Cos(angle)), Y: center.Y + radii.Y*float32(gomath.Sin(angle))}
+		if i == 0 {
+			p.MoveTo(point)
+		} else {
+			p.LineTo(point)
+		}
+	}
+	return p.Close()
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Flattening
+// ——————————————————————————————————————————————————————————————————————————————
+
+// FlattenQuad appends points along the quadratic Bézier curve from p0
+// through control to p1, excluding p0, to dst.
+func FlattenQuad(dst []math.Vec2f32, p0, control, p1 math.Vec2f32, tolerance float32) []math.Vec2f32 {
+	// The same curve as a cubic.
+	c1 := math.Vec2f32{X: p0.X + 2*(control.X-p0.X)/3, Y: p0.Y + 2*(control.Y-p0.Y)/3}
+	c2 := math.Vec2f32{X: p1.X + 2*(control.X-p1.X)/3, Y: p1.Y + 2*(control.Y-p1.Y)/3}
+	return FlattenCubic(dst, p0, c1, c2, p1, tolerance)
+}
+
+// FlattenCubic appends points along the cubic Bézier curve from p0 through
+// c1 and c2 to p3, excluding p0, to dst. The curve is split in half until
+// each piece is flat enough, so straighter parts get fewer points.
+func FlattenCubic(dst []math.Vec2f32, p0, c1, c2, p3 math.Vec2f32, tolerance float32) []math.Vec2f32 {
+	tolerance = clampTolerance(tolerance)
+	return flattenCubic(dst, p0, c1, c2, p3, 16*tolerance*tolerance, 0)
+}
+
+func flattenCubic(dst []math.Vec2f32, p0, c1, c2, p3 math.Vec2f32, flatness float32, depth int) []math.Vec2f32 {
+	// How far the control points are from where a straight line would put
+	// them; 16 times the square of the curve's greatest distance from its
+	// chord bounds it.
+	ux, uy := 3*c1.X-2*p0.X-p3.X, 3*c1.Y-2*p0.Y-p3.Y
+	vx, vy := 3*c2.X-p0.X-2*p3.X, 3*c2.Y-p0.Y-2*p3.Y
+	if max(ux*ux, vx*vx)+max(uy*uy, vy*vy) <= flatness || depth >= maxSubdivisions {
+		return append(dst, p3)
+	}
+	// de Casteljau at t = 0.5.
+	mid := func(a, b math.Vec2f32) math.Vec2f32 { return math.Vec2f32{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2} }
+	a, b, c := mid(p0, c1), mid(c1, c2), mid(c2, p3)
+	ab, bc := mid(a, b), mid(b, c)
+	m := mid(ab, bc)
+	dst = flattenCubic(dst, p0, a, ab, m, flatness, depth+1)
+	return flattenCubic(dst, m, bc, c, p3, flatness, depth+1)
+}
+
+// FlattenArc appends points along the arc of the circle around center from
+// angle start to angle end, excluding the start, to dst. Larger circles get
+// more points.
+func FlattenArc(dst []math.Vec2f32, center math.Vec2f32, radius, start, end, tolerance float32) []math.Vec2f32 {
+	sweep := float64(end - start)
+	n := arcSegments(radius, gomath.Abs(sweep), tolerance)
+	for i := 1; i <= n; i++ {
+		dst = append(dst, pointOnCircle(center, radius, float64(start)+sweep*float64(i)/float64(n)))
+	}
+	return dst
+}
+
+// arcSegments returns how many chords follow an arc of radius through angle
+// sweep within tolerance, from 1 to maxArcSegments.
+func arcSegments(radius float32, sweep float64, tolerance float32) int {
+	tolerance = clampTolerance(tolerance)
+	step := gomath.Pi / 2
+	if radius > tolerance {
+		// A chord through angle a strays r(1 - cos(a/2)) from the arc.
+		step = 2 * gomath.Acos(1-float64(tolerance/radius))
+	}
+	n := gomath.Ceil(sweep / step)
+	switch {
+	case !(n < maxArcSegments):
+		// Also catches an infinite or NaN sweep.
+		return maxArcSegments
+	case n < 1:
+		return 1
+	}
+	return int(n)
+}
+
+func clampTolerance(tolerance float32) float32 {
+	if !(tolerance >= MinTolerance) {
+		return MinTolerance
+	}
+	return tolerance
+}
+
+func pointOnCircle(center math.Vec2f32, radius float32, angle float64) math.Vec2f32 {
+	return math.Vec2f32{X: center.X + radius*float32(gomath.Cos(angle)), Y: center.Y + radius*float32(gomath.Sin(angle))}
+}
@@ -0,0 +1,106 @@
+package geometry
+
+import (
+	gomath "math"
+	"testing"
+
+	"github.com/aj-2000/mogi/math"
+)
+
+func TestArcSegments(t *testing.T) {
+	nan := float32(gomath.NaN())
+	tests := []struct {
+		name      string
+		radius    float32
+		sweep     float64
+		tolerance float32
+		want      int
+	}{
+		{"full circle", 100, 2 * gomath.Pi, 0.25, 45},
+		{"half circle", 100, gomath.Pi, 0.25, 23},
+		{"coarser tolerance", 100, 2 * gomath.Pi, 1, 23},
+		{"small circle", 4, 2 * gomath.Pi, 0.25, 9},
+		{"radius within tolerance", 0.2, 2 * gomath.Pi, 0.25, 4},
+		{"radius within tolerance, short sweep", 0.2, 0.1, 0.25, 1},
+		{"zero sweep", 100, 0, 0.25, 1},
+		{"zero radius", 0, gomath.Pi, 0.25, 2},
+		{"zero tolerance", 100, 2 * gomath.Pi, 0, 223},
+		{"negative tolerance", 100, 2 * gomath.Pi, -1, 223},
+		{"nan tolerance", 100, 2 * gomath.Pi, nan, 223},
+		{"tiny tolerance", 100, 2 * gomath.Pi, 1e-9, 223},
+		{"capped", 1e6, 2 * gomath.Pi, 0.01, maxArcSegments},
+		{"infinite sweep", 100, gomath.Inf(1), 0.25, maxArcSegments},
+		{"nan sweep", 100, gomath.NaN(), 0.25, maxArcSegments},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := arcSegments(tt.radius, tt.sweep, tt.tolerance); got != tt.want {
+				t.Errorf("arcSegments(%v, %v, %v) = %d, want %d", tt.radius, tt.sweep, tt.tolerance, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenArc(t *testing.T) {
+	center := math.Vec2f32{X: 10, Y: 20}
+	tests := []struct {
+		name                    string
+		radius, start, end, tol float32
+	}{
+		{"clockwise", 50, 0, gomath.Pi / 2, 0.25},
+		{"counterclockwise", 50, gomath.Pi, 0, 0.25},
+		{"full circle", 200, 0, 2 * gomath.Pi, 0.1},
+		{"zero tolerance", 50, 0, gomath.Pi, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := pointOnCircle(center, tt.radius, float64(tt.start))
+			pts := FlattenArc(nil, center, tt.radius, tt.start, tt.end, tt.tol)
+			sweep := gomath.Abs(float64(tt.end - tt.start))
+			if want := arcSegments(tt.radius, sweep, tt.tol); len(pts) != want {
+				t.Fatalf("%d points, want %d", len(pts), want)
+			}
+			if last, want := pts[len(pts)-1], pointOnCircle(center, tt.radius, float64(tt.end)); last.Distance(want) > 1e-3 {
+				t.Errorf("last point %v, want %v", last, want)
+			}
+			// Every chord stays within the tolerance of the arc.
+			tol := float64(clampTolerance(tt.tol))
+			prev := start
+			for _, p := range pts {
+				if d := float64(p.Distance(center)) - float64(tt.radius); gomath.Abs(d) > 1e-3 {
+					t.Fatalf("%v is %v off the circle", p, d)
+				}
+				mid := prev.Lerp(p, 0.5)
+				if sag := float64(tt.radius) - float64(mid.Distance(center)); sag > tol+1e-3 {
+					t.Fatalf("chord %v-%v strays %v, more than %v", prev, p, sag, tol)
+				}
+				prev = p
+			}
+		})
+	}
+}
+
+func TestFlattenCubic(t *testing.T) {
+	p0, c1, c2, p3 := math.Vec2f32{}, math.Vec2f32{X: 0, Y: 100}, math.Vec2f32{X: 100, Y: 100}, math.Vec2f32{X: 100, Y: 0}
+	coarse := FlattenCubic(nil, p0, c1, c2, p3, 1)
+	fine := FlattenCubic(nil, p0, c1, c2, p3, 0.1)
+	if len(fine) <= len(coarse) {
+		t.Errorf("tolerance 0.1 gives %d points, no more than tolerance 1's %d", len(fine), len(coarse))
+	}
+	for _, pts := range [][]math.Vec2f32{coarse, fine} {
+		if pts[len(pts)-1] != p3 {
+			t.Errorf("last point %v, want %v", pts[len(pts)-1], p3)
+		}
+	}
+	// A straight curve needs a single segment.
+	if got := FlattenCubic(nil, p0, math.Vec2f32{X: 1}, math.Vec2f32{X: 2}, math.Vec2f32{X: 3}, 0.25); len(got) != 1 {
+		t.Errorf("straight cubic flattened to %d points, want 1", len(got))
+	}
+	// Zero and negative tolerances do not subdivide to the limit.
+	for _, tol := range []float32{0, -1, float32(gomath.NaN())} {
+		got := FlattenCubic(nil, p0, c1, c2, p3, tol)
+		if want := FlattenCubic(nil, p0, c1, c2, p3, MinTolerance); len(got) != len(want) {
+			t.Errorf("tolerance %v gives %d points, want %d", tol, len(got), len(want))
+		}
+	}
+}
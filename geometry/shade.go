@@ -0,0 +1,346 @@
+package geometry
+
+import (
+	gomath "math"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Gradients and Shadows
+// ——————————————————————————————————————————————————————————————————————————————
+
+// ColorVertex is a corner of a triangle with a color of its own; colors are
+// blended across the triangle.
+type ColorVertex struct {
+	Pos   math.Vec2f32
+	Color color.RGBA
+}
+
+// ColorMesh is a list of triangles, three vertices each, colored per vertex.
+type ColorMesh struct {
+	Vertices []ColorVertex
+}
+
+// Shadow is the shape of a box shadow, as in CSS box-shadow.
+type Shadow struct {
+	Offset math.Vec2f32
+	// Blur is how far the shadow's edge is spread out, as the CSS blur
+	// radius: twice the standard deviation of the Gaussian blur.
+	Blur float32
+	// Spread grows the shadow before it is blurred, or shrinks it if
+	// negative.
+	Spread float32
+	// Inset casts the shadow inside the box, as if the box were a hole.
+	Inset bool
+}
+
+const (
+	// cornerSegments is how many segments each corner of a rounded
+	// rectangle is drawn with in shadows, whose outlines must all have the
+	// same number of points.
+	cornerSegments = 8
+	// shadowRings is how many rings a blurred shadow edge is drawn with.
+	shadowRings = 8
+)
+
+// RoundedRect returns the outline of the rectangle at pos of the given size
+// with corners of radius, clockwise from the top-left corner, with segments
+// segments per corner. The radius is clamped to half the shorter side. The
+// outline always has 4*(segments+1) points, so outlines of different sizes
+// can be joined point by point.
+func RoundedRect(pos, size math.Vec2f32, radius float32, segments int) []math.Vec2f32 {
+	radius = max(0, min(radius, size.X/2, size.Y/2))
+	segments = max(1, segments)
+	corners := [4]math.Vec2f32{
+		{X: pos.X + radius, Y: pos.Y + radius},
+		{X: pos.X + size.X - radius, Y: pos.Y + radius},
+		{X: pos.X + size.X - radius, Y: pos.Y + size.Y - radius},
+		{X: pos.X + radius, Y: pos.Y + size.Y - radius},
+	}
+	points := make([]math.Vec2f32, 0, 4*(segments+1))
+	for i, c := range corners {
+		start := gomath.Pi + float64(i)*gomath.Pi/2
+		for k := range segments + 1 {
+			points = append(points, pointOnCircle(c, radius, start+float64(k)*gomath.Pi/2/float64(segments)))
+		}
+	}
+	return points
+}
+
+// CornerSegments returns how many segments a rounded corner of radius needs
+// to stay within DefaultTolerance of the arc.
+func CornerSegments(radius float32) int {
+	return max(1, arcSegments(radius, gomath.Pi/2, DefaultTolerance))
+}
+
+// GradientFill fills the convex shape with gradient g, laid out over the box
+// at pos of the given size.
+//
+// The shape is cut into pieces over which the gradient is linear: bands
+// between stops for a linear gradient, rings between stops for a radial
+// one. Each vertex is colored by the gradient at its own position, so the
+// colors blended across a piece's triangles match the gradient.
+func GradientFill(shape []math.Vec2f32, pos, size math.Vec2f32, g *color.Gradient) ColorMesh {
+	var mesh ColorMesh
+	if len(shape) < 3 || len(g.Stops) == 0 {
+		return mesh
+	}
+	center := math.Vec2f32{X: pos.X + size.X/2, Y: pos.Y + size.Y/2}
+	var offset func(p math.Vec2f32) float32
+	var pieces [][]math.Vec2f32
+
+	switch g.Kind {
+	case color.GradientRadial:
+		center = math.Vec2f32{X: pos.X + g.CenterX*size.X, Y: pos.Y + g.CenterY*size.Y}
+		// An ellipse through the farthest corner, with the aspect of the
+		// box.
+		radii := math.Vec2f32{
+			X: max(center.X-pos.X, pos.X+size.X-center.X) * gomath.Sqrt2,
+			Y: max(center.Y-pos.Y, pos.Y+size.Y-center.Y) * gomath.Sqrt2,
+		}
+		if radii.X <= 0 || radii.Y <= 0 {
+			return mesh
+		}
+		offset = func(p math.Vec2f32) float32 {
+			dx, dy := (p.X-center.X)/radii.X, (p.Y-center.Y)/radii.Y
+			return float32(gomath.Sqrt(float64(dx*dx + dy*dy)))
+		}
+		ring := func(t float32) []math.Vec2f32 {
+			points := make([]math.Vec2f32, 64)
+			for i := range points {
+				angle := 2 * gomath.Pi * float64(i) / float64(len(points))
+				points[i] = math.Vec2f32{
+					X: center.X + t*radii.X*float32(gomath.Cos(angle)),
+					Y: center.Y + t*radii.Y*float32(gomath.Sin(angle)),
+				}
+			}
+			return points
+		}
+		levels := gradientLevels(g, 0, 1)
+		for k := 0; k+1 < len(levels); k++ {
+			inner, outer := ring(levels[k]), ring(levels[k+1])
+			for i := range inner {
+				j := (i + 1) % len(inner)
+				pieces = append(pieces, []math.Vec2f32{inner[i], outer[i], outer[j], inner[j]})
+			}
+		}
+
+	default:
+		// The gradient line runs through the center at the CSS angle, long
+		// enough that its ends' perpendiculars touch the box's corners.
+		angle := float64(g.Angle) * gomath.Pi / 180
+		dir := math.Vec2f32{X: float32(gomath.Sin(angle)), Y: float32(-gomath.Cos(angle))}
+		length := float32(gomath.Abs(float64(size.X)*gomath.Sin(angle)) + gomath.Abs(float64(size.Y)*gomath.Cos(angle)))
+		if length <= 0 {
+			return mesh
+		}
+		offset = func(p math.Vec2f32) float32 {
+			return ((p.X-center.X)*dir.X+(p.Y-center.Y)*dir.Y)/length + 0.5
+		}
+		lo, hi := offset(shape[0]), offset(shape[0])
+		for _, p := range shape[1:] {
+			lo, hi = min(lo, offset(p)), max(hi, offset(p))
+		}
+		across := math.Vec2f32{X: -dir.Y * (size.X + size.Y), Y: dir.X * (size.X + size.Y)}
+		at := func(t float32) math.Vec2f32 {
+			return math.Vec2f32{X: center.X + dir.X*(t-0.5)*length, Y: center.Y + dir.Y*(t-0.5)*length}
+		}
+		levels := gradientLevels(g, lo, hi)
+		for k := 0; k+1 < len(levels); k++ {
+			a, b := at(levels[k]), at(levels[k+1])
+			pieces = append(pieces, []math.Vec2f32{
+				{X: a.X - across.X, Y: a.Y - across.Y},
+				{X: b.X - across.X, Y: b.Y - across.Y},
+				{X: b.X + across.X, Y: b.Y + across.Y},
+				{X: a.X + across.X, Y: a.Y + across.Y},
+			})
+		}
+	}
+
+	var triangles []math.Vec2f32
+	for _, piece := range pieces {
+		triangles = clipFan(triangles, piece, shape)
+	}
+	mesh.Vertices = make([]ColorVertex, len(triangles))
+	for i, p := range triangles {
+		mesh.Vertices[i] = ColorVertex{Pos: p, Color: g.At(offset(p))}
+	}
+	return mesh
+}
+
+// gradientLevels returns the offsets from lo to hi at which g's pieces are
+// cut: every stop between them, and more between stops whose alphas differ,
+// since colors are mixed premultiplied and so do not change linearly then.
+func gradientLevels(g *color.Gradient, lo, hi float32) []float32 {
+	levels := []float32{lo}
+	add := func(t float32) {
+		if t > levels[len(levels)-1] && t < hi {
+			levels = append(levels, t)
+		}
+	}
+	for i, s := range g.Stops {
+		if i > 0 && g.Stops[i-1].Color.A != s.Color.A {
+			from := g.Stops[i-1].Offset
+			for k := 1; k < 8; k++ {
+				add(from + (s.Offset-from)*float32(k)/8)
+			}
+		}
+		add(s.Offset)
+	}
+	return append(levels, hi)
+}
+
+// BoxShadow tessellates shadow s of the rounded rectangle at pos of the
+// given size. The mesh is opaque where the shadow is solid and its alpha
+// falls off across the blur, following the Gaussian profile of a straight
+// edge. An inset shadow fills the box around a hole, and is clipped to the
+// box.
+func BoxShadow(pos, size math.Vec2f32, radius float32, s Shadow) Mesh {
+	var mesh Mesh
+	spread := s.Spread
+	if s.Inset {
+		spread = -spread
+	}
+	// The shape that casts the shadow, or for an inset shadow the hole.
+	shapePos := math.Vec2f32{X: pos.X + s.Offset.X - spread, Y: pos.Y + s.Offset.Y - spread}
+	shapeSize := math.Vec2f32{X: size.X + 2*spread, Y: size.Y + 2*spread}
+	shapeRadius := radius
+	if radius > 0 {
+		shapeRadius = max(0, radius+spread)
+	}
+	shapeSize = math.Vec2f32{X: max(0, shapeSize.X), Y: max(0, shapeSize.Y)}
+	blur := max(0, s.Blur)
+
+	// outline returns the shape grown by d (shrunk if negative).
+	outline := func(d float32) []math.Vec2f32 {
+		return RoundedRect(
+			math.Vec2f32{X: shapePos.X - d, Y: shapePos.Y - d},
+			math.Vec2f32{X: max(0, shapeSize.X+2*d), Y: max(0, shapeSize.Y+2*d)},
+			max(0, shapeRadius+d), cornerSegments)
+	}
+	// The blur cannot reach further into the shape than its middle.
+	inner := min(blur, shapeSize.X/2, shapeSize.Y/2)
+	levels := make([]float32, 0, shadowRings+1)
+	for k := range shadowRings + 1 {
+		levels = append(levels, -inner+(blur+inner)*float32(k)/shadowRings)
+	}
+
+	var pieces [][]math.Vec2f32
+	ring := func(a, b []math.Vec2f32) {
+		for i := range a {
+			j := (i + 1) % len(a)
+			pieces = append(pieces, []math.Vec2f32{a[i], b[i], b[j], a[j]})
+		}
+	}
+	for k := 0; k+1 < len(levels); k++ {
+		ring(outline(levels[k]), outline(levels[k+1]))
+	}
+	if s.Inset {
+		// Beyond the blur the shadow covers the rest of the box.
+		far := abs(s.Offset.X) + abs(s.Offset.Y) + abs(spread) + blur + 1
+		ring(outline(blur), RoundedRect(
+			math.Vec2f32{X: pos.X - far, Y: pos.Y - far},
+			math.Vec2f32{X: size.X + 2*far, Y: size.Y + 2*far},
+			radius+far, cornerSegments))
+	} else {
+		pieces = append(pieces, outline(levels[0]))
+	}
+
+	var clip []math.Vec2f32
+	if s.Inset {
+		clip = RoundedRect(pos, size, radius, CornerSegments(radius))
+	}
+	var triangles []math.Vec2f32
+	for _, piece := range pieces {
+		triangles = clipFan(triangles, piece, clip)
+	}
+
+	sigma := float64(blur) / 2
+	mesh.Vertices = make([]Vertex, len(triangles))
+	for i, p := range triangles {
+		d := float64(roundedRectDistance(p, shapePos, shapeSize, shapeRadius))
+		if s.Inset {
+			d = -d
+		}
+		alpha := float32(1)
+		if sigma > 0 {
+			alpha = float32(gomath.Erfc(d/(sigma*gomath.Sqrt2)) / 2)
+		} else if d > 0.5 {
+			// A hard edge; vertices on it are within rounding of zero.
+			alpha = 0
+		}
+		mesh.Vertices[i] = Vertex{Pos: p, Alpha: alpha}
+	}
+	return mesh
+}
+
+// roundedRectDistance returns the signed distance from p to the edge of the
+// rounded rectangle at pos of the given size: negative inside, positive
+// outside.
+func roundedRectDistance(p, pos, size math.Vec2f32, radius float32) float32 {
+	radius = max(0, min(radius, size.X/2, size.Y/2))
+	half := math.Vec2f32{X: size.X / 2, Y: size.Y / 2}
+	qx := abs(p.X-pos.X-half.X) - (half.X - radius)
+	qy := abs(p.Y-pos.Y-half.Y) - (half.Y - radius)
+	outside := float32(gomath.Hypot(float64(max(qx, 0)), float64(max(qy, 0))))
+	return outside + min(max(qx, qy), 0) - radius
+}
+
+func abs(x float32) float32 { return max(x, -x) }
+
+// clipFan clips the convex polygon piece to the convex polygon clip (if
+// any) and appends what is left to dst as a fan of triangles.
+func clipFan(dst, piece, clip []math.Vec2f32) []math.Vec2f32 {
+	if len(clip) >= 3 {
+		piece = clipConvex(piece, clip)
+	}
+	for i := 1; i+1 < len(piece); i++ {
+		dst = append(dst, piece[0], piece[i], piece[i+1])
+	}
+	return dst
+}
+
+// clipConvex returns the part of polygon inside the convex polygon clip, by
+// clipping it against each of clip's edges in turn (Sutherland–Hodgman).
+func clipConvex(polygon, clip []math.Vec2f32) []math.Vec2f32 {
+	// Which side of an edge is inside depends on which way clip runs.
+	var area float32
+	for i, a := range clip {
+		b := clip[(i+1)%len(clip)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	sign := float32(1)
+	if area < 0 {
+		sign = -1
+	}
+
+	out := polygon
+	for i, a := range clip {
+		b := clip[(i+1)%len(clip)]
+		if a == b {
+			continue
+		}
+		side := func(p math.Vec2f32) float32 {
+			return sign * ((b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X))
+		}
+		in := out
+		out = make([]math.Vec2f32, 0, len(in)+2)
+		for j, p := range in {
+			q := in[(j+1)%len(in)]
+			sp, sq := side(p), side(q)
+			if sp >= 0 {
+				out = append(out, p)
+			}
+			if (sp >= 0) != (sq >= 0) {
+				t := sp / (sp - sq)
+				out = append(out, math.Vec2f32{X: p.X + (q.X-p.X)*t, Y: p.Y + (q.Y-p.Y)*t})
+			}
+		}
+		if len(out) < 3 {
+			return nil
+		}
+	}
+	return out
+}
@@ -0,0 +1,408 @@
+package geometry
+
+import (
+	gomath "math"
+
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Strokes
+// ——————————————————————————————————————————————————————————————————————————————
+
+// LineJoin is how a stroke turns a corner.
+type LineJoin int
+
+const (
+	JoinMiter LineJoin = iota
+	JoinRound
+	JoinBevel
+)
+
+// LineCap is how an open stroke ends.
+type LineCap int
+
+const (
+	CapButt LineCap = iota
+	CapRound
+	// CapSquare extends the ends by half the line width.
+	CapSquare
+)
+
+// DefaultMiterLimit is the miter limit of a StrokeStyle that sets none.
+const DefaultMiterLimit float32 = 10
+
+// antiAliasFringe is how far, in pixels, a smoothed edge fades out across.
+const antiAliasFringe float32 = 1
+
+// StrokeStyle is how contours are outlined.
+type StrokeStyle struct {
+	Width float32
+	Join  LineJoin
+	Cap   LineCap
+	// MiterLimit is how long a miter join may be, in line widths, before it
+	// is beveled instead.
+	MiterLimit float32
+	// AntiAlias fades the edges out across a pixel instead of cutting them
+	// off. Lines thinner than a pixel are drawn a pixel wide and fainter.
+	AntiAlias bool
+	// Tolerance is how closely round joins and caps follow their arcs.
+	Tolerance float32
+}
+
+// section is a cut across a stroke at center: its edges are at center plus
+// left and at center plus right. A stroke is a strip between consecutive
+// sections; a join or cap is a run of sections at about the same center.
+type section struct {
+	center, left, right math.Vec2f32
+}
+
+// Stroke outlines contours in style, as one triangle strip per contour
+// (turned into a list) with its joins and caps built in.
+func Stroke(contours []Contour, style StrokeStyle) Mesh {
+	var mesh Mesh
+	if style.Width <= 0 {
+		return mesh
+	}
+	if style.MiterLimit <= 0 {
+		style.MiterLimit = DefaultMiterLimit
+	}
+	if style.Tolerance <= 0 {
+		style.Tolerance = DefaultTolerance
+	}
+	s := stroker{style: style, half: style.Width / 2}
+	for _, c := range contours {
+		s.contour(&mesh, c)
+	}
+	return mesh
+}
+
+type stroker struct {
+	style    StrokeStyle
+	half     float32
+	sections []section
+}
+
+func (s *stroker) contour(mesh *Mesh, c Contour) {
+	points := dedupe(c.Points, c.Closed)
+	s.sections = s.sections[:0]
+	n := len(points)
+	switch {
+	case n == 0:
+		return
+	case n == 1:
+		// A lone point shows only as its caps, facing along x.
+		right := math.Vec2f32{X: 1}
+		s.startCap(points[0], right)
+		s.endCap(points[0], right)
+	case c.Closed && n > 2:
+		for i := range n {
+			prev, next := points[(i+n-1)%n], points[(i+1)%n]
+			s.join(prev, points[i], next)
+		}
+		s.sections = append(s.sections, s.sections[0])
+	default:
+		s.startCap(points[0], unit(points[0], points[1]))
+		for i := 1; i < n-1; i++ {
+			s.join(points[i-1], points[i], points[i+1])
+		}
+		s.endCap(points[n-1], unit(points[n-2], points[n-1]))
+	}
+	s.strip(mesh)
+}
+
+// dedupe drops points equal to the one before them (and, for closed
+// contours, a last point equal to the first).
+func dedupe(points []math.Vec2f32, closed bool) []math.Vec2f32 {
+	out := make([]math.Vec2f32, 0, len(points))
+	for _, p := range points {
+		if len(out) == 0 || out[len(out)-1] != p {
+			out = append(out, p)
+		}
+	}
+	if closed && len(out) > 1 && out[0] == out[len(out)-1] {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+func (s *stroker) add(center, left, right math.Vec2f32) {
+	s.sections = append(s.sections, section{center: center, left: left, right: right})
+}
+
+// startCap adds the sections that begin a stroke at p heading in dir.
+func (s *stroker) startCap(p, dir math.Vec2f32) {
+	n := normal(dir).Times(s.half)
+	switch s.style.Cap {
+	case CapSquare:
+		s.add(p.Minus(dir.Times(s.half)), n, n.Times(-1))
+	case CapRound:
+		// From the tip of a half circle back to its full width at p.
+		steps := arcSegments(s.half, gomath.Pi/2, s.style.Tolerance)
+		for i := 0; i <= steps; i++ {
+			angle := gomath.Pi / 2 * float64(i) / float64(steps)
+			across := n.Times(float32(gomath.Sin(angle)))
+			s.add(p.Minus(dir.Times(s.half*float32(gomath.Cos(angle)))), across, across.Times(-1))
+		}
+	default:
+		s.add(p, n, n.Times(-1))
+	}
+}
+
+// endCap adds the sections that end a stroke at p heading in dir.
+func (s *stroker) endCap(p, dir math.Vec2f32) {
+	n := normal(dir).Times(s.half)
+	switch s.style.Cap {
+	case CapSquare:
+		s.add(p.Plus(dir.Times(s.half)), n, n.Times(-1))
+	case CapRound:
+		steps := arcSegments(s.half, gomath.Pi/2, s.style.Tolerance)
+		for i := steps; i >= 0; i-- {
+			angle := gomath.Pi / 2 * float64(i) / float64(steps)
+			across := n.Times(float32(gomath.Sin(angle)))
+			s.add(p.Plus(dir.Times(s.half*float32(gomath.Cos(angle)))), across, across.Times(-1))
+		}
+	default:
+		s.add(p, n, n.Times(-1))
+	}
+}
+
+// join adds the sections that turn the stroke at p from the line coming
+// from prev to the line going to next.
+func (s *stroker) join(prev, p, next math.Vec2f32) {
+	in, out := unit(prev, p), unit(p, next)
+	n0, n1 := normal(in), normal(out)
+	cross := in.X*out.Y - in.Y*out.X
+	dot := in.Dot(out)
+	if gomath.Abs(float64(cross)) < 1e-6 && dot > 0 {
+		s.add(p, n0.Times(s.half), n0.Times(-s.half))
+		return
+	}
+
+	// The stroke turns towards the side of n0 if cross > 0; that side is
+	// the inside of the corner and the other the outside.
+	inner := float32(1)
+	if cross < 0 {
+		inner = -1
+	}
+	// section orders a pair of inner and outer offsets as left and right.
+	section := func(innerOffset, outerOffset math.Vec2f32) {
+		if inner > 0 {
+			s.add(p, innerOffset, outerOffset)
+		} else {
+			s.add(p, outerOffset, innerOffset)
+		}
+	}
+
+	// Where the edges on the side of n0 meet, if they do.
+	m := n0.Plus(n1)
+	lengthSq := m.Dot(m)
+	var miter math.Vec2f32
+	if lengthSq > 1e-6 {
+		miter = m.Times(2 * s.half / lengthSq)
+	}
+	// Inside, the edges meet at the miter point unless it lies beyond
+	// either line; then the lines simply overlap.
+	shortest := min(prev.Distance(p), p.Distance(next))
+	innerMiter := lengthSq > 1e-6 && miter.Norm() <= shortest
+	innerAt := func(n math.Vec2f32) math.Vec2f32 {
+		if innerMiter {
+			return miter.Times(inner)
+		}
+		return n.Times(inner * s.half)
+	}
+	outer0, outer1 := n0.Times(-inner*s.half), n1.Times(-inner*s.half)
+
+	switch {
+	case s.style.Join == JoinMiter && lengthSq > 1e-6 && 4/lengthSq <= s.style.MiterLimit*s.style.MiterLimit:
+		section(innerAt(n0), miter.Times(-inner))
+		if !innerMiter {
+			section(innerAt(n1), miter.Times(-inner))
+		}
+	case s.style.Join == JoinRound:
+		// Sweep the outer edge around p, the short way, or forwards when
+		// the line doubles back.
+		from := gomath.Atan2(float64(outer0.Y), float64(outer0.X))
+		to := gomath.Atan2(float64(outer1.Y), float64(outer1.X))
+		sweep := gomath.Remainder(to-from, 2*gomath.Pi)
+		if gomath.Abs(float64(cross)) < 1e-6 {
+			midway := from + sweep/2
+			if gomath.Cos(midway)*float64(in.X)+gomath.Sin(midway)*float64(in.Y) < 0 {
+				sweep = -sweep
+			}
+		}
+		steps := arcSegments(s.half, gomath.Abs(sweep), s.style.Tolerance)
+		for i := 0; i <= steps; i++ {
+			angle := from + sweep*float64(i)/float64(steps)
+			outer := math.Vec2f32{X: s.half * float32(gomath.Cos(angle)), Y: s.half * float32(gomath.Sin(angle))}
+			n := n0
+			if i == steps {
+				n = n1
+			}
+			section(innerAt(n), outer)
+		}
+	default:
+		section(innerAt(n0), outer0)
+		section(innerAt(n1), outer1)
+	}
+}
+
+// strip fills between consecutive sections, with fringes that fade out at
+// both edges when anti-aliasing.
+func (s *stroker) strip(mesh *Mesh) {
+	type rail struct {
+		scale float32
+		left  bool
+		alpha float32
+	}
+	rails := []rail{{1, false, 1}, {1, true, 1}}
+	if s.style.AntiAlias {
+		// Thin lines get fainter rather than thinner.
+		alpha := min(1, s.style.Width)
+		core := max(0, s.half-antiAliasFringe/2) / s.half
+		outside := (max(s.half, antiAliasFringe/2) + antiAliasFringe/2) / s.half
+		rails = []rail{{outside, false, 0}, {core, false, alpha}, {core, true, alpha}, {outside, true, 0}}
+	}
+	vertex := func(sec section, r rail) Vertex {
+		offset := sec.right
+		if r.left {
+			offset = sec.left
+		}
+		return Vertex{Pos: sec.center.Plus(offset.Times(r.scale)), Alpha: r.alpha}
+	}
+	for k := 1; k < len(s.sections); k++ {
+		a, b := s.sections[k-1], s.sections[k]
+		for r := 1; r < len(rails); r++ {
+			mesh.quad(vertex(a, rails[r-1]), vertex(a, rails[r]), vertex(b, rails[r]), vertex(b, rails[r-1]))
+		}
+	}
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Dashes
+// ——————————————————————————————————————————————————————————————————————————————
+
+// MaxDashes is the most dashes Dash cuts a path into. A pattern that fine
+// for the length of the path cannot be seen anyway, and the path is left
+// solid instead of filling memory with dashes.
+const MaxDashes = 1 << 16
+
+// Dash cuts contours into the dashes of pattern, alternating lengths of dash
+// and gap, starting offset into the pattern. A pattern of odd length is
+// repeated to make it even. Without a pattern, with one that has no
+// length, or with one that would make more than MaxDashes dashes, the
+// contours are returned as they are.
+func Dash(contours []Contour, pattern []float32, offset float32) []Contour {
+	var total float64
+	for _, length := range pattern {
+		if length < 0 {
+			return contours
+		}
+		total += float64(length)
+	}
+	if total == 0 {
+		return contours
+	}
+	if len(pattern)%2 == 1 {
+		pattern = append(pattern[:len(pattern):len(pattern)], pattern...)
+		total *= 2
+	}
+	if dashCount(contours, total, len(pattern)/2) > MaxDashes {
+		return contours
+	}
+
+	var dashes []Contour
+	for _, c := range contours {
+		points := c.Points
+		if c.Closed && len(points) > 1 {
+			points = append(points[:len(points):len(points)], points[0])
+		}
+		if len(points) < 2 {
+			continue
+		}
+
+		// Find where in the pattern the offset starts.
+		index, into := 0, gomath.Mod(float64(offset), total)
+		if into < 0 {
+			into += total
+		}
+		for into >= float64(pattern[index]) {
+			into -= float64(pattern[index])
+			index = (index + 1) % len(pattern)
+		}
+		left := float64(pattern[index]) - into
+
+		var dash []math.Vec2f32
+		if index%2 == 0 {
+			dash = []math.Vec2f32{points[0]}
+		}
+		for i := 1; i < len(points); i++ {
+			// The position along the segment is tracked in float64: in
+			// float32, adding a short dash to a long way done stops
+			// moving it.
+			from, to := points[i-1], points[i]
+			dx, dy := float64(to.X-from.X), float64(to.Y-from.Y)
+			length := gomath.Hypot(dx, dy)
+			if length == 0 {
+				continue
+			}
+			var done float64
+			for length-done >= left {
+				done += left
+				t := done / length
+				at := math.Vec2f32{X: from.X + float32(dx*t), Y: from.Y + float32(dy*t)}
+				if index%2 == 0 {
+					dashes = append(dashes, Contour{Points: append(dash, at)})
+					dash = nil
+				} else {
+					dash = []math.Vec2f32{at}
+				}
+				index = (index + 1) % len(pattern)
+				left = float64(pattern[index])
+			}
+			left -= length - done
+			if index%2 == 0 {
+				dash = append(dash, to)
+			}
+		}
+		if len(dash) > 1 {
+			dashes = append(dashes, Contour{Points: dash})
+		}
+	}
+	return dashes
+}
+
+// dashCount returns about how many dashes a pattern total long with dashes
+// dashes per repeat cuts contours into, at most one more per contour.
+func dashCount(contours []Contour, total float64, dashes int) float64 {
+	var count float64
+	for _, c := range contours {
+		var length float64
+		points := c.Points
+		for i := 1; i < len(points); i++ {
+			length += gomath.Hypot(float64(points[i].X-points[i-1].X), float64(points[i].Y-points[i-1].Y))
+		}
+		if c.Closed && len(points) > 1 {
+			last := points[len(points)-1]
+			length += gomath.Hypot(float64(points[0].X-last.X), float64(points[0].Y-last.Y))
+		}
+		count += gomath.Ceil(length/total)*float64(dashes) + 1
+	}
+	return count
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Vector helpers
+// ——————————————————————————————————————————————————————————————————————————————
+
+// normal returns v turned a quarter turn.
+func normal(v math.Vec2f32) math.Vec2f32 { return math.Vec2f32{X: -v.Y, Y: v.X} }
+
+// unit returns the unit vector from a to b.
+func unit(a, b math.Vec2f32) math.Vec2f32 {
+	d := a.Distance(b)
+	if d == 0 {
+		return math.Vec2f32{}
+	}
+	return b.Minus(a).Times(1 / d)
+}
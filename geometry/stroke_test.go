@@ -0,0 +1,92 @@
+package geometry
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aj-2000/mogi/math"
+)
+
+func TestDash(t *testing.T) {
+	line := []Contour{{Points: points(0, 0, 11, 0)}}
+	tests := []struct {
+		name     string
+		contours []Contour
+		pattern  []float32
+		offset   float32
+		want     []Contour
+	}{
+		{
+			name:     "dash and gap",
+			contours: line,
+			pattern:  []float32{3, 2},
+			want: []Contour{
+				{Points: points(0, 0, 3, 0)},
+				{Points: points(5, 0, 8, 0)},
+				{Points: points(10, 0, 11, 0)},
+			},
+		},
+		{
+			name:     "offset into a gap",
+			contours: line,
+			pattern:  []float32{3, 2},
+			offset:   3,
+			want: []Contour{
+				{Points: points(2, 0, 5, 0)},
+				{Points: points(7, 0, 10, 0)},
+			},
+		},
+		{
+			name:     "odd pattern repeats",
+			contours: line,
+			pattern:  []float32{4},
+			want: []Contour{
+				{Points: points(0, 0, 4, 0)},
+				{Points: points(8, 0, 11, 0)},
+			},
+		},
+		{
+			name:     "around a corner",
+			contours: []Contour{{Points: points(0, 0, 4, 0, 4, 4)}},
+			pattern:  []float32{6, 1},
+			want: []Contour{
+				{Points: points(0, 0, 4, 0, 4, 2)},
+				{Points: points(4, 3, 4, 4)},
+			},
+		},
+		{name: "no pattern", contours: line, want: line},
+		{name: "no length", contours: line, pattern: []float32{0, 0}, want: line},
+		{name: "negative length", contours: line, pattern: []float32{3, -1}, want: line},
+		{
+			// Twenty million dashes would not fit in memory.
+			name:     "too fine for the length",
+			contours: []Contour{{Points: points(0, 0, 4e7, 0)}},
+			pattern:  []float32{1, 1},
+			want:     []Contour{{Points: points(0, 0, 4e7, 0)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Dash(tt.contours, tt.pattern, tt.offset)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dash = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDashLongSegment dashes a segment long enough that float32 steps along
+// it would lose whole units, and checks every dash starts where it should.
+func TestDashLongSegment(t *testing.T) {
+	const length, step = 20_000_500, 1000
+	dashes := Dash([]Contour{{Points: points(0, 0, length, 0)}}, []float32{step, step}, 0)
+	if want := length/(2*step) + 1; len(dashes) != want {
+		t.Fatalf("%d dashes, want %d", len(dashes), want)
+	}
+	for i, d := range dashes {
+		want := math.Vec2f32{X: float32(2 * step * i)}
+		if len(d.Points) != 2 || d.Points[0] != want {
+			t.Fatalf("dash %d = %v, want it to start at %v", i, d.Points, want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package bidi
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Arabic shaping
+// ——————————————————————————————————————————————————————————————————————————————
+//
+// Fonts loaded by the renderer have no shaping engine, so Arabic letters are
+// replaced by their contextual forms from the Arabic Presentation Forms
+// blocks before measuring and drawing.
+
+type joining int
+
+const (
+	joinNone        joining = iota // does not join (hamza, non-Arabic)
+	joinRight                      // joins only to the preceding letter (alef, dal, reh, waw)
+	joinDual                       // joins on both sides
+	joinCausing                    // tatweel: joins on both sides, has a single form
+	joinTransparent                // marks: skipped when determining joins
+)
+
+// arabicForm lists the isolated, final, initial and medial forms of a letter.
+// Right-joining letters have no initial or medial forms.
+type arabicForm [4]rune
+
+const (
+	formIsolated = iota
+	formFinal
+	formInitial
+	formMedial
+)
+
+var arabicForms = map[rune]arabicForm{
+	0x0621: {0xFE80},
+	0x0622: {0xFE81, 0xFE82},
+	0x0623: {0xFE83, 0xFE84},
+	0x0624: {0xFE85, 0xFE86},
+	0x0625: {0xFE87, 0xFE88},
+	0x0626: {0xFE89, 0xFE8A, 0xFE8B, 0xFE8C},
+	0x0627: {0xFE8D, 0xFE8E},
+	0x0628: {0xFE8F, 0xFE90, 0xFE91, 0xFE92},
+	0x0629: {0xFE93, 0xFE94},
+	0x062A: {0xFE95, 0xFE96, 0xFE97, 0xFE98},
+	0x062B: {0xFE99, 0xFE9A, 0xFE9B, 0xFE9C},
+	0x062C: {0xFE9D, 0xFE9E, 0xFE9F, 0xFEA0},
+	0x062D: {0xFEA1, 0xFEA2, 0xFEA3, 0xFEA4},
+	0x062E: {0xFEA5, 0xFEA6, 0xFEA7, 0xFEA8},
+	0x062F: {0xFEA9, 0xFEAA},
+	0x0630: {0xFEAB, 0xFEAC},
+	0x0631: {0xFEAD, 0xFEAE},
+	0x0632: {0xFEAF, 0xFEB0},
+	0x0633: {0xFEB1, 0xFEB2, 0xFEB3, 0xFEB4},
+	0x0634: {0xFEB5, 0xFEB6, 0xFEB7, 0xFEB8},
+	0x0635: {0xFEB9, 0xFEBA, 0xFEBB, 0xFEBC},
+	0x0636: {0xFEBD, 0xFEBE, 0xFEBF, 0xFEC0},
+	0x0637: {0xFEC1, 0xFEC2, 0xFEC3, 0xFEC4},
+	0x0638: {0xFEC5, 0xFEC6, 0xFEC7, 0xFEC8},
+	0x0639: {0xFEC9, 0xFECA, 0xFECB, 0xFECC},
+	0x063A: {0xFECD, 0xFECE, 0xFECF, 0xFED0},
+	0x0641: {0xFED1, 0xFED2, 0xFED3, 0xFED4},
+	0x0642: {0xFED5, 0xFED6, 0xFED7, 0xFED8},
+	0x0643: {0xFED9, 0xFEDA, 0xFEDB, 0xFEDC},
+	0x0644: {0xFEDD, 0xFEDE, 0xFEDF, 0xFEE0},
+	0x0645: {0xFEE1, 0xFEE2, 0xFEE3, 0xFEE4},
+	0x0646: {0xFEE5, 0xFEE6, 0xFEE7, 0xFEE8},
+	0x0647: {0xFEE9, 0xFEEA, 0xFEEB, 0xFEEC},
+	0x0648: {0xFEED, 0xFEEE},
+	0x0649: {0xFEEF, 0xFEF0},
+	0x064A: {0xFEF1, 0xFEF2, 0xFEF3, 0xFEF4},
+	// Persian and Urdu letters (Presentation Forms-A)
+	0x067E: {0xFB56, 0xFB57, 0xFB58, 0xFB59},
+	0x0686: {0xFB7A, 0xFB7B, 0xFB7C, 0xFB7D},
+	0x0698: {0xFB8A, 0xFB8B},
+	0x06A9: {0xFB8E, 0xFB8F, 0xFB90, 0xFB91},
+	0x06AF: {0xFB92, 0xFB93, 0xFB94, 0xFB95},
+	0x06CC: {0xFBFC, 0xFBFD, 0xFBFE, 0xFBFF},
+}
+
+// lamAlef maps an alef variant to the isolated and final forms of its
+// ligature with a preceding lam.
+var lamAlef = map[rune][2]rune{
+	0x0622: {0xFEF5, 0xFEF6},
+	0x0623: {0xFEF7, 0xFEF8},
+	0x0625: {0xFEF9, 0xFEFA},
+	0x0627: {0xFEFB, 0xFEFC},
+}
+
+const (
+	lam     = 0x0644
+	tatweel = 0x0640
+)
+
+func joiningOf(r rune) joining {
+	if r == tatweel {
+		return joinCausing
+	}
+	if form, ok := arabicForms[r]; ok {
+		switch {
+		case form[formInitial] != 0:
+			return joinDual
+		case form[formFinal] != 0:
+			return joinRight
+		}
+		return joinNone
+	}
+	if r >= 0x0600 && r <= 0x06FF && ClassOf(r) == NSM {
+		return joinTransparent
+	}
+	return joinNone
+}
+
+func joinsNext(j joining) bool { return j == joinDual || j == joinCausing }
+func joinsPrev(j joining) bool { return j == joinDual || j == joinRight || j == joinCausing }
+
+// Shape replaces Arabic letters in logical-order text with the forms they
+// take next to their neighbours, and lam followed by alef with a ligature.
+func Shape(text string) string {
+	runes := []rune(text)
+	hasArabic := false
+	for _, r := range runes {
+		if r >= 0x0600 && r <= 0x06FF {
+			hasArabic = true
+			break
+		}
+	}
+	if !hasArabic {
+		return text
+	}
+
+	joins := make([]joining, len(runes))
+	for i, r := range runes {
+		joins[i] = joiningOf(r)
+	}
+	// neighbour returns the index of the nearest non-transparent rune.
+	neighbour := func(i, step int) int {
+		for i += step; i >= 0 && i < len(runes); i += step {
+			if joins[i] != joinTransparent {
+				return i
+			}
+		}
+		return -1
+	}
+
+	out := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		join := joins[i]
+		if join == joinNone || join == joinTransparent || join == joinCausing {
+			// Letters that never join, like hamza, still take their
+			// isolated form.
+			if form, ok := arabicForms[r]; ok {
+				r = form[formIsolated]
+			}
+			out = append(out, r)
+			continue
+		}
+
+		prev := neighbour(i, -1)
+		connectPrev := prev >= 0 && joinsNext(joins[prev]) && joinsPrev(join)
+
+		if r == lam {
+			if next := neighbour(i, 1); next >= 0 {
+				if lig, ok := lamAlef[runes[next]]; ok {
+					if connectPrev {
+						out = append(out, lig[1])
+					} else {
+						out = append(out, lig[0])
+					}
+					// Keep marks between lam and alef, drop the alef itself.
+					out = append(out, runes[i+1:next]...)
+					i = next
+					continue
+				}
+			}
+		}
+
+		next := neighbour(i, 1)
+		connectNext := next >= 0 && joinsNext(join) && joinsPrev(joins[next])
+
+		form := arabicForms[r]
+		switch {
+		case connectPrev && connectNext:
+			out = append(out, form[formMedial])
+		case connectPrev:
+			out = append(out, form[formFinal])
+		case connectNext:
+			out = append(out, form[formInitial])
+		default:
+			out = append(out, form[formIsolated])
+		}
+	}
+	return string(out)
+}
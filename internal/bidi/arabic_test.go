@@ -0,0 +1,44 @@
+package bidi
+
+import "testing"
+
+func TestShape(t *testing.T) {
+	const (
+		beh     = "ب"
+		alefA   = "ا"
+		lam     = "ل"
+		hamza   = "ء"
+		fatha   = "َ"
+		tatweel = "ـ"
+	)
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"latin unchanged", "abc", "abc"},
+		{"isolated", beh, "ﺏ"},
+		{"initial and final", beh + beh, "ﺑﺐ"},
+		{"medial", beh + beh + beh, "ﺑﺒﺐ"},
+		{"right-joining letter ends the join", beh + alefA + beh, "ﺑﺎﺏ"},
+		{"right-joining letter first", alefA + beh, "ﺍﺏ"},
+		{"non-joining letter", beh + hamza + beh, "ﺏﺀﺏ"},
+		{"space breaks the join", beh + " " + beh, "ﺏ ﺏ"},
+		{"latin breaks the join", beh + "a" + beh, "ﺏaﺏ"},
+		{"marks are transparent", beh + fatha + beh, "ﺑ" + fatha + "ﺐ"},
+		{"tatweel joins", beh + tatweel + beh, "ﺑ" + tatweel + "ﺐ"},
+		{"lam alef ligature", lam + alefA, "ﻻ"},
+		{"lam alef after a letter", beh + lam + alefA, "ﺑﻼ"},
+		{"lam alef keeps marks", lam + fatha + alefA, "ﻻ" + fatha},
+		{"lam alone", lam, "ﻝ"},
+		{"persian peh", "پپ", "ﭘﭗ"},
+		{"digits unchanged", beh + "١", "ﺏ١"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Shape(tt.text); got != tt.want {
+				t.Errorf("Shape(%q) = %+q, want %+q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
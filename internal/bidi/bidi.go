@@ -0,0 +1,297 @@
+package bidi
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Direction
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Direction is the base (paragraph) direction of a piece of text.
+type Direction int
+
+const (
+	// Auto takes the direction from the first strong character (rule P2).
+	Auto Direction = iota
+	LeftToRight
+	RightToLeft
+)
+
+// ParagraphDirection returns the direction of the first strong character
+// in text, or LeftToRight if there is none.
+func ParagraphDirection(text string) Direction {
+	for _, r := range text {
+		switch ClassOf(r) {
+		case L:
+			return LeftToRight
+		case R, AL:
+			return RightToLeft
+		}
+	}
+	return LeftToRight
+}
+
+// NeedsBidi reports whether text contains right-to-left characters.
+// Callers use it to skip the algorithm for plain left-to-right text.
+func NeedsBidi(text string) bool {
+	for _, r := range text {
+		if r < 0x0590 {
+			continue
+		}
+		switch ClassOf(r) {
+		case R, AL, AN:
+			return true
+		}
+	}
+	return false
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Resolving levels
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Levels resolves the embedding level of every rune of a single line.
+func Levels(runes []rune, dir Direction) []uint8 {
+	if dir == Auto {
+		dir = ParagraphDirection(string(runes))
+	}
+	paraLevel := uint8(0)
+	if dir == RightToLeft {
+		paraLevel = 1
+	}
+	sos := L
+	if paraLevel == 1 {
+		sos = R
+	}
+
+	classes := make([]Class, len(runes))
+	original := make([]Class, len(runes))
+	for i, r := range runes {
+		classes[i] = ClassOf(r)
+		original[i] = classes[i]
+	}
+
+	resolveWeak(classes, sos)
+	resolveNeutral(classes, sos, sos)
+
+	levels := make([]uint8, len(runes))
+	for i, c := range classes {
+		level := paraLevel
+		switch {
+		case paraLevel%2 == 0 && c == R:
+			level++
+		case paraLevel%2 == 0 && (c == AN || c == EN):
+			level += 2
+		case paraLevel%2 == 1 && (c == L || c == EN || c == AN):
+			level++
+		}
+		levels[i] = level
+	}
+
+	// L1: separators and trailing whitespace return to the paragraph level.
+	trailing := true
+	for i := len(runes) - 1; i >= 0; i-- {
+		switch original[i] {
+		case S, B:
+			levels[i] = paraLevel
+			trailing = true
+		case WS, BN:
+			if trailing {
+				levels[i] = paraLevel
+			}
+		default:
+			trailing = false
+		}
+	}
+	return levels
+}
+
+// resolveWeak applies rules W1-W7 to a single level run.
+func resolveWeak(classes []Class, sos Class) {
+	// W1: marks take the type of the preceding character. Boundary
+	// neutrals are treated the same way since they are not removed (X9).
+	prev := sos
+	for i, c := range classes {
+		if c == NSM || c == BN {
+			classes[i] = prev
+		} else {
+			prev = c
+		}
+	}
+
+	// W2 and W3: European numbers after Arabic letters are Arabic numbers.
+	lastStrong := sos
+	for i, c := range classes {
+		switch c {
+		case L, R, AL:
+			lastStrong = c
+		case EN:
+			if lastStrong == AL {
+				classes[i] = AN
+			}
+		}
+	}
+	for i, c := range classes {
+		if c == AL {
+			classes[i] = R
+		}
+	}
+
+	// W4: a single separator between two numbers of the same type.
+	for i := 1; i+1 < len(classes); i++ {
+		before, after := classes[i-1], classes[i+1]
+		switch {
+		case classes[i] == ES && before == EN && after == EN:
+			classes[i] = EN
+		case classes[i] == CS && before == after && (before == EN || before == AN):
+			classes[i] = before
+		}
+	}
+
+	// W5: terminators adjacent to European numbers.
+	for i := 0; i < len(classes); {
+		if classes[i] != ET {
+			i++
+			continue
+		}
+		end := i
+		for end < len(classes) && classes[end] == ET {
+			end++
+		}
+		if (i > 0 && classes[i-1] == EN) || (end < len(classes) && classes[end] == EN) {
+			for j := i; j < end; j++ {
+				classes[j] = EN
+			}
+		}
+		i = end
+	}
+
+	// W6: remaining separators and terminators become neutral.
+	for i, c := range classes {
+		if c == ES || c == ET || c == CS {
+			classes[i] = ON
+		}
+	}
+
+	// W7: European numbers in a left-to-right context are L.
+	lastStrong = sos
+	for i, c := range classes {
+		switch c {
+		case L, R:
+			lastStrong = c
+		case EN:
+			if lastStrong == L {
+				classes[i] = L
+			}
+		}
+	}
+}
+
+// resolveNeutral applies rules N1 and N2.
+func resolveNeutral(classes []Class, sos, eos Class) {
+	embedding := sos
+	strongOf := func(c Class) Class {
+		if c == EN || c == AN {
+			return R
+		}
+		return c
+	}
+	for i := 0; i < len(classes); {
+		if !isNeutral(classes[i]) {
+			i++
+			continue
+		}
+		end := i
+		for end < len(classes) && isNeutral(classes[end]) {
+			end++
+		}
+		before := sos
+		if i > 0 {
+			before = strongOf(classes[i-1])
+		}
+		after := eos
+		if end < len(classes) {
+			after = strongOf(classes[end])
+		}
+		resolved := embedding
+		if before == after {
+			resolved = before
+		}
+		for j := i; j < end; j++ {
+			classes[j] = resolved
+		}
+		i = end
+	}
+}
+
+func isNeutral(c Class) bool {
+	return c == B || c == S || c == WS || c == ON
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Reordering
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Reorder returns the runes of a line in visual (left-to-right drawing)
+// order, mirroring brackets at right-to-left levels (rules L2 and L4).
+func Reorder(runes []rune, levels []uint8) []rune {
+	out := make([]rune, len(runes))
+	for i, j := range VisualOrder(levels) {
+		out[i] = runes[j]
+		if levels[j]%2 == 1 {
+			if m, ok := mirrors[out[i]]; ok {
+				out[i] = m
+			}
+		}
+	}
+	return out
+}
+
+// VisualOrder returns the indices of the items of a line at the given
+// levels in visual order (rule L2). The items may be runes or whole runs.
+func VisualOrder(levels []uint8) []int {
+	out := make([]int, len(levels))
+	for i := range out {
+		out[i] = i
+	}
+	order := make([]uint8, len(levels))
+	copy(order, levels)
+	highest, lowestOdd := uint8(0), uint8(255)
+	for _, level := range order {
+		highest = max(highest, level)
+		if level%2 == 1 {
+			lowestOdd = min(lowestOdd, level)
+		}
+	}
+	// From the highest level down, reverse every run at that level or above.
+	for level := highest; level >= lowestOdd && level > 0; level-- {
+		for i := 0; i < len(order); {
+			if order[i] < level {
+				i++
+				continue
+			}
+			end := i
+			for end < len(order) && order[end] >= level {
+				end++
+			}
+			reverse(out[i:end])
+			reverse(order[i:end])
+			i = end
+		}
+	}
+	return out
+}
+
+func reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Visual shapes a single line of logical text and returns it in display
+// order. Text without right-to-left characters in a left-to-right
+// paragraph is returned unchanged.
+func Visual(line string, dir Direction) string {
+	if dir != RightToLeft && !NeedsBidi(line) {
+		return line
+	}
+	runes := []rune(Shape(line))
+	return string(Reorder(runes, Levels(runes, dir)))
+}
@@ -0,0 +1,118 @@
+package bidi
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Hebrew letters, so that expectations read in logical order.
+const (
+	alef  = "א"
+	bet   = "ב"
+	gimel = "ג"
+)
+
+func TestParagraphDirection(t *testing.T) {
+	tests := []struct {
+		text string
+		want Direction
+	}{
+		{"", LeftToRight},
+		{"abc", LeftToRight},
+		{"123 !?", LeftToRight},
+		{alef + bet, RightToLeft},
+		{"123 " + alef + " abc", RightToLeft},
+		{"abc " + alef, LeftToRight},
+		{"ب", RightToLeft},
+	}
+	for _, tt := range tests {
+		if got := ParagraphDirection(tt.text); got != tt.want {
+			t.Errorf("ParagraphDirection(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestVisual(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		dir  Direction
+		want string
+	}{
+		{"latin unchanged", "abc def", Auto, "abc def"},
+		{"hebrew reversed", alef + bet + gimel, Auto, gimel + bet + alef},
+		{"hebrew in latin", "abc " + alef + bet + gimel + " def", LeftToRight, "abc " + gimel + bet + alef + " def"},
+		{"latin in hebrew", alef + bet + " abc", RightToLeft, "abc " + bet + alef},
+		{"two hebrew words", alef + bet + " " + gimel + alef, LeftToRight, alef + gimel + " " + bet + alef},
+		{"numbers keep their order", alef + bet + " 123", RightToLeft, "123 " + bet + alef},
+		{"number between hebrew", alef + " 12 " + bet, LeftToRight, bet + " 12 " + alef},
+		{"latin and number in rtl", "abc 123", RightToLeft, "abc 123"},
+		{"brackets mirror", "(" + alef + bet + ")", RightToLeft, "(" + bet + alef + ")"},
+		{"trailing space stays at the end", alef + bet + " ", LeftToRight, bet + alef + " "},
+		{"auto takes the first strong", alef + " abc", Auto, "abc " + alef},
+		{"arabic digits", "١٢", RightToLeft, "١٢"},
+		{"arabic is shaped then reversed", "بب", Auto, "ﺐﺑ"},
+		{"empty", "", RightToLeft, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Visual(tt.text, tt.dir); got != tt.want {
+				t.Errorf("Visual(%q, %v) = %q, want %q", tt.text, tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevels(t *testing.T) {
+	tests := []struct {
+		text string
+		dir  Direction
+		want []uint8
+	}{
+		{"ab", LeftToRight, []uint8{0, 0}},
+		{alef + bet, LeftToRight, []uint8{1, 1}},
+		{alef + bet, RightToLeft, []uint8{1, 1}},
+		{"a" + alef, RightToLeft, []uint8{2, 1}},
+		{alef + "1", RightToLeft, []uint8{1, 2}},
+		{"a " + alef + " ", LeftToRight, []uint8{0, 0, 1, 0}},
+	}
+	for _, tt := range tests {
+		got := Levels([]rune(tt.text), tt.dir)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("Levels(%q, %v) = %v, want %v", tt.text, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestVisualOrder(t *testing.T) {
+	tests := []struct {
+		levels []uint8
+		want   []int
+	}{
+		{nil, []int{}},
+		{[]uint8{0, 0, 0}, []int{0, 1, 2}},
+		{[]uint8{1, 1, 1}, []int{2, 1, 0}},
+		{[]uint8{0, 1, 1, 0}, []int{0, 2, 1, 3}},
+		{[]uint8{1, 2, 2, 1}, []int{3, 1, 2, 0}},
+	}
+	for _, tt := range tests {
+		if got := VisualOrder(tt.levels); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("VisualOrder(%v) = %v, want %v", tt.levels, got, tt.want)
+		}
+	}
+}
+
+func TestNeedsBidi(t *testing.T) {
+	for text, want := range map[string]bool{
+		"":                false,
+		"plain ascii 123": false,
+		"café ü":          false,
+		"a" + alef:        true,
+		"ب":               true,
+		"١":               true,
+	} {
+		if got := NeedsBidi(text); got != want {
+			t.Errorf("NeedsBidi(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package bidi implements the parts of the Unicode bidirectional algorithm
+// (UAX #9) needed to display single lines of mixed left-to-right and
+// right-to-left text, and contextual shaping of Arabic letters.
+//
+// Explicit embeddings, overrides and isolates are not supported; their
+// control characters are ignored.
+package bidi
+
+import "unicode"
+
+// Class is a bidirectional character type.
+type Class int
+
+const (
+	L   Class = iota // left-to-right
+	R                // right-to-left (Hebrew)
+	AL               // Arabic letter
+	EN               // European number
+	ES               // European number separator
+	ET               // European number terminator
+	AN               // Arabic number
+	CS               // common number separator
+	NSM              // non-spacing mark
+	BN               // boundary neutral
+	B                // paragraph separator
+	S                // segment separator
+	WS               // whitespace
+	ON               // other neutral
+)
+
+// ClassOf returns the bidirectional type of r. The table covers ASCII,
+// Hebrew, Arabic and their presentation forms exactly and falls back to
+// general categories elsewhere.
+func ClassOf(r rune) Class {
+	switch {
+	case r < 0x80:
+		return asciiClass(r)
+	case r == 0xA0 || r == 0x060C:
+		return CS
+	case r >= 0xA2 && r <= 0xA5, r == 0xB0, r == 0xB1, r == 0x066A:
+		return ET
+	case r == 0x200E:
+		return L
+	case r == 0x200F:
+		return R
+	case r == 0x061C:
+		return AL
+	case r >= 0x200B && r <= 0x200D, r >= 0x202A && r <= 0x202E, r >= 0x2066 && r <= 0x2069, r == 0xFEFF:
+		return BN
+	case r == 0x2028:
+		return WS
+	case r == 0x2029:
+		return B
+	case r >= 0x0590 && r <= 0x05FF:
+		if r >= 0x0591 && r <= 0x05BD || r == 0x05BF || r == 0x05C1 || r == 0x05C2 ||
+			r == 0x05C4 || r == 0x05C5 || r == 0x05C7 {
+			return NSM
+		}
+		return R
+	case r >= 0x0600 && r <= 0x06FF:
+		return arabicClass(r)
+	case r >= 0x0700 && r <= 0x07BF:
+		if unicode.Is(unicode.Mn, r) {
+			return NSM
+		}
+		return AL
+	case r >= 0x07C0 && r <= 0x085F:
+		if unicode.Is(unicode.Mn, r) {
+			return NSM
+		}
+		return R
+	case r >= 0x0860 && r <= 0x08FF:
+		if unicode.Is(unicode.Mn, r) {
+			return NSM
+		}
+		return AL
+	case r >= 0xFB1D && r <= 0xFB4F:
+		if r == 0xFB1E {
+			return NSM
+		}
+		return R
+	case r >= 0xFB50 && r <= 0xFDFF, r >= 0xFE70 && r <= 0xFEFE:
+		return AL
+	case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r):
+		return NSM
+	case unicode.IsSpace(r):
+		return WS
+	case unicode.IsLetter(r) || unicode.IsDigit(r):
+		return L
+	case unicode.IsPunct(r) || unicode.IsSymbol(r):
+		return ON
+	}
+	return L
+}
+
+func asciiClass(r rune) Class {
+	switch {
+	case r >= '0' && r <= '9':
+		return EN
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		return L
+	}
+	switch r {
+	case '+', '-':
+		return ES
+	case '#', '$', '%':
+		return ET
+	case ',', '.', '/', ':':
+		return CS
+	case '\t', 0x0B, 0x1F:
+		return S
+	case '\n', '\r', 0x1C, 0x1D, 0x1E:
+		return B
+	case ' ', 0x0C:
+		return WS
+	}
+	if r < 0x20 || r == 0x7F {
+		return BN
+	}
+	return ON
+}
+
+func arabicClass(r rune) Class {
+	switch {
+	case r >= 0x0660 && r <= 0x0669, r == 0x066B, r == 0x066C, r >= 0x0600 && r <= 0x0605, r == 0x06DD:
+		return AN
+	case r >= 0x06F0 && r <= 0x06F9:
+		return EN
+	case r >= 0x0610 && r <= 0x061A, r >= 0x064B && r <= 0x065F, r == 0x0670,
+		r >= 0x06D6 && r <= 0x06DC, r >= 0x06DF && r <= 0x06E4, r == 0x06E7, r == 0x06E8,
+		r >= 0x06EA && r <= 0x06ED:
+		return NSM
+	case r == 0x06DE || r == 0x06E9:
+		return ON
+	}
+	return AL
+}
+
+// isStrong reports whether c fixes the direction of surrounding neutrals.
+func (c Class) isStrong() bool {
+	return c == L || c == R || c == AL
+}
+
+// mirrors maps characters that are drawn mirrored at right-to-left levels.
+var mirrors = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	0xAB: 0xBB, 0xBB: 0xAB,
+	0x2039: 0x203A, 0x203A: 0x2039,
+	0x2264: 0x2265, 0x2265: 0x2264,
+}
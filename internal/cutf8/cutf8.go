@@ -0,0 +1,40 @@
+// Package cutf8 exposes the C renderer's UTF-8 decoder
+// (renderer/include/utf8.h) to Go, so that it can be tested against
+// unicode/utf8.
+package cutf8
+
+/*
+#include <stdlib.h>
+#include "../../renderer/include/utf8.h"
+
+static int decode_first(const char* s, int* size) {
+    const char* p = s;
+    int cp = decode_utf8(&p);
+    *size = (int)(p - s);
+    return cp;
+}
+*/
+import "C"
+import "unsafe"
+
+// Decode decodes the first sequence of s as the renderer does when it draws
+// and measures text, and returns the code point and the bytes it consumed.
+// Like the renderer, it sees s only up to the first NUL byte.
+func Decode(s string) (rune, int) {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	var size C.int
+	r := C.decode_first(cs, &size)
+	return rune(r), int(size)
+}
+
+// Runes decodes all of s.
+func Runes(s string) []rune {
+	var runes []rune
+	for len(s) > 0 && s[0] != 0 {
+		r, size := Decode(s)
+		runes = append(runes, r)
+		s = s[size:]
+	}
+	return runes
+}
@@ -0,0 +1,86 @@
+package cutf8
+
+import (
+	"fmt"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []rune
+	}{
+		{"empty", "", nil},
+		{"ascii", "aZ~", []rune{'a', 'Z', '~'}},
+		{"two bytes", "é", []rune{0xE9}},
+		{"three bytes", "€ש", []rune{0x20AC, 0x05E9}},
+		{"four bytes", "😀", []rune{0x1F600}},
+		{"smallest of each length", "\u0080ࠀ\U00010000", []rune{0x80, 0x800, 0x10000}},
+		{"largest of each length", "߿￿\U0010FFFF", []rune{0x7FF, 0xFFFF, 0x10FFFF}},
+		{"stops at nul", "a\x00b", []rune{'a'}},
+		{"lone continuation", "\x80a", []rune{0xFFFD, 'a'}},
+		{"invalid lead bytes", "\xC0\xC1\xF5\xFF", []rune{0xFFFD, 0xFFFD, 0xFFFD, 0xFFFD}},
+		{"overlong two bytes", "\xC0\x80", []rune{0xFFFD, 0xFFFD}},
+		{"overlong three bytes", "\xE0\x80\x80", []rune{0xFFFD, 0xFFFD, 0xFFFD}},
+		{"overlong four bytes", "\xF0\x80\x80\x80", []rune{0xFFFD, 0xFFFD, 0xFFFD, 0xFFFD}},
+		{"surrogate", "\xED\xA0\x80", []rune{0xFFFD, 0xFFFD, 0xFFFD}},
+		{"above max", "\xF4\x90\x80\x80", []rune{0xFFFD, 0xFFFD, 0xFFFD, 0xFFFD}},
+		{"truncated at the end", "a\xE2\x82", []rune{'a', 0xFFFD}},
+		{"truncated four bytes", "\xF0\x9F\x98", []rune{0xFFFD}},
+		{"truncated before ascii", "\xE2\x82a", []rune{0xFFFD, 'a'}},
+		{"truncated before a lead", "\xE2\xE2\x82\xAC", []rune{0xFFFD, 0x20AC}},
+		{"truncated before nul", "\xE2\x00b", []rune{0xFFFD}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Runes(tt.in); fmt.Sprintf("%U", got) != fmt.Sprintf("%U", tt.want) {
+				t.Errorf("Runes(%q) = %U, want %U", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDecodeMatchesGo checks every sequence of up to three bytes, and four
+// byte ones around the edges of the valid range, against unicode/utf8.
+func TestDecodeMatchesGo(t *testing.T) {
+	check := func(s string) {
+		t.Helper()
+		r, size := Decode(s)
+		want, wantSize := utf8.DecodeRuneInString(s)
+		if want == utf8.RuneError && wantSize <= 1 {
+			// Invalid: the decoder may consume more than Go's single byte,
+			// but never past the sequence.
+			if r != utf8.RuneError || size < 1 || size > len(s) {
+				t.Fatalf("Decode(%q) = %U, %d, want U+FFFD", s, r, size)
+			}
+			return
+		}
+		if r != want || size != wantSize {
+			t.Fatalf("Decode(%q) = %U, %d, want %U, %d", s, r, size, want, wantSize)
+		}
+	}
+	for a := 1; a < 256; a++ {
+		check(string([]byte{byte(a)}))
+		for b := 1; b < 256; b++ {
+			check(string([]byte{byte(a), byte(b)}))
+		}
+	}
+	for a := 0xE0; a <= 0xEF; a++ {
+		for b := 0x80; b < 0xC0; b++ {
+			for c := 1; c < 256; c++ {
+				check(string([]byte{byte(a), byte(b), byte(c)}))
+			}
+		}
+	}
+	for _, lead := range []byte{0xF0, 0xF1, 0xF4, 0xF5} {
+		for b := 0x80; b < 0xC0; b++ {
+			for _, c := range []byte{0x80, 0xBF, 'a'} {
+				for _, d := range []byte{0x80, 0xBF, 'a'} {
+					check(string([]byte{lead, byte(b), c, d}))
+				}
+			}
+		}
+	}
+}
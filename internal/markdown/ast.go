@@ -0,0 +1,126 @@
+// Package markdown parses a practical subset of CommonMark (plus GitHub
+// tables) into a small block/inline tree that UI components can expand.
+package markdown
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Blocks
+// ——————————————————————————————————————————————————————————————————————————————
+
+type Block interface{ isBlock() }
+
+type Document struct {
+	Blocks []Block
+}
+
+type Heading struct {
+	Level   int // 1..6
+	Inlines []Inline
+}
+
+type Paragraph struct {
+	Inlines []Inline
+}
+
+type List struct {
+	Ordered bool
+	Start   int
+	Items   [][]Block
+}
+
+type CodeBlock struct {
+	Info string // language from the fence info string
+	Text string
+}
+
+type BlockQuote struct {
+	Blocks []Block
+}
+
+type Alignment int
+
+const (
+	AlignNone Alignment = iota
+	AlignLeft
+	AlignCenter
+	AlignRight
+)
+
+type Table struct {
+	Header [][]Inline
+	Align  []Alignment
+	Rows   [][][]Inline
+}
+
+type ThematicBreak struct{}
+
+func (*Heading) isBlock()       {}
+func (*Paragraph) isBlock()     {}
+func (*List) isBlock()          {}
+func (*CodeBlock) isBlock()     {}
+func (*BlockQuote) isBlock()    {}
+func (*Table) isBlock()         {}
+func (*ThematicBreak) isBlock() {}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Inlines
+// ——————————————————————————————————————————————————————————————————————————————
+
+type Inline interface{ isInline() }
+
+type Text struct {
+	Text string
+}
+
+// Emphasis is *em* (Strong false) or **strong** (Strong true).
+type Emphasis struct {
+	Strong   bool
+	Children []Inline
+}
+
+type Code struct {
+	Text string
+}
+
+type Link struct {
+	URL      string
+	Title    string
+	Children []Inline
+}
+
+type Image struct {
+	URL   string
+	Title string
+	Alt   string
+}
+
+// LineBreak is a hard line break (two trailing spaces or a backslash).
+type LineBreak struct{}
+
+func (*Text) isInline()      {}
+func (*Emphasis) isInline()  {}
+func (*Code) isInline()      {}
+func (*Link) isInline()      {}
+func (*Image) isInline()     {}
+func (*LineBreak) isInline() {}
+
+// PlainText flattens inlines into their visible text.
+func PlainText(inlines []Inline) string {
+	var out []byte
+	for _, in := range inlines {
+		switch n := in.(type) {
+		case *Text:
+			out = append(out, n.Text...)
+		case *Code:
+			out = append(out, n.Text...)
+		case *Emphasis:
+			out = append(out, PlainText(n.Children)...)
+		case *Link:
+			out = append(out, PlainText(n.Children)...)
+		case *Image:
+			out = append(out, n.Alt...)
+		case *LineBreak:
+			out = append(out, '\n')
+		}
+	}
+	return string(out)
+}
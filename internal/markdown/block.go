@@ -0,0 +1,431 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Block parsing
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Parse parses Markdown source into a document. It never fails: anything
+// it does not recognise becomes paragraph text.
+func Parse(src string) *Document {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\t", "    ")
+	return &Document{Blocks: parseBlocks(strings.Split(src, "\n"))}
+}
+
+func parseBlocks(lines []string) []Block {
+	var blocks []Block
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if isBlank(line) {
+			i++
+			continue
+		}
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+
+		var block Block
+		switch {
+		case indent >= 4:
+			block, i = parseIndentedCode(lines, i)
+		case isFence(trimmed):
+			block, i = parseFencedCode(lines, i)
+		case isThematicBreak(trimmed):
+			block, i = &ThematicBreak{}, i+1
+		case headingLevel(trimmed) > 0:
+			block, i = parseHeading(trimmed), i+1
+		case strings.HasPrefix(trimmed, ">"):
+			block, i = parseBlockQuote(lines, i)
+		case isListItem(line):
+			block, i = parseList(lines, i)
+		case i+1 < len(lines) && strings.Contains(line, "|") && isTableDelimiter(lines[i+1]):
+			block, i = parseTable(lines, i)
+		default:
+			block, i = parseParagraph(lines, i)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// startsBlock reports whether line would interrupt a paragraph.
+func startsBlock(line string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) >= 4 {
+		return false
+	}
+	return isFence(trimmed) || isThematicBreak(trimmed) || headingLevel(trimmed) > 0 ||
+		strings.HasPrefix(trimmed, ">") || isListItem(line)
+}
+
+func parseParagraph(lines []string, i int) (Block, int) {
+	var text []string
+	for ; i < len(lines) && !isBlank(lines[i]); i++ {
+		if len(text) > 0 {
+			// An underline turns the paragraph into a setext heading; "---"
+			// here is not a thematic break.
+			if level := setextLevel(lines[i]); level > 0 {
+				heading := strings.TrimSpace(strings.Join(text, "\n"))
+				return &Heading{Level: level, Inlines: parseInlines(heading)}, i + 1
+			}
+			if startsBlock(lines[i]) {
+				break
+			}
+		}
+		text = append(text, strings.TrimLeft(lines[i], " "))
+	}
+	joined := strings.Join(text, "\n")
+	// Trailing spaces on the last line are not a hard break.
+	return &Paragraph{Inlines: parseInlines(strings.TrimRight(joined, " "))}, i
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Headings and breaks
+// ——————————————————————————————————————————————————————————————————————————————
+
+func headingLevel(trimmed string) int {
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0
+	}
+	if level < len(trimmed) && trimmed[level] != ' ' {
+		return 0
+	}
+	return level
+}
+
+// setextLevel returns 1 for a "===" underline, 2 for a "---" one and 0 if
+// line is not an underline.
+func setextLevel(line string) int {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || len(line)-len(strings.TrimLeft(line, " ")) >= 4 {
+		return 0
+	}
+	switch {
+	case strings.Trim(trimmed, "=") == "":
+		return 1
+	case strings.Trim(trimmed, "-") == "":
+		return 2
+	}
+	return 0
+}
+
+func parseHeading(trimmed string) Block {
+	level := headingLevel(trimmed)
+	text := strings.TrimSpace(trimmed[level:])
+	// Optional closing sequence: "## Title ##"
+	if stripped := strings.TrimRight(text, "#"); stripped != text && (stripped == "" || strings.HasSuffix(stripped, " ")) {
+		text = strings.TrimSpace(stripped)
+	}
+	return &Heading{Level: level, Inlines: parseInlines(text)}
+}
+
+func isThematicBreak(trimmed string) bool {
+	if trimmed == "" {
+		return false
+	}
+	marker := trimmed[0]
+	if marker != '-' && marker != '*' && marker != '_' {
+		return false
+	}
+	count := 0
+	for i := 0; i < len(trimmed); i++ {
+		switch trimmed[i] {
+		case marker:
+			count++
+		case ' ':
+		default:
+			return false
+		}
+	}
+	return count >= 3
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Code blocks
+// ——————————————————————————————————————————————————————————————————————————————
+
+func isFence(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+func parseFencedCode(lines []string, i int) (Block, int) {
+	line := lines[i]
+	trimmed := strings.TrimLeft(line, " ")
+	indent := len(line) - len(trimmed)
+	fenceChar := trimmed[0]
+	fenceLen := 0
+	for fenceLen < len(trimmed) && trimmed[fenceLen] == fenceChar {
+		fenceLen++
+	}
+	info := strings.TrimSpace(trimmed[fenceLen:])
+	if fields := strings.Fields(info); len(fields) > 0 {
+		info = fields[0]
+	}
+
+	var body []string
+	for i++; i < len(lines); i++ {
+		t := strings.TrimLeft(lines[i], " ")
+		if len(lines[i])-len(t) < 4 && strings.HasPrefix(t, strings.Repeat(string(fenceChar), fenceLen)) &&
+			strings.Trim(t, string(fenceChar)+" ") == "" {
+			i++
+			break
+		}
+		body = append(body, removeIndent(lines[i], indent))
+	}
+	return &CodeBlock{Info: info, Text: strings.Join(body, "\n")}, i
+}
+
+func parseIndentedCode(lines []string, i int) (Block, int) {
+	var body []string
+	for ; i < len(lines); i++ {
+		if isBlank(lines[i]) {
+			body = append(body, "")
+			continue
+		}
+		if len(lines[i])-len(strings.TrimLeft(lines[i], " ")) < 4 {
+			break
+		}
+		body = append(body, lines[i][4:])
+	}
+	for len(body) > 0 && body[len(body)-1] == "" {
+		body = body[:len(body)-1]
+	}
+	return &CodeBlock{Text: strings.Join(body, "\n")}, i
+}
+
+// removeIndent strips up to n leading spaces.
+func removeIndent(line string, n int) string {
+	i := 0
+	for i < n && i < len(line) && line[i] == ' ' {
+		i++
+	}
+	return line[i:]
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Block quotes
+// ——————————————————————————————————————————————————————————————————————————————
+
+func parseBlockQuote(lines []string, i int) (Block, int) {
+	var inner []string
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimLeft(lines[i], " ")
+		if strings.HasPrefix(trimmed, ">") {
+			content := trimmed[1:]
+			content = strings.TrimPrefix(content, " ")
+			inner = append(inner, content)
+			continue
+		}
+		// Lazy continuation of a quoted paragraph.
+		if !isBlank(lines[i]) && len(inner) > 0 && !isBlank(inner[len(inner)-1]) && !startsBlock(lines[i]) {
+			inner = append(inner, trimmed)
+			continue
+		}
+		break
+	}
+	return &BlockQuote{Blocks: parseBlocks(inner)}, i
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Lists
+// ——————————————————————————————————————————————————————————————————————————————
+
+type listMarker struct {
+	ordered bool
+	start   int
+	char    byte // bullet char, or '.' / ')' for ordered lists
+	offset  int  // column where the item content starts
+}
+
+func parseListMarker(line string) (listMarker, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	indent := len(line) - len(trimmed)
+	if indent > 3 || trimmed == "" {
+		return listMarker{}, false
+	}
+
+	var m listMarker
+	var width int
+	switch c := trimmed[0]; {
+	case c == '-' || c == '*' || c == '+':
+		m.char = c
+		width = 1
+	case c >= '0' && c <= '9':
+		digits := 0
+		for digits < len(trimmed) && digits < 9 && trimmed[digits] >= '0' && trimmed[digits] <= '9' {
+			digits++
+		}
+		if digits >= len(trimmed) || (trimmed[digits] != '.' && trimmed[digits] != ')') {
+			return listMarker{}, false
+		}
+		m.ordered = true
+		m.start, _ = strconv.Atoi(trimmed[:digits])
+		m.char = trimmed[digits]
+		width = digits + 1
+	default:
+		return listMarker{}, false
+	}
+
+	rest := trimmed[width:]
+	if rest != "" && rest[0] != ' ' {
+		return listMarker{}, false
+	}
+	spaces := len(rest) - len(strings.TrimLeft(rest, " "))
+	if spaces == 0 || spaces > 4 || strings.TrimSpace(rest) == "" {
+		spaces = 1
+	}
+	m.offset = indent + width + spaces
+	return m, true
+}
+
+func isListItem(line string) bool {
+	_, ok := parseListMarker(line)
+	return ok && !isThematicBreak(strings.TrimLeft(line, " "))
+}
+
+func parseList(lines []string, i int) (Block, int) {
+	first, _ := parseListMarker(lines[i])
+	list := &List{Ordered: first.ordered, Start: first.start}
+
+	var item []string
+	offset := 0
+	flush := func() {
+		for len(item) > 0 && isBlank(item[len(item)-1]) {
+			item = item[:len(item)-1]
+		}
+		list.Items = append(list.Items, parseBlocks(item))
+		item = nil
+	}
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if m, ok := parseListMarker(line); ok && !isThematicBreak(strings.TrimLeft(line, " ")) &&
+			(offset == 0 || indent < offset) {
+			if m.ordered != first.ordered || m.char != first.char {
+				break
+			}
+			if offset > 0 {
+				flush()
+			}
+			offset = m.offset
+			item = append(item, padTo(line, offset)[offset:])
+			continue
+		}
+		if isBlank(line) {
+			item = append(item, "")
+			continue
+		}
+		if indent >= offset {
+			item = append(item, line[offset:])
+			continue
+		}
+		// Lazy continuation of the item's paragraph.
+		if len(item) > 0 && !isBlank(item[len(item)-1]) && !startsBlock(line) {
+			item = append(item, strings.TrimLeft(line, " "))
+			continue
+		}
+		break
+	}
+	flush()
+	return list, i
+}
+
+// padTo makes sure an empty list item line is long enough to slice.
+func padTo(line string, n int) string {
+	if len(line) < n {
+		return line + strings.Repeat(" ", n-len(line))
+	}
+	return line
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Tables (GitHub flavoured)
+// ——————————————————————————————————————————————————————————————————————————————
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	inCode := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case c == '`':
+			inCode = !inCode
+			cell.WriteByte(c)
+		case c == '|' && !inCode:
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(c)
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func isTableDelimiter(line string) bool {
+	if !strings.Contains(line, "-") {
+		return false
+	}
+	for _, cell := range splitTableRow(line) {
+		cell = strings.TrimSuffix(strings.TrimPrefix(cell, ":"), ":")
+		if cell == "" || strings.Trim(cell, "-") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func parseTable(lines []string, i int) (Block, int) {
+	t := &Table{}
+	for _, cell := range splitTableRow(lines[i]) {
+		t.Header = append(t.Header, parseInlines(cell))
+	}
+	for _, cell := range splitTableRow(lines[i+1]) {
+		left := strings.HasPrefix(cell, ":")
+		right := strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			t.Align = append(t.Align, AlignCenter)
+		case left:
+			t.Align = append(t.Align, AlignLeft)
+		case right:
+			t.Align = append(t.Align, AlignRight)
+		default:
+			t.Align = append(t.Align, AlignNone)
+		}
+	}
+
+	for i += 2; i < len(lines) && !isBlank(lines[i]) && strings.Contains(lines[i], "|"); i++ {
+		cells := splitTableRow(lines[i])
+		row := make([][]Inline, len(t.Header))
+		for c := range row {
+			if c < len(cells) {
+				row[c] = parseInlines(cells[c])
+			}
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t, i
+}
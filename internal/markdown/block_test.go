@@ -0,0 +1,175 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// dump writes blocks in a compact form that tests can compare against:
+// h2(Title), p(text), ul[item|item], ol3[...], code:go("..."), quote(...), hr.
+func dump(blocks []Block) string {
+	parts := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		switch b := b.(type) {
+		case *Heading:
+			parts = append(parts, fmt.Sprintf("h%d(%s)", b.Level, PlainText(b.Inlines)))
+		case *Paragraph:
+			parts = append(parts, fmt.Sprintf("p(%s)", PlainText(b.Inlines)))
+		case *List:
+			items := make([]string, len(b.Items))
+			for i, item := range b.Items {
+				items[i] = dump(item)
+			}
+			kind := "ul"
+			if b.Ordered {
+				kind = fmt.Sprintf("ol%d", b.Start)
+			}
+			parts = append(parts, fmt.Sprintf("%s[%s]", kind, strings.Join(items, "|")))
+		case *CodeBlock:
+			parts = append(parts, fmt.Sprintf("code:%s(%q)", b.Info, b.Text))
+		case *BlockQuote:
+			parts = append(parts, fmt.Sprintf("quote(%s)", dump(b.Blocks)))
+		case *Table:
+			parts = append(parts, fmt.Sprintf("table%dx%d", len(b.Header), len(b.Rows)))
+		case *ThematicBreak:
+			parts = append(parts, "hr")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestParseHeadings(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{"atx", "# One\n## Two\n###### Six", "h1(One) h2(Two) h6(Six)"},
+		{"atx closing sequence", "## Title ##", "h2(Title)"},
+		{"atx needs a space", "#Title", "p(#Title)"},
+		{"atx too deep", "####### Seven", "p(####### Seven)"},
+		{"atx empty", "#", "h1()"},
+		{"setext level 1", "Title\n=====", "h1(Title)"},
+		{"setext level 2", "Title\n---", "h2(Title)"},
+		{"setext short underline", "Title\n=", "h1(Title)"},
+		{"setext multiline", "First\nSecond\n===", "h1(First Second)"},
+		{"setext ends the paragraph", "Title\n---\nBody", "h2(Title) p(Body)"},
+		{"underline alone is a break", "---", "hr"},
+		{"underline alone is text", "===", "p(===)"},
+		{"spaced dashes are a break", "Text\n- - -", "p(Text) hr"},
+		{"underline after blank line", "Title\n\n---", "p(Title) hr"},
+		{"indented underline is text", "Title\n    ===", "p(Title ===)"},
+		{"atx interrupts a paragraph", "Text\n# Title", "p(Text) h1(Title)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dump(Parse(tt.src).Blocks); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFencedCode(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{"backticks", "```\ncode\n```", `code:("code")`},
+		{"info string", "```go extra\nfmt.Println()\n```", `code:go("fmt.Println()")`},
+		{"tildes", "~~~\na\n~~~", `code:("a")`},
+		{"longer closing fence", "```\na\n`````", `code:("a")`},
+		{"shorter fence does not close", "````\na\n```\nb\n````", "code:(\"a\\n```\\nb\")"},
+		{"other fence char does not close", "```\na\n~~~\n```", "code:(\"a\\n~~~\")"},
+		{"keeps blank lines", "```\na\n\nb\n```", `code:("a\n\nb")`},
+		{"strips the fence indent", "  ```\n  a\n    b\n  ```", `code:("a\n  b")`},
+		{"markdown inside is literal", "```\n# not a heading\n- nor a list\n```", `code:("# not a heading\n- nor a list")`},
+		{"unterminated runs to the end", "```\na\n\n# b", `code:("a\n\n# b")`},
+		{"unterminated and empty", "```", `code:("")`},
+		{"interrupts a paragraph", "text\n```\ncode\n```\nafter", `p(text) code:("code") p(after)`},
+		{"indented code", "    a\n\n    b\nc", `code:("a\n\nb") p(c)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dump(Parse(tt.src).Blocks); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLists(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{"bullets", "- a\n- b", "ul[p(a)|p(b)]"},
+		{"ordered start", "3. a\n4. b", "ol3[p(a)|p(b)]"},
+		{"paren delimiter", "1) a\n2) b", "ol1[p(a)|p(b)]"},
+		{"nested", "- a\n  - b\n  - c\n- d", "ul[p(a) ul[p(b)|p(c)]|p(d)]"},
+		{"nested three deep", "- a\n  - b\n    - c", "ul[p(a) ul[p(b) ul[p(c)]]]"},
+		{"ordered in bullets", "- a\n  1. b\n  2. c", "ul[p(a) ol1[p(b)|p(c)]]"},
+		{"bullets in ordered", "1. a\n   - b", "ol1[p(a) ul[p(b)]]"},
+		{"code in an item", "- a\n\n  ```\n  x\n  ```", `ul[p(a) code:("x")]`},
+		{"quote in an item", "- > q", "ul[quote(p(q))]"},
+		{"lazy continuation", "- a\nb", "ul[p(a b)]"},
+		{"loose items", "- a\n\n- b", "ul[p(a)|p(b)]"},
+		{"marker change starts a new list", "- a\n* b", "ul[p(a)] ul[p(b)]"},
+		{"ordered to bullets", "1. a\n- b", "ol1[p(a)] ul[p(b)]"},
+		{"empty item", "-\n- b", "ul[|p(b)]"},
+		{"unterminated at the end", "1. a\n2.", "ol1[p(a)|]"},
+		{"empty marker underlines the item", "- a\n  -", "ul[h2(a)]"},
+		{"needs a space", "-a", "p(-a)"},
+		{"break is not an item", "- - -", "hr"},
+		{"ends at a heading", "- a\n# b", "ul[p(a)] h1(b)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dump(Parse(tt.src).Blocks); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOtherBlocks(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{"empty", "", ""},
+		{"blank lines", "\n \n\n", ""},
+		{"crlf", "a\r\nb\r\n\r\nc", "p(a b) p(c)"},
+		{"quote", "> a\n> b", "quote(p(a b))"},
+		{"quote lazy continuation", "> a\nb", "quote(p(a b))"},
+		{"quote nested", "> > a", "quote(quote(p(a)))"},
+		{"quote with a list", "> - a\n> - b", "quote(ul[p(a)|p(b)])"},
+		{"quote unterminated fence", "> ```\n> a", `quote(code:("a"))`},
+		{"table", "a | b\n--|--\n1 | 2", "table2x1"},
+		{"table needs a delimiter", "a | b\n1 | 2", "p(a | b 1 | 2)"},
+		{"breaks", "***\n___", "hr hr"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dump(Parse(tt.src).Blocks); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUnterminatedInlines(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{"*open", "*open"},
+		{"**open", "**open"},
+		{"`open", "`open"},
+		{"[text](open", "[text](open"},
+		{"[open", "[open"},
+		{"![alt](open", "![alt](open"},
+		{"<open", "<open"},
+		{"trailing \\", "trailing \\"},
+	}
+	for _, tt := range tests {
+		if got := dump(Parse(tt.src).Blocks); got != "p("+tt.want+")" {
+			t.Errorf("Parse(%q) = %s, want p(%s)", tt.src, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Inline parsing
+// ——————————————————————————————————————————————————————————————————————————————
+
+const asciiPunctuation = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+func parseInlines(s string) []Inline {
+	p := inlineParser{src: s}
+	return p.parse()
+}
+
+type inlineParser struct {
+	src   string
+	out   []Inline
+	text  strings.Builder
+	index int
+}
+
+func (p *inlineParser) parse() []Inline {
+	s := p.src
+	for p.index < len(s) {
+		c := s[p.index]
+		switch {
+		case c == '\\' && p.index+1 < len(s) && s[p.index+1] == '\n':
+			p.emit(&LineBreak{})
+			p.index += 2
+		case c == '\\' && p.index+1 < len(s) && strings.IndexByte(asciiPunctuation, s[p.index+1]) >= 0:
+			p.text.WriteByte(s[p.index+1])
+			p.index += 2
+		case c == '\n':
+			p.lineEnding()
+		case c == '`':
+			p.codeSpan()
+		case c == '!' && p.index+1 < len(s) && s[p.index+1] == '[':
+			if !p.link(true) {
+				p.text.WriteByte(c)
+				p.index++
+			}
+		case c == '[':
+			if !p.link(false) {
+				p.text.WriteByte(c)
+				p.index++
+			}
+		case c == '<':
+			if !p.autolink() {
+				p.text.WriteByte(c)
+				p.index++
+			}
+		case c == '*' || c == '_':
+			p.emphasis()
+		default:
+			p.text.WriteByte(c)
+			p.index++
+		}
+	}
+	p.flushText()
+	return p.out
+}
+
+func (p *inlineParser) flushText() {
+	if p.text.Len() == 0 {
+		return
+	}
+	p.out = append(p.out, &Text{Text: p.text.String()})
+	p.text.Reset()
+}
+
+func (p *inlineParser) emit(in Inline) {
+	p.flushText()
+	p.out = append(p.out, in)
+}
+
+// lineEnding turns a newline into a hard break after two trailing spaces,
+// or a single space (soft break) otherwise.
+func (p *inlineParser) lineEnding() {
+	pending := p.text.String()
+	trimmed := strings.TrimRight(pending, " ")
+	hard := len(pending)-len(trimmed) >= 2
+	p.text.Reset()
+	p.text.WriteString(trimmed)
+	if hard {
+		p.emit(&LineBreak{})
+	} else {
+		p.text.WriteByte(' ')
+	}
+	p.index++
+	// Leading spaces of the next line are not significant.
+	for p.index < len(p.src) && p.src[p.index] == ' ' {
+		p.index++
+	}
+}
+
+func (p *inlineParser) codeSpan() {
+	s := p.src
+	start := p.index
+	n := runLength(s, start, '`')
+	for i := start + n; i < len(s); {
+		j := strings.IndexByte(s[i:], '`')
+		if j < 0 {
+			break
+		}
+		j += i
+		m := runLength(s, j, '`')
+		if m == n {
+			code := strings.ReplaceAll(s[start+n:j], "\n", " ")
+			if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+			p.emit(&Code{Text: code})
+			p.index = j + m
+			return
+		}
+		i = j + m
+	}
+	// No closing run: the backticks are literal.
+	p.text.WriteString(s[start : start+n])
+	p.index = start + n
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+// link parses [label](destination "title") or, with image set, ![alt](src).
+func (p *inlineParser) link(image bool) bool {
+	s := p.src
+	open := p.index
+	if image {
+		open++
+	}
+	closeLabel := matchingBracket(s, open)
+	if closeLabel < 0 || closeLabel+1 >= len(s) || s[closeLabel+1] != '(' {
+		return false
+	}
+	closeDest := strings.IndexByte(s[closeLabel+2:], ')')
+	if closeDest < 0 {
+		return false
+	}
+	closeDest += closeLabel + 2
+
+	dest := strings.TrimSpace(s[closeLabel+2 : closeDest])
+	title := ""
+	if k := strings.IndexAny(dest, " \n"); k >= 0 {
+		title = strings.Trim(strings.TrimSpace(dest[k:]), "\"'")
+		dest = dest[:k]
+	}
+	dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+	label := s[open+1 : closeLabel]
+
+	if image {
+		p.emit(&Image{URL: dest, Title: title, Alt: PlainText(parseInlines(label))})
+	} else {
+		p.emit(&Link{URL: dest, Title: title, Children: parseInlines(label)})
+	}
+	p.index = closeDest + 1
+	return true
+}
+
+// matchingBracket returns the index of the ']' closing the '[' at open.
+func matchingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '`':
+			// Brackets inside code spans do not count.
+			n := runLength(s, i, '`')
+			if j := strings.Index(s[i+n:], strings.Repeat("`", n)); j >= 0 {
+				i += n + j + n - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (p *inlineParser) autolink() bool {
+	s := p.src
+	end := strings.IndexByte(s[p.index:], '>')
+	if end < 0 {
+		return false
+	}
+	target := s[p.index+1 : p.index+end]
+	if strings.ContainsAny(target, " \n<") {
+		return false
+	}
+	switch {
+	case strings.Contains(target, "://"):
+	case strings.Contains(target, "@"):
+		p.emit(&Link{URL: "mailto:" + target, Children: []Inline{&Text{Text: target}}})
+		p.index += end + 1
+		return true
+	default:
+		return false
+	}
+	p.emit(&Link{URL: target, Children: []Inline{&Text{Text: target}}})
+	p.index += end + 1
+	return true
+}
+
+// emphasis handles *em*, _em_, **strong** and __strong__. Openers must be
+// followed by non-space and closers preceded by non-space; underscores
+// additionally may not open or close inside a word.
+func (p *inlineParser) emphasis() {
+	s := p.src
+	c := s[p.index]
+	n := runLength(s, p.index, c)
+
+	if canOpen(s, p.index, n, c) {
+		for _, width := range []int{2, 1} {
+			if n < width {
+				continue
+			}
+			if closeAt := findCloser(s, p.index+width, c, width); closeAt >= 0 {
+				inner := s[p.index+width : closeAt]
+				p.emit(&Emphasis{Strong: width == 2, Children: parseInlines(inner)})
+				p.index = closeAt + width
+				return
+			}
+		}
+	}
+	p.text.WriteString(s[p.index : p.index+n])
+	p.index += n
+}
+
+func canOpen(s string, i, n int, c byte) bool {
+	after, _ := utf8.DecodeRuneInString(s[min(i+n, len(s)):])
+	if i+n >= len(s) || unicode.IsSpace(after) {
+		return false
+	}
+	if c == '_' && i > 0 {
+		before, _ := utf8.DecodeLastRuneInString(s[:i])
+		if unicode.IsLetter(before) || unicode.IsDigit(before) {
+			return false
+		}
+	}
+	return true
+}
+
+// findCloser returns the index of a closing run of width delimiters c,
+// skipping code spans, escapes and nested runs of a different width.
+func findCloser(s string, from int, c byte, width int) int {
+	for i := from; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '`':
+			n := runLength(s, i, '`')
+			if j := strings.Index(s[i+n:], strings.Repeat("`", n)); j >= 0 {
+				i += n + j + n - 1
+			}
+		case c:
+			n := runLength(s, i, c)
+			before, _ := utf8.DecodeLastRuneInString(s[:i])
+			closes := i > from && !unicode.IsSpace(before)
+			if c == '_' && i+n < len(s) {
+				after, _ := utf8.DecodeRuneInString(s[i+n:])
+				closes = closes && !unicode.IsLetter(after) && !unicode.IsDigit(after)
+			}
+			// A run of three closes both an emphasis and a strong span;
+			// use its last delimiters so the rest belongs to the inner span.
+			if closes && (n == width || n == 3) {
+				return i + n - width
+			}
+			i += n - 1
+		}
+	}
+	return -1
+}
@@ -21,18 +21,48 @@ type Button struct {
 }
 
 func NewButton(label string) *Button {
+	theme := CurrentTheme()
 	b := &Button{
 		Component:    newComponentBase(ButtonKind),
 		Label:        label,
 		Callback:     nil,
-		HoverColor:   color.RGBA{R: 0.3, G: 0.5, B: 0.9, A: 1},
-		PressedColor: color.RGBA{R: 0.1, G: 0.3, B: 0.7, A: 1},
-		TextColor:    color.RGBA{R: 1, G: 1, B: 1, A: 1},
+		HoverColor:   theme.PrimaryHover,
+		PressedColor: theme.PrimaryPressed,
+		TextColor:    theme.OnPrimary,
 	}
 	b.Component.setDisplay(DisplayBlock)
+	b.Component.setBackgroundColor(theme.Primary)
+	b.Component.setTransitions(NewTransition(PropertyBackgroundColor, ButtonFadeDuration))
 	return b
 }
 
+// ButtonFadeDuration is how long, in seconds, a button takes to fade between
+// its background, hover and pressed colors.
+const ButtonFadeDuration float32 = 0.15
+
+// stateColor returns the color the button is filled with in its current
+// state.
+func (b *Button) stateColor() color.RGBA {
+	if b.IsPressed {
+		return b.PressedColor
+	}
+	if b.IsMouseOver {
+		return b.HoverColor
+	}
+	return b.backgroundColor
+}
+
+// Fill returns the color the button is drawn in: the one for its state, or
+// while a background transition runs, the color it has faded to so far.
+func (b *Button) Fill() color.RGBA {
+	for _, t := range b.transitions {
+		if t.Property == PropertyBackgroundColor {
+			return b.backgroundColor
+		}
+	}
+	return b.stateColor()
+}
+
 // ——————————————————————————————————————————————————————————————————————————————
 // Fluent Setters
 // ——————————————————————————————————————————————————————————————————————————————
@@ -92,6 +122,42 @@ func (b *Button) SetZIndex(zIndex int) *Button {
 	return b
 }
 
+func (b *Button) SetTooltip(tooltip *Tooltip) *Button {
+	b.Component.setTooltip(tooltip)
+	return b
+}
+
+func (b *Button) SetTransform(transform *Transform) *Button {
+	b.Component.setTransform(transform)
+	return b
+}
+
+func (b *Button) SetOpacity(opacity float32) *Button {
+	b.Component.setOpacity(opacity)
+	return b
+}
+
+// SetShadow sets the shadows the button casts, replacing any set before.
+func (b *Button) SetShadow(shadows ...BoxShadow) *Button {
+	b.Component.setShadows(shadows...)
+	return b
+}
+
+// SetGradient fills the background with gradient instead of the background
+// color; nil goes back to the color.
+func (b *Button) SetGradient(gradient *color.Gradient) *Button {
+	b.Component.setGradient(gradient)
+	return b
+}
+
+// SetTransition animates changes to the given properties, replacing the
+// transitions already set for them. A button fades its background by
+// default; pass NewTransition(PropertyBackgroundColor, 0) to make it snap.
+func (b *Button) SetTransition(transitions ...*Transition) *Button {
+	b.Component.setTransitions(transitions...)
+	return b
+}
+
 func (b *Button) FontSize() float32 {
 	return 24.0
 }
@@ -0,0 +1,322 @@
+package ui
+
+import (
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/geometry"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Canvas Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// DefaultCanvasSize is the size of a canvas's content when it is not given
+// one.
+var DefaultCanvasSize = math.Vec2f32{X: 300, Y: 150}
+
+// Canvas is drawn by a callback with a Painter, every frame, in coordinates
+// relative to its content box. What is drawn outside the content box is
+// clipped.
+type Canvas struct {
+	Component
+	// Draw paints the canvas; size is the size of its content box.
+	Draw        func(p *Painter, size math.Vec2f32)
+	defaultSize math.Vec2f32
+}
+
+func NewCanvas(draw func(p *Painter, size math.Vec2f32)) *Canvas {
+	return &Canvas{
+		Component:   newComponentBase(CanvasKind),
+		Draw:        draw,
+		defaultSize: DefaultCanvasSize,
+	}
+}
+
+// Paint runs the draw callback for a content box of size and returns what it
+// painted.
+func (c *Canvas) Paint(size math.Vec2f32) []PaintOp {
+	if c.Draw == nil {
+		return nil
+	}
+	p := NewPainter()
+	c.Draw(p, size)
+	return p.Ops()
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Painter
+// ——————————————————————————————————————————————————————————————————————————————
+
+// PaintOpKind is the shape a PaintOp draws.
+type PaintOpKind int
+
+const (
+	// PaintTriangles fills the triangles of Mesh.
+	PaintTriangles PaintOpKind = iota
+	// PaintCircle fills the circle around Center of Radius.
+	PaintCircle
+)
+
+// PaintOp is one shape painted on a canvas, in Color.
+type PaintOp struct {
+	Kind   PaintOpKind
+	Mesh   geometry.Mesh
+	Center math.Vec2f32
+	Radius float32
+	Color  color.RGBA
+}
+
+// Painter builds paths and fills or strokes them, recording what it paints
+// as PaintOps, much like an HTML canvas context. A path is a number of
+// subpaths, each started with MoveTo (or a shape such as Rect or Circle).
+// Strokes are anti-aliased unless turned off with SetAntiAlias.
+type Painter struct {
+	ops        []PaintOp
+	path       geometry.Path
+	style      geometry.StrokeStyle
+	fillRule   geometry.FillRule
+	dash       []float32
+	dashOffset float32
+}
+
+func NewPainter() *Painter {
+	return &Painter{style: geometry.StrokeStyle{
+		Width:      1,
+		MiterLimit: geometry.DefaultMiterLimit,
+		AntiAlias:  true,
+	}}
+}
+
+// Ops returns what has been painted so far, in order.
+func (p *Painter) Ops() []PaintOp { return p.ops }
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Style
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (p *Painter) SetLineWidth(width float32) *Painter {
+	p.style.Width = max(0, width)
+	return p
+}
+
+func (p *Painter) SetLineJoin(join geometry.LineJoin) *Painter {
+	p.style.Join = join
+	return p
+}
+
+func (p *Painter) SetLineCap(lineCap geometry.LineCap) *Painter {
+	p.style.Cap = lineCap
+	return p
+}
+
+// SetMiterLimit sets how long a miter join may be, in line widths, before it
+// is beveled instead. It is 10 by default.
+func (p *Painter) SetMiterLimit(limit float32) *Painter {
+	p.style.MiterLimit = max(1, limit)
+	return p
+}
+
+// SetDash strokes with alternating dashes and gaps of the given lengths,
+// starting offset into the pattern. No pattern draws solid lines.
+func (p *Painter) SetDash(offset float32, pattern ...float32) *Painter {
+	p.dash, p.dashOffset = pattern, offset
+	return p
+}
+
+func (p *Painter) SetAntiAlias(antiAlias bool) *Painter {
+	p.style.AntiAlias = antiAlias
+	return p
+}
+
+// SetFillRule sets which areas of a path that overlaps itself Fill fills.
+// It is geometry.NonZero by default.
+func (p *Painter) SetFillRule(rule geometry.FillRule) *Painter {
+	p.fillRule = rule
+	return p
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Paths
+// ——————————————————————————————————————————————————————————————————————————————
+
+// BeginPath discards the current path.
+func (p *Painter) BeginPath() *Painter {
+	p.path.Reset()
+	return p
+}
+
+// MoveTo starts a new subpath at point.
+func (p *Painter) MoveTo(point math.Vec2f32) *Painter {
+	p.path.MoveTo(point)
+	return p
+}
+
+// LineTo adds a straight line to point.
+func (p *Painter) LineTo(point math.Vec2f32) *Painter {
+	p.path.LineTo(point)
+	return p
+}
+
+// QuadTo adds a quadratic Bézier curve through control to point.
+func (p *Painter) QuadTo(control, point math.Vec2f32) *Painter {
+	p.path.QuadTo(control, point)
+	return p
+}
+
+// CubicTo adds a cubic Bézier curve through control points c1 and c2 to
+// point.
+func (p *Painter) CubicTo(c1, c2, point math.Vec2f32) *Painter {
+	p.path.CubicTo(c1, c2, point)
+	return p
+}
+
+// Arc adds an arc of the circle around center from angle start to angle end,
+// in radians clockwise from the positive x axis. It sweeps counterclockwise
+// when end is less than start. A line joins the current point to the start
+// of the arc.
+func (p *Painter) Arc(center math.Vec2f32, radius, start, end float32) *Painter {
+	p.path.Arc(center, radius, start, end)
+	return p
+}
+
+// Close closes the current subpath with a line back to its start.
+func (p *Painter) Close() *Painter {
+	p.path.Close()
+	return p
+}
+
+// Rect adds a closed rectangle at pos of size.
+func (p *Painter) Rect(pos, size math.Vec2f32) *Painter {
+	p.path.Rect(pos, size)
+	return p
+}
+
+// Circle adds a closed circle around center.
+func (p *Painter) Circle(center math.Vec2f32, radius float32) *Painter {
+	p.path.Ellipse(center, math.Vec2f32{X: radius, Y: radius})
+	return p
+}
+
+// Ellipse adds a closed ellipse around center with the horizontal and
+// vertical radii.
+func (p *Painter) Ellipse(center, radii math.Vec2f32) *Painter {
+	p.path.Ellipse(center, radii)
+	return p
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Painting
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (p *Painter) paint(mesh geometry.Mesh, paint color.RGBA) {
+	if len(mesh.Vertices) > 0 {
+		p.ops = append(p.ops, PaintOp{Kind: PaintTriangles, Mesh: mesh, Color: paint})
+	}
+}
+
+// Fill fills the current path with fill, by the fill rule.
+func (p *Painter) Fill(fill color.RGBA) *Painter {
+	p.paint(geometry.Fill(p.path.Contours(), p.fillRule), fill)
+	return p
+}
+
+// Stroke draws the outline of the current path in stroke, with the line
+// width, joins, caps and dashes set on the painter.
+func (p *Painter) Stroke(stroke color.RGBA) *Painter {
+	contours := geometry.Dash(p.path.Contours(), p.dash, p.dashOffset)
+	p.paint(geometry.Stroke(contours, p.style), stroke)
+	return p
+}
+
+// FillCircle fills a circle around center without touching the current
+// path.
+func (p *Painter) FillCircle(center math.Vec2f32, radius float32, fill color.RGBA) *Painter {
+	p.ops = append(p.ops, PaintOp{Kind: PaintCircle, Center: center, Radius: radius, Color: fill})
+	return p
+}
+
+// FillRect fills a rectangle without touching the current path.
+func (p *Painter) FillRect(pos, size math.Vec2f32, fill color.RGBA) *Painter {
+	var rect geometry.Path
+	p.paint(geometry.Fill(rect.Rect(pos, size).Contours(), geometry.NonZero), fill)
+	return p
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (c *Canvas) SetID(id string) *Canvas {
+	c.Component.setID(id)
+	return c
+}
+
+func (c *Canvas) SetDraw(draw func(p *Painter, size math.Vec2f32)) *Canvas {
+	c.Draw = draw
+	return c
+}
+
+func (c *Canvas) SetBackgroundColor(color color.RGBA) *Canvas {
+	c.Component.setBackgroundColor(color)
+	return c
+}
+
+func (c *Canvas) SetSize(size math.Vec2f32) *Canvas {
+	c.Component.setSize(size)
+	return c
+}
+
+func (c *Canvas) SetWidthPercent(widthPercent float32) *Canvas {
+	c.Component.setWidthPercent(widthPercent)
+	return c
+}
+
+func (c *Canvas) SetDisplay(d Display) *Canvas {
+	c.Component.setDisplay(d)
+	return c
+}
+
+func (c *Canvas) SetPosition(pos Position) *Canvas {
+	c.Component.setPos(pos)
+	return c
+}
+
+func (c *Canvas) SetMargin(margin math.Vec2f32) *Canvas {
+	c.Component.setMargin(margin)
+	return c
+}
+
+func (c *Canvas) SetPadding(padding math.Vec2f32) *Canvas {
+	c.Component.setPadding(padding)
+	return c
+}
+
+func (c *Canvas) SetBorder(border math.Vec2f32) *Canvas {
+	c.Component.setBorder(border)
+	return c
+}
+
+func (c *Canvas) SetBorderColor(color color.RGBA) *Canvas {
+	c.Component.setBorderColor(color)
+	return c
+}
+
+func (c *Canvas) SetBorderRadius(radius float32) *Canvas {
+	c.Component.setBorderRadius(radius)
+	return c
+}
+
+func (c *Canvas) SetZIndex(zIndex int) *Canvas {
+	c.Component.setZIndex(zIndex)
+	return c
+}
+
+func (c *Canvas) SetTooltip(tooltip *Tooltip) *Canvas {
+	c.Component.setTooltip(tooltip)
+	return c
+}
+
+func (c *Canvas) SetTransform(transform *Transform) *Canvas {
+	c.Component.setTransform(transform)
+	return c
+}
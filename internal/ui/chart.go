@@ -0,0 +1,493 @@
+package ui
+
+import (
+	gomath "math"
+	"strconv"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Chart Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+var (
+	// DefaultChartSize is the size of a chart's content when it is not
+	// given one.
+	DefaultChartSize = math.Vec2f32{X: 320, Y: 200}
+	// DefaultSparklineSize is the size of a sparkline when it is not given
+	// one.
+	DefaultSparklineSize = math.Vec2f32{X: 120, Y: 28}
+)
+
+const (
+	// ChartLabelGap is the space between the plot and its axis labels, and
+	// between legend entries.
+	ChartLabelGap float32 = 6
+	// chartBarGroup is the part of a category's slot its bars fill.
+	chartBarGroup float32 = 0.7
+)
+
+// ChartType is how a chart draws its series.
+type ChartType int
+
+const (
+	ChartTypeLine ChartType = iota
+	// ChartTypeArea is a line chart filled down to zero (or the bottom of
+	// the value axis).
+	ChartTypeArea
+	// ChartTypeBar draws a bar per value, grouped by index.
+	ChartTypeBar
+)
+
+// ChartSeries is one named series of values, plotted against their index.
+// A zero Color takes the next color of the chart's palette.
+type ChartSeries struct {
+	Name   string
+	Values []float32
+	Color  color.RGBA
+}
+
+// DefaultChartPalette colors series that have no color of their own.
+var DefaultChartPalette = []color.RGBA{
+	{R: 0.3, G: 0.6, B: 1, A: 1},
+	{R: 1, G: 0.6, B: 0.2, A: 1},
+	{R: 0.4, G: 0.8, B: 0.4, A: 1},
+	{R: 0.9, G: 0.35, B: 0.4, A: 1},
+	{R: 0.7, G: 0.5, B: 0.9, A: 1},
+}
+
+// Chart plots series of numbers as lines, filled areas or bars, with a value
+// axis whose ticks fall on round numbers, optional grid lines, category
+// labels under the plot and a legend above it. While the pointer is over the
+// plot a crosshair marks the nearest index and a tooltip lists its values.
+//
+// The value axis fits the data unless Min and Max are set with SetRange.
+type Chart struct {
+	Component
+	Type   ChartType
+	Series []ChartSeries
+	// Labels name the indices along the bottom axis.
+	Labels    []string
+	Min       float32
+	Max       float32
+	AutoScale bool
+	// Ticks is about how many value ticks to show.
+	Ticks          int
+	ShowAxes       bool
+	ShowGrid       bool
+	ShowLegend     bool
+	ShowTooltip    bool
+	Thickness      float32
+	FontSize       float32
+	TextColor      color.RGBA
+	AxisColor      color.RGBA
+	GridColor      color.RGBA
+	CrosshairColor color.RGBA
+	TooltipColor   color.RGBA
+	Palette        []color.RGBA
+	// HoveredIndex is the index under the pointer, or -1; Pointer is where
+	// the pointer is, relative to the content box.
+	HoveredIndex int
+	Pointer      math.Vec2f32
+	defaultSize  math.Vec2f32
+	// Set by layout: the plot area within the content box, the value axis
+	// and how many indices apart the bottom labels are.
+	plotPos    math.Vec2f32
+	plotSize   math.Vec2f32
+	lo, hi     float32
+	step       float32
+	labelEvery int
+}
+
+func NewChart(chartType ChartType, series ...ChartSeries) *Chart {
+	theme := CurrentTheme()
+	return &Chart{
+		Component:      newComponentBase(ChartKind),
+		Type:           chartType,
+		Series:         series,
+		AutoScale:      true,
+		Ticks:          5,
+		ShowAxes:       true,
+		ShowGrid:       true,
+		ShowLegend:     len(series) > 1,
+		ShowTooltip:    true,
+		Thickness:      2,
+		FontSize:       theme.FontSizeCaption,
+		TextColor:      theme.TextMuted,
+		AxisColor:      theme.Tinted(0.4),
+		GridColor:      theme.Tinted(0.1),
+		CrosshairColor: theme.Tinted(0.5),
+		TooltipColor:   theme.Surface.WithAlpha(0.95),
+		Palette:        DefaultChartPalette,
+		HoveredIndex:   -1,
+		defaultSize:    DefaultChartSize,
+		labelEvery:     1,
+	}
+}
+
+// NewSparkline returns a small line chart of values with no axes, grid,
+// legend or tooltip, to sit inline with text.
+func NewSparkline(values ...float32) *Chart {
+	c := NewChart(ChartTypeLine, ChartSeries{Values: values})
+	c.ShowAxes, c.ShowGrid, c.ShowLegend, c.ShowTooltip = false, false, false, false
+	c.Thickness = 1.5
+	c.defaultSize = DefaultSparklineSize
+	return c
+}
+
+// SeriesColor returns the color series i is drawn in.
+func (c *Chart) SeriesColor(i int) color.RGBA {
+	if col := c.Series[i].Color; col != (color.RGBA{}) {
+		return col
+	}
+	if len(c.Palette) == 0 {
+		return color.White
+	}
+	return c.Palette[i%len(c.Palette)]
+}
+
+// Count is the number of indices: the length of the longest series, or of
+// Labels if longer.
+func (c *Chart) Count() int {
+	n := len(c.Labels)
+	for _, s := range c.Series {
+		n = max(n, len(s.Values))
+	}
+	return n
+}
+
+// PlotArea returns the position, relative to the content box, and the size
+// of the area the series are drawn in.
+func (c *Chart) PlotArea() (math.Vec2f32, math.Vec2f32) { return c.plotPos, c.plotSize }
+
+// Scale returns the bottom and top of the value axis and the distance
+// between its ticks.
+func (c *Chart) Scale() (lo, hi, step float32) { return c.lo, c.hi, c.step }
+
+// LabelEvery is how many indices apart the bottom labels are drawn, so
+// that they do not overlap.
+func (c *Chart) LabelEvery() int { return c.labelEvery }
+
+// updateScale fits the value axis to the data, or to Min and Max, on round
+// numbers. Bars and areas always include zero.
+func (c *Chart) updateScale() {
+	lo, hi := c.Min, c.Max
+	if c.AutoScale {
+		lo, hi = float32(gomath.Inf(1)), float32(gomath.Inf(-1))
+		for _, s := range c.Series {
+			for _, v := range s.Values {
+				// NaN and infinite values have no place on the axis.
+				if finite(v) {
+					lo, hi = min(lo, v), max(hi, v)
+				}
+			}
+		}
+		if lo > hi {
+			lo, hi = 0, 1
+		}
+		if c.Type != ChartTypeLine {
+			lo, hi = min(lo, 0), max(hi, 0)
+		}
+		c.lo, c.hi, c.step = NiceScale(lo, hi, c.Ticks)
+		return
+	}
+	c.lo, c.hi = lo, hi
+	_, _, c.step = NiceScale(lo, hi, c.Ticks)
+}
+
+// ValueTicks returns the values of the ticks on the value axis.
+func (c *Chart) ValueTicks() []float32 {
+	if !finite(c.lo) || !finite(c.hi) || !finite(c.step) || c.step <= 0 {
+		return nil
+	}
+	lo, hi, step := float64(c.lo), float64(c.hi), float64(c.step)
+	var ticks []float32
+	// Counting steps avoids the drift of adding them up. The count is kept
+	// in float64, where adding one still changes it for far-off ranges.
+	for k := gomath.Ceil(lo/step - 1e-4); ; k++ {
+		v := k * step
+		if v > hi+step*1e-4 {
+			break
+		}
+		ticks = append(ticks, float32(v))
+	}
+	return ticks
+}
+
+// TickLabel formats a value tick with as many decimals as the tick step
+// needs.
+func (c *Chart) TickLabel(v float32) string {
+	decimals := 0
+	if c.step > 0 && c.step < 1 {
+		decimals = int(gomath.Ceil(-gomath.Log10(float64(c.step)) - 1e-6))
+	}
+	return strconv.FormatFloat(float64(v), 'f', decimals, 32)
+}
+
+// IndexLabel returns the label of index i, or its number.
+func (c *Chart) IndexLabel(i int) string {
+	if i < len(c.Labels) {
+		return c.Labels[i]
+	}
+	return strconv.Itoa(i + 1)
+}
+
+// ValueY returns the y of value v, relative to the content box.
+func (c *Chart) ValueY(v float32) float32 {
+	if c.hi == c.lo {
+		return c.plotPos.Y + c.plotSize.Y
+	}
+	return c.plotPos.Y + c.plotSize.Y*(1-(v-c.lo)/(c.hi-c.lo))
+}
+
+// IndexX returns the x of index i, relative to the content box: evenly
+// spread points from edge to edge for lines and areas, and the middle of
+// each category's slot for bars.
+func (c *Chart) IndexX(i int) float32 {
+	n := c.Count()
+	switch {
+	case c.Type == ChartTypeBar:
+		return c.plotPos.X + (float32(i)+0.5)*c.plotSize.X/float32(max(1, n))
+	case n <= 1:
+		return c.plotPos.X + c.plotSize.X/2
+	}
+	return c.plotPos.X + float32(i)*c.plotSize.X/float32(n-1)
+}
+
+// IndexAt returns the index nearest x (relative to the content box), or -1
+// if there is no data.
+func (c *Chart) IndexAt(x float32) int {
+	n := c.Count()
+	if n == 0 || c.plotSize.X <= 0 {
+		return -1
+	}
+	var i int
+	switch {
+	case c.Type == ChartTypeBar:
+		i = int((x - c.plotPos.X) / (c.plotSize.X / float32(n)))
+	case n > 1:
+		i = int(gomath.Round(float64((x - c.plotPos.X) / (c.plotSize.X / float32(n-1)))))
+	}
+	return max(0, min(i, n-1))
+}
+
+// BarRect returns the position, relative to the content box, and the size
+// of the bar of series s at index i.
+func (c *Chart) BarRect(s, i int) (math.Vec2f32, math.Vec2f32) {
+	slot := c.plotSize.X / float32(max(1, c.Count()))
+	group := slot * chartBarGroup
+	width := group / float32(max(1, len(c.Series)))
+	x := c.IndexX(i) - group/2 + float32(s)*width
+	base := c.ValueY(max(c.lo, min(0, c.hi)))
+	top := c.ValueY(max(c.lo, min(c.Series[s].Values[i], c.hi)))
+	return math.Vec2f32{X: x, Y: min(base, top)}, math.Vec2f32{X: width, Y: max(base, top) - min(base, top)}
+}
+
+// IsOverPlot reports whether point (relative to the content box) is over the
+// plot area.
+func (c *Chart) IsOverPlot(point math.Vec2f32) bool {
+	return math.Rect{Pos: c.plotPos, Size: c.plotSize}.Contains(point)
+}
+
+// NiceScale widens the range lo..hi to round numbers and picks a round step
+// that gives about ticks ticks, so that axis labels read like 0, 20, 40
+// rather than 3.7, 21.2, 38.7. A range with a NaN or infinite end, or one
+// too wide for float32, is returned as it is with a step of 0: no ticks.
+func NiceScale(lo, hi float32, ticks int) (niceLo, niceHi, step float32) {
+	if !finite(lo) || !finite(hi) {
+		return lo, hi, 0
+	}
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	if hi == lo {
+		// A flat series still gets an axis around its value.
+		pad := float32(gomath.Abs(float64(lo))) / 2
+		if pad == 0 {
+			pad = 1
+		}
+		lo, hi = lo-pad, hi+pad
+	}
+	ticks = max(2, ticks)
+	span := niceNumber(float64(hi)-float64(lo), false)
+	s := niceNumber(span/float64(ticks-1), true)
+	niceLo, niceHi, step = float32(gomath.Floor(float64(lo)/s)*s), float32(gomath.Ceil(float64(hi)/s)*s), float32(s)
+	if !finite(niceLo) || !finite(niceHi) || !finite(step) || step == 0 {
+		return lo, hi, 0
+	}
+	return niceLo, niceHi, step
+}
+
+func finite(v float32) bool {
+	return !gomath.IsNaN(float64(v)) && !gomath.IsInf(float64(v), 0)
+}
+
+// niceNumber returns a number of the form 1, 2 or 5 times a power of ten
+// close to x: the nearest when rounding, else the next at or above x.
+func niceNumber(x float64, round bool) float64 {
+	exp := gomath.Floor(gomath.Log10(x))
+	fraction := x / gomath.Pow(10, exp)
+	var nice float64
+	switch {
+	case round && fraction < 1.5, !round && fraction <= 1:
+		nice = 1
+	case round && fraction < 3, !round && fraction <= 2:
+		nice = 2
+	case round && fraction < 7, !round && fraction <= 5:
+		nice = 5
+	default:
+		nice = 10
+	}
+	return nice * gomath.Pow(10, exp)
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (c *Chart) SetID(id string) *Chart {
+	c.Component.setID(id)
+	return c
+}
+
+func (c *Chart) SetType(chartType ChartType) *Chart {
+	c.Type = chartType
+	return c
+}
+
+func (c *Chart) SetSeries(series ...ChartSeries) *Chart {
+	c.Series = series
+	c.ShowLegend = len(series) > 1
+	return c
+}
+
+func (c *Chart) SetLabels(labels ...string) *Chart {
+	c.Labels = labels
+	return c
+}
+
+// SetRange fixes the value axis to min..max instead of fitting the data.
+func (c *Chart) SetRange(min, max float32) *Chart {
+	c.Min, c.Max = min, max
+	c.AutoScale = false
+	return c
+}
+
+func (c *Chart) SetTicks(ticks int) *Chart {
+	c.Ticks = ticks
+	return c
+}
+
+func (c *Chart) SetShowAxes(show bool) *Chart {
+	c.ShowAxes = show
+	return c
+}
+
+func (c *Chart) SetShowGrid(show bool) *Chart {
+	c.ShowGrid = show
+	return c
+}
+
+func (c *Chart) SetShowLegend(show bool) *Chart {
+	c.ShowLegend = show
+	return c
+}
+
+func (c *Chart) SetShowTooltip(show bool) *Chart {
+	c.ShowTooltip = show
+	return c
+}
+
+func (c *Chart) SetThickness(thickness float32) *Chart {
+	c.Thickness = thickness
+	return c
+}
+
+func (c *Chart) SetFontSize(size float32) *Chart {
+	if size > 0 {
+		c.FontSize = size
+	}
+	return c
+}
+
+func (c *Chart) SetTextColor(color color.RGBA) *Chart {
+	c.TextColor = color
+	return c
+}
+
+func (c *Chart) SetGridColor(color color.RGBA) *Chart {
+	c.GridColor = color
+	return c
+}
+
+func (c *Chart) SetPalette(palette ...color.RGBA) *Chart {
+	c.Palette = palette
+	return c
+}
+
+func (c *Chart) SetBackgroundColor(color color.RGBA) *Chart {
+	c.Component.setBackgroundColor(color)
+	return c
+}
+
+func (c *Chart) SetSize(size math.Vec2f32) *Chart {
+	c.Component.setSize(size)
+	return c
+}
+
+func (c *Chart) SetWidthPercent(widthPercent float32) *Chart {
+	c.Component.setWidthPercent(widthPercent)
+	return c
+}
+
+func (c *Chart) SetDisplay(d Display) *Chart {
+	c.Component.setDisplay(d)
+	return c
+}
+
+func (c *Chart) SetPosition(pos Position) *Chart {
+	c.Component.setPos(pos)
+	return c
+}
+
+func (c *Chart) SetMargin(margin math.Vec2f32) *Chart {
+	c.Component.setMargin(margin)
+	return c
+}
+
+func (c *Chart) SetPadding(padding math.Vec2f32) *Chart {
+	c.Component.setPadding(padding)
+	return c
+}
+
+func (c *Chart) SetBorder(border math.Vec2f32) *Chart {
+	c.Component.setBorder(border)
+	return c
+}
+
+func (c *Chart) SetBorderColor(color color.RGBA) *Chart {
+	c.Component.setBorderColor(color)
+	return c
+}
+
+func (c *Chart) SetBorderRadius(radius float32) *Chart {
+	c.Component.setBorderRadius(radius)
+	return c
+}
+
+func (c *Chart) SetZIndex(zIndex int) *Chart {
+	c.Component.setZIndex(zIndex)
+	return c
+}
+
+func (c *Chart) SetTooltip(tooltip *Tooltip) *Chart {
+	c.Component.setTooltip(tooltip)
+	return c
+}
+
+func (c *Chart) SetTransform(transform *Transform) *Chart {
+	c.Component.setTransform(transform)
+	return c
+}
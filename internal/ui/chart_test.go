@@ -0,0 +1,84 @@
+package ui
+
+import (
+	gomath "math"
+	"slices"
+	"testing"
+)
+
+func TestNiceScale(t *testing.T) {
+	nan, inf := float32(gomath.NaN()), float32(gomath.Inf(1))
+	tests := []struct {
+		name           string
+		lo, hi         float32
+		ticks          int
+		wantLo, wantHi float32
+		wantStep       float32
+		wantNoTicks    bool
+	}{
+		{name: "round range", lo: 0, hi: 100, ticks: 5, wantLo: 0, wantHi: 100, wantStep: 20},
+		{name: "widened", lo: 3.7, hi: 38.7, ticks: 5, wantLo: 0, wantHi: 40, wantStep: 10},
+		{name: "reversed", lo: 100, hi: 0, ticks: 5, wantLo: 0, wantHi: 100, wantStep: 20},
+		{name: "flat", lo: 10, hi: 10, ticks: 5, wantLo: 4, wantHi: 16, wantStep: 2},
+		{name: "flat at zero", lo: 0, hi: 0, ticks: 5, wantLo: -1, wantHi: 1, wantStep: 0.5},
+		{name: "nan low", lo: nan, hi: 1, ticks: 5, wantNoTicks: true},
+		{name: "nan high", lo: 0, hi: nan, ticks: 5, wantNoTicks: true},
+		{name: "infinite", lo: -inf, hi: inf, ticks: 5, wantNoTicks: true},
+		{name: "too wide for float32", lo: -3e38, hi: 3e38, ticks: 5, wantNoTicks: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, step := NiceScale(tt.lo, tt.hi, tt.ticks)
+			if tt.wantNoTicks {
+				if step != 0 {
+					t.Errorf("NiceScale(%v, %v) step = %v, want 0", tt.lo, tt.hi, step)
+				}
+				return
+			}
+			if lo != tt.wantLo || hi != tt.wantHi || step != tt.wantStep {
+				t.Errorf("NiceScale(%v, %v) = %v, %v, %v, want %v, %v, %v",
+					tt.lo, tt.hi, lo, hi, step, tt.wantLo, tt.wantHi, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestChartScaleSkipsNonFiniteValues(t *testing.T) {
+	nan, inf := float32(gomath.NaN()), float32(gomath.Inf(1))
+	tests := []struct {
+		name   string
+		values []float32
+		want   []float32
+	}{
+		{"nan", []float32{0, nan, 100}, []float32{0, 20, 40, 60, 80, 100}},
+		{"infinities", []float32{-inf, 0, 100, inf}, []float32{0, 20, 40, 60, 80, 100}},
+		{"only nan", []float32{nan, nan}, []float32{0, 0.2, 0.4, 0.6, 0.8, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChart(ChartTypeLine, ChartSeries{Values: tt.values})
+			c.updateScale()
+			if got := c.ValueTicks(); !slices.Equal(got, tt.want) {
+				t.Errorf("ValueTicks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChartValueTicksOnBadRanges(t *testing.T) {
+	nan := float32(gomath.NaN())
+	for _, r := range [][2]float32{{nan, 1}, {0, nan}, {float32(gomath.Inf(-1)), 0}} {
+		c := NewChart(ChartTypeLine).SetRange(r[0], r[1])
+		c.updateScale()
+		if got := c.ValueTicks(); got != nil {
+			t.Errorf("ValueTicks() for range %v = %v, want none", r, got)
+		}
+	}
+
+	// Far from zero, a float32 tick counter would stop counting.
+	c := NewChart(ChartTypeLine).SetRange(1e9, 1e9+10)
+	c.updateScale()
+	if got := len(c.ValueTicks()); got == 0 || got > 20 {
+		t.Errorf("ValueTicks() for a far range has %d ticks", got)
+	}
+}
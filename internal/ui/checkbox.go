@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Checkbox Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// ToggleLabelGap is the space between the indicator of a checkbox, radio
+// option or switch and its label.
+const ToggleLabelGap float32 = 8
+
+type CheckState int
+
+const (
+	CheckStateUnchecked CheckState = iota
+	CheckStateChecked
+	CheckStateIndeterminate
+)
+
+// Checkbox is a labelled boolean input. Its state is kept by the layout
+// engine between frames; call SetChecked or SetState every frame to control
+// it from application state instead.
+type Checkbox struct {
+	Component
+	Label         string
+	State         CheckState
+	Disabled      bool
+	FontSize      float32
+	TextColor     color.RGBA
+	AccentColor   color.RGBA
+	HoverColor    color.RGBA
+	DisabledColor color.RGBA
+	FocusColor    color.RGBA
+	OnChange      func(self *Checkbox, checked bool)
+	IsMouseOver   bool
+	IsPressed     bool
+	IsFocused     bool
+	controlled    bool
+}
+
+func NewCheckbox(label string) *Checkbox {
+	theme := CurrentTheme()
+	c := &Checkbox{
+		Component:     newComponentBase(CheckboxKind),
+		Label:         label,
+		FontSize:      theme.FontSizeBody,
+		TextColor:     theme.Text,
+		AccentColor:   theme.Primary,
+		HoverColor:    theme.PrimaryHover,
+		DisabledColor: theme.TextDisabled,
+		FocusColor:    theme.Focus,
+	}
+	// The border colour outlines the box; the component itself has no border.
+	c.Component.setBorderColor(theme.Outline)
+	return c
+}
+
+// Checked reports whether the box is checked. Indeterminate is not checked.
+func (c *Checkbox) Checked() bool { return c.State == CheckStateChecked }
+
+// Toggle checks an unchecked or indeterminate box and unchecks a checked
+// one, then calls OnChange. It does nothing while disabled.
+func (c *Checkbox) Toggle() {
+	if c.Disabled {
+		return
+	}
+	if c.State == CheckStateChecked {
+		c.State = CheckStateUnchecked
+	} else {
+		c.State = CheckStateChecked
+	}
+	if c.OnChange != nil {
+		c.OnChange(c, c.Checked())
+	}
+}
+
+// IndicatorSize is the side of the square box.
+func (c *Checkbox) IndicatorSize() float32 { return c.FontSize + 2 }
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (c *Checkbox) SetID(id string) *Checkbox {
+	c.Component.setID(id)
+	return c
+}
+
+func (c *Checkbox) SetLabel(label string) *Checkbox {
+	c.Label = label
+	return c
+}
+
+func (c *Checkbox) SetChecked(checked bool) *Checkbox {
+	if checked {
+		return c.SetState(CheckStateChecked)
+	}
+	return c.SetState(CheckStateUnchecked)
+}
+
+// SetState makes the checkbox controlled: the given state wins over the
+// state kept from the previous frame.
+func (c *Checkbox) SetState(state CheckState) *Checkbox {
+	c.State = state
+	c.controlled = true
+	return c
+}
+
+func (c *Checkbox) SetIndeterminate() *Checkbox {
+	return c.SetState(CheckStateIndeterminate)
+}
+
+func (c *Checkbox) SetDisabled(disabled bool) *Checkbox {
+	c.Disabled = disabled
+	return c
+}
+
+func (c *Checkbox) SetOnChange(callback func(self *Checkbox, checked bool)) *Checkbox {
+	c.OnChange = callback
+	return c
+}
+
+func (c *Checkbox) SetFontSize(size float32) *Checkbox {
+	if size > 0 {
+		c.FontSize = size
+	}
+	return c
+}
+
+func (c *Checkbox) SetTextColor(color color.RGBA) *Checkbox {
+	c.TextColor = color
+	return c
+}
+
+func (c *Checkbox) SetAccentColor(color color.RGBA) *Checkbox {
+	c.AccentColor = color
+	return c
+}
+
+func (c *Checkbox) SetHoverColor(color color.RGBA) *Checkbox {
+	c.HoverColor = color
+	return c
+}
+
+func (c *Checkbox) SetDisplay(d Display) *Checkbox {
+	c.Component.setDisplay(d)
+	return c
+}
+
+func (c *Checkbox) SetPosition(pos Position) *Checkbox {
+	c.Component.setPos(pos)
+	return c
+}
+
+func (c *Checkbox) SetMargin(margin math.Vec2f32) *Checkbox {
+	c.Component.setMargin(margin)
+	return c
+}
+
+func (c *Checkbox) SetPadding(padding math.Vec2f32) *Checkbox {
+	c.Component.setPadding(padding)
+	return c
+}
+
+func (c *Checkbox) SetZIndex(zIndex int) *Checkbox {
+	c.Component.setZIndex(zIndex)
+	return c
+}
+
+func (c *Checkbox) SetTooltip(tooltip *Tooltip) *Checkbox {
+	c.Component.setTooltip(tooltip)
+	return c
+}
+
+func (c *Checkbox) SetTransform(transform *Transform) *Checkbox {
+	c.Component.setTransform(transform)
+	return c
+}
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"slices"
+
 	"github.com/aj-2000/mogi/color"
 	"github.com/aj-2000/mogi/math"
 )
@@ -30,6 +32,26 @@ type Component struct {
 	flexItemProps   FlexItemProps
 	zIndex          int
 	sizePercent     math.Vec2f32
+	direction       Direction
+	tooltip         *Tooltip
+	opacity         float32
+	transitions     []*Transition
+	shadows         []BoxShadow
+	gradient        *color.Gradient
+	transform       *Transform
+}
+
+// BoxShadow is a shadow cast by a component's box, as in CSS box-shadow.
+type BoxShadow struct {
+	Offset math.Vec2f32
+	// Blur is the blur radius: how far the shadow's edge fades out over.
+	Blur float32
+	// Spread grows the shadow before it is blurred, or shrinks it if
+	// negative.
+	Spread float32
+	Color  color.RGBA
+	// Inset casts the shadow inside the box instead of behind it.
+	Inset bool
 }
 
 func newComponentBase(kind ComponentKind) Component {
@@ -46,6 +68,7 @@ func newComponentBase(kind ComponentKind) Component {
 		borderRadius:    0,
 		borderColor:     color.Black,
 		backgroundColor: color.Transparent,
+		opacity:         1,
 	}
 }
 
@@ -104,10 +127,11 @@ func (c *Component) AbsoluteZIndex() int {
 	}
 	return c.zIndex
 }
+
+// IsPointInsideComponent reports whether a point on screen falls on the
+// component, as drawn with its transforms.
 func (c *Component) IsPointInsideComponent(point math.Vec2f32) bool {
-	absPos := c.AbsolutePos()
-	return point.X >= absPos.X && point.X <= absPos.X+c.size.X &&
-		point.Y >= absPos.Y && point.Y <= absPos.Y+c.size.Y
+	return math.Rect{Pos: c.AbsolutePos(), Size: c.size}.Contains(c.LocalPoint(point))
 }
 func (c *Component) SetParent(p IComponent) {
 	c.parent = p
@@ -118,6 +142,39 @@ func (c *Component) Parent() IComponent {
 }
 func (c *Component) Display() Display { return c.display }
 
+// Opacity returns how opaque the component is drawn, from 0 to 1.
+func (c *Component) Opacity() float32 { return c.opacity }
+
+// AbsoluteOpacity returns the component's opacity multiplied by that of
+// every ancestor, so fading a container fades what is inside it.
+func (c *Component) AbsoluteOpacity() float32 {
+	if c.parent != nil {
+		return c.opacity * c.parent.AbsoluteOpacity()
+	}
+	return c.opacity
+}
+
+// Shadows returns the shadows the component's box casts, drawn in order.
+func (c *Component) Shadows() []BoxShadow { return c.shadows }
+
+// Gradient returns the gradient the component's background is filled with,
+// instead of its background color, or nil.
+func (c *Component) Gradient() *color.Gradient { return c.gradient }
+
+// Transitions returns the transitions that animate changes to the
+// component's properties.
+func (c *Component) Transitions() []*Transition { return c.transitions }
+
+// Direction returns the writing direction set on this component or the
+// nearest ancestor, or DirectionAuto if none is set.
+func (c *Component) Direction() Direction {
+	if c.direction != DirectionAuto || c.parent == nil {
+		return c.direction
+	}
+	return c.parent.Direction()
+}
+func (c *Component) IsRTL() bool { return c.Direction() == DirectionRTL }
+
 // ——————————————————————————————————————————————————————————————————————————————
 // Internal Setters (used by layout engine)
 // ——————————————————————————————————————————————————————————————————————————————
@@ -153,6 +210,30 @@ func (c *Component) setBackgroundColor(color color.Color) {
 func (c *Component) setZIndex(zIndex int) {
 	c.zIndex = zIndex
 }
+
+// Tooltip returns the tooltip shown when the component is hovered, or nil.
+func (c *Component) Tooltip() *Tooltip { return c.tooltip }
+
+func (c *Component) setTooltip(tooltip *Tooltip) { c.tooltip = tooltip }
+
+func (c *Component) setOpacity(opacity float32) { c.opacity = max(0, min(opacity, 1)) }
+
+func (c *Component) setShadows(shadows ...BoxShadow) { c.shadows = shadows }
+
+func (c *Component) setGradient(gradient *color.Gradient) { c.gradient = gradient }
+
+// setTransitions adds transitions, replacing any already set for the same
+// property.
+func (c *Component) setTransitions(transitions ...*Transition) {
+	for _, t := range transitions {
+		c.transitions = slices.DeleteFunc(c.transitions, func(old *Transition) bool { return old.Property == t.Property })
+		c.transitions = append(c.transitions, t)
+	}
+}
+
+func (c *Component) setDirection(direction Direction) {
+	c.direction = direction
+}
 func (c *Component) setWidthPercent(widthPercent float32) {
 	if widthPercent < 0 {
 		widthPercent = 0
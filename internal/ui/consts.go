@@ -54,6 +54,26 @@ const (
 	ButtonKind
 	ImageKind
 	TableKind
+	IconKind
+	RichTextKind
+	MarkdownKind
+	CheckboxKind
+	RadioGroupKind
+	SwitchKind
+	SliderKind
+	RangeSliderKind
+	SelectKind
+	ComboBoxKind
+	ModalKind
+	TabsKind
+	VirtualListKind
+	VirtualGridKind
+	TreeViewKind
+	ChartKind
+	CanvasKind
+	ProgressBarKind
+	SpinnerKind
+	SkeletonKind
 )
 
 func (k ComponentKind) String() string {
@@ -68,11 +88,136 @@ func (k ComponentKind) String() string {
 		return "Image"
 	case TableKind:
 		return "Table"
+	case IconKind:
+		return "Icon"
+	case RichTextKind:
+		return "RichText"
+	case MarkdownKind:
+		return "Markdown"
+	case CheckboxKind:
+		return "Checkbox"
+	case RadioGroupKind:
+		return "RadioGroup"
+	case SwitchKind:
+		return "Switch"
+	case SliderKind:
+		return "Slider"
+	case RangeSliderKind:
+		return "RangeSlider"
+	case SelectKind:
+		return "Select"
+	case ComboBoxKind:
+		return "ComboBox"
+	case ModalKind:
+		return "Modal"
+	case TabsKind:
+		return "Tabs"
+	case VirtualListKind:
+		return "VirtualList"
+	case VirtualGridKind:
+		return "VirtualGrid"
+	case TreeViewKind:
+		return "TreeView"
+	case ChartKind:
+		return "Chart"
+	case CanvasKind:
+		return "Canvas"
+	case ProgressBarKind:
+		return "ProgressBar"
+	case SpinnerKind:
+		return "Spinner"
+	case SkeletonKind:
+		return "Skeleton"
 	default:
 		return "Unknown"
 	}
 }
 
+//
+// ——————————————————————————————————————————————————————————————————————————————
+// Text
+// ——————————————————————————————————————————————————————————————————————————————
+//
+
+type TextAlign int
+
+const (
+	TextAlignLeft TextAlign = iota
+	TextAlignCenter
+	TextAlignRight
+	TextAlignJustify
+	// TextAlignStart and TextAlignEnd follow the writing direction:
+	// start is the left edge for LTR text and the right edge for RTL text.
+	TextAlignStart
+	TextAlignEnd
+)
+
+// Resolve maps start/end alignment to left/right for the given direction.
+func (a TextAlign) Resolve(rtl bool) TextAlign {
+	switch {
+	case a == TextAlignStart && rtl, a == TextAlignEnd && !rtl:
+		return TextAlignRight
+	case a == TextAlignStart, a == TextAlignEnd:
+		return TextAlignLeft
+	}
+	return a
+}
+
+type TextVerticalAlign int
+
+const (
+	TextVerticalAlignTop TextVerticalAlign = iota
+	TextVerticalAlignMiddle
+	TextVerticalAlignBottom
+)
+
+type FontWeight int
+
+const (
+	FontWeightRegular FontWeight = iota
+	FontWeightBold
+)
+
+// Font selects a registered font face. The zero value is the default font.
+type Font struct {
+	Family string
+	Weight FontWeight
+	Italic bool
+}
+
+type TextOverflow int
+
+const (
+	TextOverflowClip TextOverflow = iota
+	TextOverflowEllipsis
+)
+
+// Overflow is what a container does with children that reach outside it.
+type Overflow int
+
+const (
+	OverflowVisible Overflow = iota
+	// OverflowHidden clips children to the inside of the border, following
+	// its rounded corners.
+	OverflowHidden
+)
+
+//
+// ——————————————————————————————————————————————————————————————————————————————
+// Writing direction
+// ——————————————————————————————————————————————————————————————————————————————
+//
+
+type Direction int
+
+const (
+	// DirectionAuto inherits the direction of the parent. Text with no
+	// direction set anywhere above it takes it from its first strong character.
+	DirectionAuto Direction = iota
+	DirectionLTR
+	DirectionRTL
+)
+
 //
 // ——————————————————————————————————————————————————————————————————————————————
 // Flex
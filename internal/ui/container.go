@@ -11,6 +11,7 @@ type Container struct {
 	Component
 	// Flex properties for when THIS component IS a flex container
 	flexContainerProps FlexContainerProps
+	Overflow           Overflow
 }
 
 // --- Container Constructor ---
@@ -85,6 +86,42 @@ func (c *Container) SetZIndex(zIndex int) *Container {
 	return c
 }
 
+// SetTooltip shows tooltip when the container is hovered; nil removes it.
+func (c *Container) SetTooltip(tooltip *Tooltip) *Container {
+	c.Component.setTooltip(tooltip)
+	return c
+}
+
+func (c *Container) SetTransform(transform *Transform) *Container {
+	c.Component.setTransform(transform)
+	return c
+}
+
+func (c *Container) SetOpacity(opacity float32) *Container {
+	c.Component.setOpacity(opacity)
+	return c
+}
+
+// SetShadow sets the shadows the container casts, replacing any set before.
+func (c *Container) SetShadow(shadows ...BoxShadow) *Container {
+	c.Component.setShadows(shadows...)
+	return c
+}
+
+// SetGradient fills the background with gradient instead of the background
+// color; nil goes back to the color.
+func (c *Container) SetGradient(gradient *color.Gradient) *Container {
+	c.Component.setGradient(gradient)
+	return c
+}
+
+// SetTransition animates changes to the given properties, replacing the
+// transitions already set for them.
+func (c *Container) SetTransition(transitions ...*Transition) *Container {
+	c.Component.setTransitions(transitions...)
+	return c
+}
+
 func (c *Container) SetWidthPercent(widthPercent float32) *Container {
 	c.Component.setWidthPercent(widthPercent)
 	return c
@@ -143,6 +180,19 @@ func (c *Container) SetDisplay(d Display) *Container {
 	return c
 }
 
+func (c *Container) SetOverflow(overflow Overflow) *Container {
+	c.Overflow = overflow
+	return c
+}
+
+// SetDirection sets the writing direction of the container and everything
+// inside it that does not set its own. RTL containers flow children from
+// the right edge.
+func (c *Container) SetDirection(direction Direction) *Container {
+	c.Component.setDirection(direction)
+	return c
+}
+
 // ——————————————————————————————————————————————————————————————————————————————
 // AddChild and AddChildren methods for adding child components
 // ——————————————————————————————————————————————————————————————————————————————
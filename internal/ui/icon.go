@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Icon
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Icon draws a named entry from a loaded sprite atlas (see App.LoadAtlas).
+type Icon struct {
+	Component
+	Name  string
+	Color color.RGBA
+}
+
+func NewIcon(name string) *Icon {
+	theme := CurrentTheme()
+	i := &Icon{
+		Component: newComponentBase(IconKind),
+		Name:      name,
+		Color:     theme.Text,
+	}
+	i.Component.setSize(math.Vec2f32{X: 16, Y: 16})
+	return i
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (i *Icon) SetID(id string) *Icon {
+	i.Component.setID(id)
+	return i
+}
+
+func (i *Icon) SetName(name string) *Icon {
+	i.Name = name
+	return i
+}
+
+// SetColor tints the icon; white draws it unchanged.
+func (i *Icon) SetColor(color color.RGBA) *Icon {
+	i.Color = color
+	return i
+}
+
+func (i *Icon) SetDisplay(d Display) *Icon {
+	i.Component.setDisplay(d)
+	return i
+}
+
+func (i *Icon) SetPosition(pos Position) *Icon {
+	i.Component.setPos(pos)
+	return i
+}
+
+func (i *Icon) SetSize(size math.Vec2f32) *Icon {
+	i.Component.setSize(size)
+	return i
+}
+
+func (i *Icon) SetMargin(margin math.Vec2f32) *Icon {
+	i.Component.setMargin(margin)
+	return i
+}
+
+func (i *Icon) SetZIndex(zIndex int) *Icon {
+	i.Component.setZIndex(zIndex)
+	return i
+}
+
+func (i *Icon) SetTooltip(tooltip *Tooltip) *Icon {
+	i.Component.setTooltip(tooltip)
+	return i
+}
+
+func (i *Icon) SetTransform(transform *Transform) *Icon {
+	i.Component.setTransform(transform)
+	return i
+}
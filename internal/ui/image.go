@@ -9,6 +9,8 @@ import "github.com/aj-2000/mogi/math"
 type Image struct {
 	Component
 	Path string
+	// Sprite names an atlas entry; when set it is drawn instead of Path.
+	Sprite string
 }
 
 func NewImage(path string) *Image {
@@ -19,6 +21,15 @@ func NewImage(path string) *Image {
 	return i
 }
 
+// NewSpriteImage creates an image drawn from a named atlas entry.
+func NewSpriteImage(sprite string) *Image {
+	i := &Image{
+		Component: newComponentBase(ImageKind),
+		Sprite:    sprite,
+	}
+	return i
+}
+
 // ——————————————————————————————————————————————————————————————————————————————
 // Fluent Setters
 // ——————————————————————————————————————————————————————————————————————————————
@@ -28,6 +39,11 @@ func (i *Image) SetID(id string) *Image {
 	return i
 }
 
+func (i *Image) SetSprite(sprite string) *Image {
+	i.Sprite = sprite
+	return i
+}
+
 func (i *Image) SetDisplay(d Display) *Image {
 	i.Component.setDisplay(d)
 	return i
@@ -47,3 +63,18 @@ func (i *Image) SetZIndex(zIndex int) *Image {
 	i.Component.setZIndex(zIndex)
 	return i
 }
+
+func (i *Image) SetTooltip(tooltip *Tooltip) *Image {
+	i.Component.setTooltip(tooltip)
+	return i
+}
+
+func (i *Image) SetTransform(transform *Transform) *Image {
+	i.Component.setTransform(transform)
+	return i
+}
+
+func (i *Image) SetOpacity(opacity float32) *Image {
+	i.Component.setOpacity(opacity)
+	return i
+}
@@ -31,6 +31,16 @@ type IComponent interface {
 	ZIndex() int
 	WidthPercent() float32
 	HeightPercent() float32
+	Direction() Direction
+	Tooltip() *Tooltip
+	Opacity() float32
+	AbsoluteOpacity() float32
+	Shadows() []BoxShadow
+	Gradient() *color.Gradient
+	Transitions() []*Transition
+	Transform() *Transform
+	AbsoluteTransform() math.Mat3
+	LocalPoint(point math.Vec2f32) math.Vec2f32
 
 	// --- Fluent Setters ---
 
@@ -51,6 +61,13 @@ type IComponent interface {
 	setID(id string)
 	setFullID(fullID string)
 	setZIndex(zIndex int)
+	setDirection(direction Direction)
+	setTooltip(tooltip *Tooltip)
+	setOpacity(opacity float32)
+	setShadows(shadows ...BoxShadow)
+	setGradient(gradient *color.Gradient)
+	setTransitions(transitions ...*Transition)
+	setTransform(transform *Transform)
 	setWidthPercent(widthPercent float32)
 	setHeightPercent(heightPercent float32)
 	// Optional: Method to get intrinsic size (needed for flex-basis: auto)
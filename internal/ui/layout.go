@@ -3,25 +3,91 @@ package ui
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
-	"github.com/aj-2000/mogi/color"
 	"github.com/aj-2000/mogi/math"
 )
 
 type LayoutEngine struct {
 	CalculateTextWidth func(text string, fontSize float32) float32
-	alive              map[string]bool
-	count              map[string]int
-	state              map[string]ComponentState
+	// MeasureText and FontAscent are optional font-aware metrics used by
+	// RichText; without them the default font and an estimated ascent are used.
+	MeasureText func(font Font, text string, fontSize float32) float32
+	FontAscent  func(font Font, fontSize float32) float32
+	alive       map[string]bool
+	count       map[string]int
+	state       map[string]ComponentState
+	focused     string // FullID of the component with keyboard focus
+	// tweens holds the running transitions and animations, and generation
+	// counts the frames they are advanced in.
+	tweens     map[tweenKey]*tween
+	generation int
 }
 
 type ComponentState struct {
 	IsMouseOver bool
 	IsPressed   bool
 	Display     Display
+	// HoveredItem and PressedItem track sub-items (e.g. RichText spans)
+	// as index+1, so that the zero value means none.
+	HoveredItem int
+	PressedItem int
+	// Value is the value of an input component (checkbox state, switch
+	// position, selected radio option + 1).
+	Value int
+	// Range holds the values of continuous inputs (slider value, range
+	// slider low and high) and the first row of a select's popup.
+	Range [2]float32
+	// Open is set while a component's popup is shown.
+	Open bool
+	// Text and Time hold typed input and when it was last typed (select
+	// search text and type-ahead).
+	Text string
+	Time float64
+	// HoverSince is when the pointer entered the component, for hover
+	// delays such as tooltips. It is kept across state copies.
+	HoverSince float64
+	hovering   bool
+	// tabs holds the active tab, order, closed tabs and scroll of a Tabs.
+	tabs *tabsState
+	// virtual holds the scroll position and measured items of a
+	// VirtualList or VirtualGrid.
+	virtual *virtualState
+	// tree holds the expanded, selected and loaded nodes of a TreeView.
+	tree *treeState
+	// table holds the sort, selection, column widths and scroll of a Table.
+	table *tableState
+	// animation holds the phase and shown value of an Animated component.
+	animation *animationState
+	// saved is false for components that have not finished a frame yet, so
+	// their initial display and value are not overwritten by zero state.
+	saved bool
 }
 
+// TrackHover records whether the component with fullID is hovered at time
+// now (in seconds) and returns for how long it has been hovered without
+// interruption, or 0 if it is not hovered.
+func (le *LayoutEngine) TrackHover(fullID string, hovered bool, now float64) float64 {
+	state := le.state[fullID]
+	if !hovered {
+		state.hovering = false
+		le.state[fullID] = state
+		return 0
+	}
+	if !state.hovering {
+		state.hovering = true
+		state.HoverSince = now
+	}
+	le.state[fullID] = state
+	return now - state.HoverSince
+}
+
+// FocusedID returns the FullID of the component with keyboard focus.
+func (le *LayoutEngine) FocusedID() string { return le.focused }
+
+// SetFocusedID moves keyboard focus to the component with the given FullID.
+// An empty id clears focus.
+func (le *LayoutEngine) SetFocusedID(id string) { le.focused = id }
+
 func (le *LayoutEngine) BeginLayout() {
 	le.alive = make(map[string]bool, len(le.state))
 	le.count = make(map[string]int)
@@ -39,6 +105,7 @@ func (le *LayoutEngine) EndLayout() {
 	// 	}
 	// }
 
+	le.endTransitions()
 }
 
 func (le *LayoutEngine) CopyStateToComponentsRecursive(comp IComponent) {
@@ -54,6 +121,14 @@ func (le *LayoutEngine) CopyStateToComponentsRecursive(comp IComponent) {
 		le.state[fullID] = state
 	}
 
+	if !state.saved {
+		// First frame of this component: keep what the builder set.
+		for _, child := range comp.Children() {
+			le.CopyStateToComponentsRecursive(child)
+		}
+		return
+	}
+
 	comp.setDisplay(state.Display)
 
 	switch c := comp.(type) {
@@ -64,6 +139,79 @@ func (le *LayoutEngine) CopyStateToComponentsRecursive(comp IComponent) {
 	case *Button:
 		c.IsMouseOver = state.IsMouseOver
 		c.IsPressed = state.IsPressed
+	case *RichText:
+		c.HoveredSpan = state.HoveredItem - 1
+		c.PressedSpan = state.PressedItem - 1
+	case *Checkbox:
+		c.IsMouseOver = state.IsMouseOver
+		c.IsPressed = state.IsPressed
+		c.IsFocused = le.focused == fullID
+		if !c.controlled {
+			c.State = CheckState(state.Value)
+		}
+	case *Switch:
+		c.IsMouseOver = state.IsMouseOver
+		c.IsPressed = state.IsPressed
+		c.IsFocused = le.focused == fullID
+		if !c.controlled {
+			c.On = state.Value == 1
+		}
+	case *RadioGroup:
+		c.HoveredOption = state.HoveredItem - 1
+		c.PressedOption = state.PressedItem - 1
+		c.IsFocused = le.focused == fullID
+		if !c.controlled {
+			c.Selected = state.Value - 1
+		}
+	case *Slider:
+		c.IsMouseOver = state.IsMouseOver
+		c.IsDragging = state.IsPressed
+		c.IsFocused = le.focused == fullID
+		if !c.controlled {
+			c.Value = c.Snap(state.Range[0])
+		}
+	case *RangeSlider:
+		c.HoveredThumb = state.HoveredItem - 1
+		c.DraggedThumb = state.PressedItem - 1
+		c.ActiveThumb = state.Value
+		c.IsFocused = le.focused == fullID
+		if !c.controlled {
+			c.Low, c.High = c.Snap(state.Range[0]), c.Snap(state.Range[1])
+		}
+	case *Select:
+		c.IsMouseOver = state.IsMouseOver
+		c.IsPressed = state.IsPressed
+		c.IsFocused = le.focused == fullID
+		c.Open = state.Open && !c.Disabled
+		c.Highlighted = state.HoveredItem - 1
+		c.PressedOption = state.PressedItem - 1
+		c.typedAt = state.Time
+		c.ScrollRow = int(state.Range[0])
+		if c.Searchable {
+			c.Query = state.Text
+		} else {
+			c.typeAhead = state.Text
+		}
+		if !c.controlled {
+			c.Selected = state.Value - 1
+		}
+	case *Tabs:
+		// Restored in AssignIDsRecursive, before the panel was mounted.
+		c.IsFocused = le.focused == fullID
+	case virtualizer:
+		// Restored in AssignIDsRecursive, before the items were mounted.
+	case *TreeView:
+		if state.tree != nil {
+			c.restoreState(state.tree)
+		}
+		c.IsFocused = le.focused == fullID
+	case *Table:
+		// Restored in AssignIDsRecursive, before the cells were mounted.
+		c.IsFocused = le.focused == fullID
+	case Animated:
+		if state.animation != nil {
+			c.restoreAnimation(state.animation)
+		}
 	case *Image:
 		// Image doesn't have mouse state, but we need to sync its children.
 	default:
@@ -86,6 +234,16 @@ func (le *LayoutEngine) CopyStateFromComponentsRecursive(comp IComponent) {
 	}
 
 	var isMouseOver, isPressed bool
+	var hoveredItem, pressedItem, value int
+	var values [2]float32
+	var open bool
+	var text string
+	var typedAt float64
+	var tabs *tabsState
+	var virtual *virtualState
+	var tree *treeState
+	var table *tableState
+	var animation *animationState
 
 	// For now, set to false as a placeholder.
 	isMouseOver = false
@@ -104,6 +262,55 @@ func (le *LayoutEngine) CopyStateFromComponentsRecursive(comp IComponent) {
 		// Button has mouse state, so we can use its methods to get the state.
 		isMouseOver = c.IsMouseOver
 		isPressed = c.IsPressed
+	case *RichText:
+		hoveredItem = c.HoveredSpan + 1
+		pressedItem = c.PressedSpan + 1
+	case *Checkbox:
+		isMouseOver = c.IsMouseOver
+		isPressed = c.IsPressed
+		value = int(c.State)
+	case *Switch:
+		isMouseOver = c.IsMouseOver
+		isPressed = c.IsPressed
+		if c.On {
+			value = 1
+		}
+	case *RadioGroup:
+		hoveredItem = c.HoveredOption + 1
+		pressedItem = c.PressedOption + 1
+		value = c.Selected + 1
+	case *Slider:
+		isMouseOver = c.IsMouseOver
+		isPressed = c.IsDragging
+		values[0] = c.Value
+	case *RangeSlider:
+		hoveredItem = c.HoveredThumb + 1
+		pressedItem = c.DraggedThumb + 1
+		value = c.ActiveThumb
+		values = [2]float32{c.Low, c.High}
+	case *Select:
+		isMouseOver = c.IsMouseOver
+		isPressed = c.IsPressed
+		open = c.Open
+		hoveredItem = c.Highlighted + 1
+		pressedItem = c.PressedOption + 1
+		value = c.Selected + 1
+		values[0] = float32(c.ScrollRow)
+		typedAt = c.typedAt
+		text = c.typeAhead
+		if c.Searchable {
+			text = c.Query
+		}
+	case *Tabs:
+		tabs = c.saveState()
+	case virtualizer:
+		virtual = c.saveVirtual()
+	case *TreeView:
+		tree = c.saveState()
+	case *Table:
+		table = c.saveState()
+	case Animated:
+		animation = c.saveAnimation()
 	case *Image:
 		// Image doesn't have mouse state, but we need to sync its children.
 		// isMouseOver = false // Images don't have mouse state
@@ -114,10 +321,26 @@ func (le *LayoutEngine) CopyStateFromComponentsRecursive(comp IComponent) {
 		// isPressed = false   // Default to false for unsupported types
 	}
 
+	prev := le.state[fullID]
 	le.state[fullID] = ComponentState{
+		HoverSince:  prev.HoverSince,
+		hovering:    prev.hovering,
 		IsMouseOver: isMouseOver,
 		IsPressed:   isPressed,
 		Display:     comp.Display(),
+		HoveredItem: hoveredItem,
+		PressedItem: pressedItem,
+		Value:       value,
+		Range:       values,
+		Open:        open,
+		Text:        text,
+		Time:        typedAt,
+		tabs:        tabs,
+		virtual:     virtual,
+		tree:        tree,
+		table:       table,
+		animation:   animation,
+		saved:       true,
 	}
 
 	for _, child := range comp.Children() {
@@ -132,49 +355,18 @@ func NewLayoutEngine(f func(string, float32) float32) *LayoutEngine {
 		alive:              make(map[string]bool),
 		count:              make(map[string]int),
 		state:              make(map[string]ComponentState),
+		tweens:             make(map[tweenKey]*tween),
+		generation:         1,
 	}
 }
 
 func (le *LayoutEngine) CalculateWrappedTextSize(text string, fontSize float32, maxLineWidth float32) math.Vec2f32 {
-	// available width minus any horizontal padding/border
-
-	words := strings.Fields(text)
-	var currentLine strings.Builder
-	var lines []string
-
-	for _, w := range words {
-		testLine := currentLine.String()
-		if testLine != "" {
-			testLine += " " + w
-		} else {
-			testLine = w
-		}
-
-		wWidth := le.CalculateTextWidth(testLine, fontSize)
-		if wWidth <= maxLineWidth {
-			if currentLine.Len() > 0 {
-				currentLine.WriteString(" ")
-			}
-			currentLine.WriteString(w)
-		} else {
-			// commit current line, start new
-			lines = append(lines, currentLine.String())
-			currentLine.Reset()
-			currentLine.WriteString(w)
-		}
-	}
-	// last line
-	if currentLine.Len() > 0 {
-		lines = append(lines, currentLine.String())
-	}
+	lines := le.BreakText(text, fontSize, maxLineWidth, true)
 
 	// find widest line
 	var widest float32
 	for _, ln := range lines {
-		w := le.CalculateTextWidth(ln, fontSize)
-		if w > widest {
-			widest = w
-		}
+		widest = max(widest, ln.Width)
 	}
 	lineHeight := fontSize
 	totalHeight := float32(len(lines)) * lineHeight
@@ -224,49 +416,22 @@ func (le *LayoutEngine) ConvertDerivedComponentToPrimitivesRecursive(comp ICompo
 		return c
 	case *Image:
 		return c
-	case *Table:
-		// TODO: remove hardcoded values
-		headRow := NewContainer().
-			SetGap(math.Vec2f32{X: 10}).
-			SetID("header").SetDisplay(DisplayBlock)
-		for i, col := range c.Header {
-			// TODO: support so columns can take full height available
-			if i == len(c.Header)-1 {
-				headRow.AddChild(NewContainer().SetSize(math.Vec2f32{X: 400}).SetBackgroundColor(color.Transparent).SetID("cell#" + strconv.Itoa(i)).AddChild(NewText(col).SetTextWrapped(true)))
-
-			} else {
-				headRow.AddChild(NewContainer().SetSize(math.Vec2f32{X: 100}).SetBackgroundColor(color.Transparent).SetID("cell#" + strconv.Itoa(i)).AddChild(NewText(col).SetTextWrapped(true)))
-			}
-		}
-		var rows []IComponent
-		for i, row := range c.Rows {
-			newRow := NewContainer().SetID("row#" + strconv.Itoa(i)).SetDisplay(DisplayBlock).SetGap(math.Vec2f32{X: 10})
-
-			for i, cell := range row.Cells {
-				if i == len(row.Cells)-1 {
-					newRow.AddChild(NewContainer().SetSize(math.Vec2f32{X: 400}).SetBackgroundColor(color.Transparent).SetID("cell#" + strconv.Itoa(i)).AddChild(NewText(cell).SetTextWrapped(true)))
-				} else {
-					newRow.AddChild(NewContainer().SetSize(math.Vec2f32{X: 100}).SetBackgroundColor(color.Transparent).SetID("cell#" + strconv.Itoa(i)).AddChild(NewText(cell).SetTextWrapped(true)))
-				}
-			}
-			rows = append(rows, newRow)
-		}
-		t := NewContainer().SetID(c.ID()).
-			SetDisplay(c.Display()).
-			SetSize(c.Size()).
-			SetPosition(c.Pos()).
-			SetBackgroundColor(color.Gray).
-			SetBorder(c.Border()).
-			// SetPadding(*math.NewVec2f32(3, 4)).
-			SetBorderColor(c.BorderColor()).
-			SetBorderRadius(10).
-			SetZIndex(c.AbsoluteZIndex()).
-			SetGap(math.Vec2f32{Y: 10}).
-			SetPadding(math.Vec2f32{X: 3, Y: 4}).
-			AddChild(headRow)
-		t.AddChildren(rows...)
-		return t
-		// TODO: should we draw table column wise?
+	case *Icon:
+		return c
+	case *RichText:
+		return c
+	case *Checkbox, *RadioGroup, *Switch, *Slider, *RangeSlider, *Select, *TreeView, *Chart, *Canvas, *ProgressBar, *Spinner, *Skeleton:
+		return c
+	case *Tabs, *VirtualList, *VirtualGrid, *Table:
+		// Their children are mounted and converted once IDs are assigned.
+		return c
+	case *Markdown:
+		// The expanded tree may contain derived components (tables) itself.
+		expanded := c.expand()
+		expanded.setTooltip(c.Tooltip())
+		return le.ConvertDerivedComponentToPrimitivesRecursive(expanded)
+	case *Modal:
+		panic("ui: a Modal cannot be placed in the tree; open it with App.OpenModal")
 	default:
 		panic(fmt.Sprintf("Unsupported component type: %T", comp))
 	}
@@ -309,12 +474,96 @@ func (le *LayoutEngine) AssignIDsRecursive(comp IComponent) {
 	newFullID := le.nextFullID(fullID, comp.Kind().String(), comp.ID())
 	comp.setFullID(newFullID)
 
+	switch c := comp.(type) {
+	case *Tabs:
+		le.mountTabPanel(c)
+	case *Table:
+		// Cells are named after their row's key, so their state follows
+		// the row when it is sorted.
+		le.mountTableCells(c)
+		return
+	case virtualizer:
+		// Items are named after their index, not their place among the
+		// mounted children.
+		le.mountVirtualItems(c)
+		return
+	}
+
 	// Recurse into children
 	for _, child := range comp.Children() {
 		le.AssignIDsRecursive(child)
 	}
 }
 
+// mountTabPanel restores a Tabs' state from the previous frame, which
+// decides the active tab, and mounts only that tab's content.
+func (le *LayoutEngine) mountTabPanel(t *Tabs) {
+	if state := le.state[t.FullID()]; state.saved && state.tabs != nil {
+		t.restoreState(state.tabs)
+	}
+	t.ensureActiveVisible()
+	t.mountPanel(le.ConvertDerivedComponentToPrimitivesRecursive(t.buildPanel()))
+}
+
+// mountTableCells restores a Table's state from the previous frame, which
+// decides the sort order and page, and mounts a component for every cell of
+// the page.
+func (le *LayoutEngine) mountTableCells(t *Table) {
+	if state := le.state[t.FullID()]; state.saved && state.table != nil {
+		t.restoreState(state.table)
+	}
+	t.arrangeRows()
+	cells := make([][]IComponent, len(t.order))
+	for r, i := range t.order {
+		cells[r] = make([]IComponent, len(t.Columns))
+		for c := range t.Columns {
+			cell := le.ConvertDerivedComponentToPrimitivesRecursive(t.buildCell(i, c))
+			le.AssignOverlayIDs(cell, t.FullID()+"/row("+t.RowKey(i)+")/cell#"+strconv.Itoa(c))
+			cells[r][c] = cell
+		}
+	}
+	t.mountCells(cells)
+}
+
+// mountVirtualItems restores the scroll position of a virtualized
+// component and mounts only the items in view. Each item's ID comes from its
+// index, so its state follows it as the list scrolls.
+func (le *LayoutEngine) mountVirtualItems(v virtualizer) {
+	if state := le.state[v.FullID()]; state.saved && state.virtual != nil {
+		v.restoreVirtual(state.virtual)
+	}
+	first, last := v.mountRange()
+	items := make([]IComponent, 0, last-first)
+	for i := first; i < last; i++ {
+		item := le.ConvertDerivedComponentToPrimitivesRecursive(v.buildItem(i))
+		le.AssignOverlayIDs(item, v.FullID()+"/item#"+strconv.Itoa(i))
+		items = append(items, item)
+	}
+	v.mount(first, items)
+}
+
+// AssignOverlayIDs assigns IDs to an overlay tree, which has no parent in
+// the main tree, under parentID (usually the FullID of its owner) so that
+// its state does not collide with the main tree.
+func (le *LayoutEngine) AssignOverlayIDs(root IComponent, parentID string) {
+	root.setFullID(le.nextFullID(parentID, root.Kind().String(), root.ID()))
+	for _, child := range root.Children() {
+		le.AssignIDsRecursive(child)
+	}
+}
+
+// BuildOverlay readies an overlay tree for layout the way the app readies
+// the main tree each frame: it converts derived components, assigns IDs
+// under parentID, restores the state of the last frame and advances
+// animations by dt seconds.
+func (le *LayoutEngine) BuildOverlay(root IComponent, parentID string, dt float32) IComponent {
+	root = le.ConvertDerivedComponentToPrimitivesRecursive(root)
+	le.AssignOverlayIDs(root, parentID)
+	le.CopyStateToComponentsRecursive(root)
+	AdvanceAnimations(root, dt)
+	return root
+}
+
 // printComponentTree is a helper for debugging the layout structure.
 func (le *LayoutEngine) printComponentTree(comp IComponent, indent string) {
 	if comp.Display() == DisplayNone {
@@ -468,62 +717,13 @@ func (le *LayoutEngine) calculateSizeRecursive(comp IComponent, availableSize ma
 		}
 
 	case *Text:
-		// TODO: textPadding should come from style/props
-
-		lineHeight := c.FontSize
-
-		if c.Wrapped {
-			// available width minus any horizontal padding/border
-			maxLineWidth := availableSize.X - 2*paddingAndBorderX
-
-			words := strings.Fields(c.Content)
-			var currentLine strings.Builder
-			var lines []string
-
-			for _, w := range words {
-				testLine := currentLine.String()
-				if testLine != "" {
-					testLine += " " + w
-				} else {
-					testLine = w
-				}
-
-				wWidth := le.CalculateTextWidth(testLine, c.FontSize)
-				if wWidth <= maxLineWidth {
-					if currentLine.Len() > 0 {
-						currentLine.WriteString(" ")
-					}
-					currentLine.WriteString(w)
-				} else {
-					// commit current line, start new
-					lines = append(lines, currentLine.String())
-					currentLine.Reset()
-					currentLine.WriteString(w)
-				}
-			}
-			// last line
-			if currentLine.Len() > 0 {
-				lines = append(lines, currentLine.String())
-			}
-
-			// find widest line
-			var widest float32
-			for _, ln := range lines {
-				w := le.CalculateTextWidth(ln, c.FontSize)
-				if w > widest {
-					widest = w
-				}
-			}
-
-			totalHeight := float32(len(lines)) * lineHeight
-			totalWidth := widest
-
-			calculatedContentSize = math.Vec2f32{X: totalWidth, Y: totalHeight}
-		} else {
-			width := le.CalculateTextWidth(c.Content, c.FontSize)
-			height := c.FontSize
-			calculatedContentSize = math.Vec2f32{X: width, Y: height}
+		// available width minus any horizontal padding/border
+		maxLineWidth := availableSize.X - 2*paddingAndBorderX
+		if hasFixedWidth {
+			maxLineWidth = fixedSize.X - 2*paddingAndBorderX
 		}
+		block := le.LayoutTextBlock(c, max(0, maxLineWidth))
+		calculatedContentSize = block.Size
 
 	case *Button:
 		// Assume button includes internal padding within its calculation logic
@@ -541,6 +741,150 @@ func (le *LayoutEngine) calculateSizeRecursive(comp IComponent, availableSize ma
 		intrinsicSize := c.Size()
 		calculatedContentSize = intrinsicSize
 
+	case *Icon:
+		calculatedContentSize = c.Size()
+
+	case *RichText:
+		maxLineWidth := availableSize.X - 2*paddingAndBorderX
+		if hasFixedWidth {
+			maxLineWidth = fixedSize.X - 2*paddingAndBorderX
+		}
+		calculatedContentSize = le.LayoutRichText(c, max(0, maxLineWidth))
+
+	case *Checkbox:
+		calculatedContentSize = le.toggleSize(c.IndicatorSize(), c.IndicatorSize(), c.Label, c.FontSize)
+
+	case *Switch:
+		track := c.TrackSize()
+		calculatedContentSize = le.toggleSize(track.X, track.Y, c.Label, c.FontSize)
+
+	case *RadioGroup:
+		for i, option := range c.Options {
+			row := le.toggleSize(c.IndicatorSize(), c.IndicatorSize(), option, c.FontSize)
+			calculatedContentSize.X = max(calculatedContentSize.X, row.X)
+			calculatedContentSize.Y += row.Y
+			if i > 0 {
+				calculatedContentSize.Y += c.Gap().Y
+			}
+		}
+
+	case *Slider:
+		labelWidth := max(le.CalculateTextWidth(c.Format(c.Min), c.FontSize), le.CalculateTextWidth(c.Format(c.Max), c.FontSize))
+		calculatedContentSize = c.ContentSize(labelWidth)
+
+	case *RangeSlider:
+		labelWidth := le.CalculateTextWidth(c.Format(c.Min)+RangeSliderSeparator+c.Format(c.Max), c.FontSize)
+		calculatedContentSize = c.ContentSize(labelWidth)
+
+	case *Select:
+		// Wide enough for every option and the placeholder, plus the arrow.
+		labelWidth := le.CalculateTextWidth(c.Placeholder, c.FontSize)
+		for _, option := range c.Options {
+			labelWidth = max(labelWidth, le.CalculateTextWidth(option, c.FontSize))
+		}
+		calculatedContentSize = math.Vec2f32{X: labelWidth + SelectArrowWidth(c.FontSize), Y: c.FontSize}
+
+	case *TreeView:
+		c.flatten()
+		for _, row := range c.rows {
+			width := float32(row.Depth+1)*c.Indent + le.CalculateTextWidth(row.Node.Label, c.FontSize)
+			calculatedContentSize.X = max(calculatedContentSize.X, width)
+		}
+		calculatedContentSize.Y = float32(len(c.rows)) * c.RowHeight()
+
+	case *Chart:
+		calculatedContentSize = c.defaultSize
+		if hasFixedWidth {
+			calculatedContentSize.X = max(0, fixedSize.X-2*paddingAndBorderX)
+		}
+		if hasFixedHeight {
+			calculatedContentSize.Y = max(0, fixedSize.Y-2*paddingAndBorderY)
+		}
+		le.layoutChart(c, calculatedContentSize)
+
+	case *Canvas:
+		calculatedContentSize = c.defaultSize
+		if hasFixedWidth {
+			calculatedContentSize.X = max(0, fixedSize.X-2*paddingAndBorderX)
+		}
+		if hasFixedHeight {
+			calculatedContentSize.Y = max(0, fixedSize.Y-2*paddingAndBorderY)
+		}
+
+	case *ProgressBar:
+		if c.Shape == ProgressCircular {
+			calculatedContentSize = math.Vec2f32{X: c.Diameter, Y: c.Diameter}
+			break
+		}
+		calculatedContentSize = math.Vec2f32{X: c.Length, Y: c.Thickness}
+		if c.ShowLabel && !c.Indeterminate {
+			// Room for the widest label, so the bar does not shrink as the
+			// value grows.
+			calculatedContentSize.X += ProgressLabelGap + le.CalculateTextWidth("100%", c.FontSize)
+			calculatedContentSize.Y = max(c.Thickness, c.FontSize)
+		}
+		if hasFixedWidth {
+			calculatedContentSize.X = max(0, fixedSize.X-2*paddingAndBorderX)
+		}
+
+	case *Spinner:
+		calculatedContentSize = math.Vec2f32{X: c.Diameter, Y: c.Diameter}
+
+	case *Skeleton:
+		calculatedContentSize = math.Vec2f32{X: c.Width, Y: c.ContentHeight()}
+		if hasFixedWidth && c.Shape != SkeletonCircle {
+			calculatedContentSize.X = max(0, fixedSize.X-2*paddingAndBorderX)
+		}
+
+	case *VirtualList, *VirtualGrid:
+		// A virtualized component fills the space it is given; its content
+		// scrolls inside.
+		viewport := math.Vec2f32{X: availableSize.X - 2*paddingAndBorderX, Y: availableSize.Y - 2*paddingAndBorderY}
+		if hasFixedWidth {
+			viewport.X = fixedSize.X - 2*paddingAndBorderX
+		}
+		if hasFixedHeight {
+			viewport.Y = fixedSize.Y - 2*paddingAndBorderY
+		}
+		viewport.X = max(0, viewport.X)
+		viewport.Y = max(0, viewport.Y)
+		le.layoutVirtualItems(c.(virtualizer), viewport)
+		calculatedContentSize = viewport
+
+	case *Table:
+		width := availableSize.X - 2*paddingAndBorderX
+		if hasFixedWidth {
+			width = fixedSize.X - 2*paddingAndBorderX
+		}
+		bodyHeight := float32(-1)
+		if hasFixedHeight {
+			bodyHeight = max(0, fixedSize.Y-2*paddingAndBorderY-c.HeaderHeight()-c.FooterHeight())
+		}
+		calculatedContentSize = le.layoutTable(c, max(0, width), bodyHeight)
+
+	case *Tabs:
+		childAvailableSize := math.Vec2f32{X: availableSize.X - 2*paddingAndBorderX, Y: availableSize.Y - 2*paddingAndBorderY - c.StripHeight()}
+		if hasFixedWidth {
+			childAvailableSize.X = fixedSize.X - 2*paddingAndBorderX
+		}
+		if hasFixedHeight {
+			childAvailableSize.Y = fixedSize.Y - 2*paddingAndBorderY - c.StripHeight()
+		}
+		childAvailableSize.X = max(0, childAvailableSize.X)
+		childAvailableSize.Y = max(0, childAvailableSize.Y)
+		var panelSize math.Vec2f32
+		if panel := c.Panel(); panel != nil {
+			panelSize = le.calculateSizeRecursive(panel, childAvailableSize)
+			panelSize.X += 2 * panel.Margin().X
+			panelSize.Y += 2 * panel.Margin().Y
+		}
+		stripWidth := le.layoutTabHeaders(c)
+		// The strip scrolls rather than grow past the available width.
+		calculatedContentSize = math.Vec2f32{
+			X: max(panelSize.X, min(stripWidth, childAvailableSize.X)),
+			Y: c.StripHeight() + panelSize.Y,
+		}
+
 	default:
 		// Return zero size for unknown types, maybe log a warning.
 		fmt.Printf("Warning: Unsupported component type for size calculation: %T\n", comp)
@@ -602,6 +946,10 @@ func (le *LayoutEngine) calculatePositionRecursive(comp IComponent, parentTopLef
 		containerContentOrigin := contentOrigin
 		// Use the size calculated in the first pass.
 
+		// RTL containers lay lines out as usual and then mirror each child
+		// horizontally, so the first child sits at the right edge.
+		rtl := c.IsRTL()
+
 		// Track position within the current line for relative layout.
 		currentLineXOffset := float32(0.0) + comp.Padding().X + comp.Border().X
 		currentLineYOffset := float32(0.0) + comp.Padding().Y + comp.Border().Y
@@ -656,10 +1004,15 @@ func (le *LayoutEngine) calculatePositionRecursive(comp IComponent, parentTopLef
 				currentLineXOffset += c.Gap().X // Add gap between children
 			}
 
+			childXOffset := currentLineXOffset
+			if rtl {
+				childXOffset = comp.Size().X - currentLineXOffset - childSize.X
+			}
+
 			// Calculate the child's position *relative* to this container's content origin.
 			childRelativePos := Position{
 				Type: PositionTypeRelative,                  // Ensure type is set correctly.
-				X:    currentLineXOffset + child.Margin().X, // Add margin to the relative position
+				X:    childXOffset + child.Margin().X,       // Add margin to the relative position
 				Y:    currentLineYOffset + child.Margin().Y, // Add margin to the relative position
 			}
 
@@ -669,7 +1022,7 @@ func (le *LayoutEngine) calculatePositionRecursive(comp IComponent, parentTopLef
 			// Calculate the child's absolute top-left screen coordinate.
 			// This is needed as the reference point (`parentTopLeft`) for positioning the child's *own* children.
 			childAbsoluteTopLeft := math.Vec2f32{
-				X: containerContentOrigin.X + currentLineXOffset, // Parent origin + child relative offset
+				X: containerContentOrigin.X + childXOffset,       // Parent origin + child relative offset
 				Y: containerContentOrigin.Y + currentLineYOffset, // Parent origin + child relative offset
 			}
 
@@ -682,7 +1035,75 @@ func (le *LayoutEngine) calculatePositionRecursive(comp IComponent, parentTopLef
 			currentLineMaxHeight = max(currentLineMaxHeight, childSize.Y)
 		}
 
-	case *Text, *Button, *Image:
+	case *Tabs:
+		// The panel sits below the header strip.
+		if panel := c.Panel(); panel != nil && panel.Display() != DisplayNone {
+			offset := math.Vec2f32{
+				X: c.Padding().X + c.Border().X,
+				Y: c.Padding().Y + c.Border().Y + c.StripHeight(),
+			}
+			panel.setPos(Position{Type: PositionTypeRelative, X: offset.X + panel.Margin().X, Y: offset.Y + panel.Margin().Y})
+			le.calculatePositionRecursive(panel, math.Vec2f32{X: contentOrigin.X + offset.X, Y: contentOrigin.Y + offset.Y})
+		}
+		c.clampScroll()
+
+	case *Table:
+		inset := math.Vec2f32{X: c.Padding().X + c.Border().X, Y: c.Padding().Y + c.Border().Y + c.HeaderHeight()}
+		// The body spans the final width, which a fixed width may widen.
+		c.viewport.X = max(0, c.Size().X-2*inset.X)
+		for r := range c.order {
+			rowY, _ := c.RowRect(r)
+			for col, column := range c.Columns {
+				cell := c.CellComponent(r, col)
+				if cell.Display() == DisplayNone {
+					continue
+				}
+				colX, colWidth := c.ColumnRect(col)
+				space := colWidth - 2*TableCellPaddingX - cell.Size().X - 2*cell.Margin().X
+				x := inset.X + colX + TableCellPaddingX
+				switch column.Align.Resolve(c.IsRTL()) {
+				case TextAlignCenter:
+					x += space / 2
+				case TextAlignRight:
+					x += space
+				}
+				y := inset.Y + rowY + TableCellPaddingY - c.Offset
+				cell.setPos(Position{Type: PositionTypeRelative, X: x + cell.Margin().X, Y: y + cell.Margin().Y})
+				le.calculatePositionRecursive(cell, math.Vec2f32{X: contentOrigin.X + x, Y: contentOrigin.Y + y})
+			}
+		}
+
+	case *VirtualList:
+		inset := math.Vec2f32{X: c.Padding().X + c.Border().X, Y: c.Padding().Y + c.Border().Y}
+		for k, item := range c.Children() {
+			x := inset.X
+			if c.IsRTL() {
+				// Items line up with the end edge, past the scrollbar.
+				x += c.Viewport().X - item.Size().X - 2*item.Margin().X
+			}
+			y := inset.Y + c.ItemOffset(c.First()+k) - c.Offset
+			item.setPos(Position{Type: PositionTypeRelative, X: x + item.Margin().X, Y: y + item.Margin().Y})
+			le.calculatePositionRecursive(item, math.Vec2f32{X: contentOrigin.X + x, Y: contentOrigin.Y + y})
+		}
+
+	case *VirtualGrid:
+		inset := math.Vec2f32{X: c.Padding().X + c.Border().X, Y: c.Padding().Y + c.Border().Y}
+		for k, cell := range c.Children() {
+			offset := c.CellOffset(c.First() + k)
+			if c.IsRTL() {
+				offset.X = c.Viewport().X - offset.X - c.CellSize.X
+			}
+			x, y := inset.X+offset.X, inset.Y+offset.Y-c.Offset
+			cell.setPos(Position{Type: PositionTypeRelative, X: x, Y: y})
+			le.calculatePositionRecursive(cell, math.Vec2f32{X: contentOrigin.X + x, Y: contentOrigin.Y + y})
+		}
+
+	case *RichText:
+		// Lines of an RTL paragraph end at the right edge of the final
+		// content box, which may be wider than the longest line.
+		c.alignFragments(c.Size().X - 2*(c.Padding().X+c.Border().X))
+
+	case *Text, *Button, *Image, *Icon, *Checkbox, *RadioGroup, *Switch, *Slider, *RangeSlider, *Select, *TreeView, *Chart, *Canvas, *ProgressBar, *Spinner, *Skeleton:
 		// Leaf node. Position was set by its parent container if relative.
 		// Absolute positioning was handled when calculating contentOrigin.
 		// No children to position.
@@ -693,3 +1114,180 @@ func (le *LayoutEngine) calculatePositionRecursive(comp IComponent, parentTopLef
 		panic(fmt.Sprintf("Unsupported component type for position calculation: %T", comp))
 	}
 }
+
+// toggleSize is the size of an indicator followed by an optional label.
+func (le *LayoutEngine) toggleSize(indicatorWidth, indicatorHeight float32, label string, fontSize float32) math.Vec2f32 {
+	size := math.Vec2f32{X: indicatorWidth, Y: max(indicatorHeight, fontSize)}
+	if label != "" {
+		size.X += ToggleLabelGap + le.CalculateTextWidth(label, fontSize)
+	}
+	return size
+}
+
+// layoutTabHeaders measures the headers of the visible tabs and returns the
+// width of the whole strip.
+func (le *LayoutEngine) layoutTabHeaders(t *Tabs) float32 {
+	t.headers = t.headers[:0]
+	var x float32
+	for pos, i := range t.VisibleTabs() {
+		if pos > 0 {
+			x += t.HeaderGap
+		}
+		width := le.CalculateTextWidth(t.Tabs[i].Title, t.FontSize) + 2*t.HeaderPadding.X
+		if t.Tabs[i].Closable {
+			width += t.HeaderPadding.X/2 + t.CloseSize()
+		}
+		t.headers = append(t.headers, TabHeader{Index: i, X: x, Width: width})
+		x += width
+	}
+	return x
+}
+
+// layoutVirtualItems sizes the mounted items of a virtualized component in
+// its viewport. List items are measured, and if their heights differ from
+// what was assumed the list keeps its top item in place.
+func (le *LayoutEngine) layoutVirtualItems(v virtualizer, viewport math.Vec2f32) {
+	switch c := v.(type) {
+	case *VirtualList:
+		c.viewport = viewport
+		itemSpace := math.Vec2f32{X: max(0, viewport.X-c.ScrollbarWidth), Y: viewport.Y}
+		changed := false
+		for k, item := range c.Children() {
+			size := le.calculateSizeRecursive(item, itemSpace)
+			changed = c.measure(c.First()+k, size.Y+2*item.Margin().Y) || changed
+		}
+		c.ensureOffsets()
+		if changed {
+			c.keepAnchor()
+		} else {
+			// Keeps the anchor in step if the content shrank under the view.
+			c.ScrollTo(c.Offset)
+		}
+	case *VirtualGrid:
+		c.viewport = viewport
+		for _, cell := range c.Children() {
+			le.calculateSizeRecursive(cell, c.CellSize)
+		}
+		c.updateContentHeight()
+		c.clampOffset()
+	}
+}
+
+// layoutChart fits a chart's value axis to its data and places the plot
+// within size, leaving room for the legend above it, the value labels at
+// its start and the index labels below it.
+func (le *LayoutEngine) layoutChart(c *Chart, size math.Vec2f32) {
+	c.updateScale()
+	var start, top, bottom float32
+	if c.ShowLegend && len(c.Series) > 0 {
+		top = c.FontSize + ChartLabelGap
+	}
+	if c.ShowAxes {
+		for _, v := range c.ValueTicks() {
+			start = max(start, le.CalculateTextWidth(c.TickLabel(v), c.FontSize))
+		}
+		start += ChartLabelGap
+		// Half a line of room keeps the top tick label inside the chart.
+		top = max(top, c.FontSize/2)
+		bottom = c.FontSize + ChartLabelGap
+	}
+	c.plotPos = math.Vec2f32{X: start, Y: top}
+	if c.IsRTL() {
+		c.plotPos.X = 0
+	}
+	c.plotSize = math.Vec2f32{X: max(0, size.X-start), Y: max(0, size.Y-top-bottom)}
+
+	// Skip index labels so that each has room for the widest.
+	c.labelEvery = 1
+	if n := c.Count(); c.ShowAxes && n > 0 && c.plotSize.X > 0 {
+		var widest float32
+		for i := range n {
+			widest = max(widest, le.CalculateTextWidth(c.IndexLabel(i), c.FontSize))
+		}
+		need := (widest + ChartLabelGap) / (c.plotSize.X / float32(n))
+		c.labelEvery = max(1, int(need))
+		if float32(c.labelEvery) < need {
+			c.labelEvery++
+		}
+	}
+}
+
+// layoutTable sizes a Table's columns within width and its cells within
+// their columns, and returns the size of its content. bodyHeight is the
+// height left for the rows by a fixed-height table, which then scrolls, or
+// -1 to fit all rows.
+func (le *LayoutEngine) layoutTable(t *Table, width, bodyHeight float32) math.Vec2f32 {
+	n := len(t.Columns)
+	t.columnWidths = make([]float32, n)
+	t.columnX = make([]float32, n)
+	if bodyHeight >= 0 {
+		width = max(0, width-t.ScrollbarWidth)
+	}
+	unbounded := math.Vec2f32{X: width}
+
+	// Fixed, resized and auto columns first; fraction columns share the rest.
+	var used, shares float32
+	for c, column := range t.Columns {
+		switch resized, ok := t.resizedWidth(c); {
+		case ok:
+			t.columnWidths[c] = resized
+		case column.Width.Sizing == ColumnFixed:
+			t.columnWidths[c] = column.clamp(column.Width.Value)
+		case column.Width.Sizing == ColumnFraction:
+			shares += max(0, column.Width.Value)
+			continue
+		default:
+			natural := le.CalculateTextWidth(column.Title, t.FontSize)
+			if column.Sortable {
+				natural += t.SortIndicatorWidth()
+			}
+			for r := range t.order {
+				cell := t.CellComponent(r, c)
+				size := le.calculateSizeRecursive(cell, unbounded)
+				natural = max(natural, size.X+2*cell.Margin().X)
+			}
+			t.columnWidths[c] = column.clamp(natural + 2*TableCellPaddingX)
+		}
+		used += t.columnWidths[c]
+	}
+	remaining := max(0, width-used)
+	for c, column := range t.Columns {
+		if _, ok := t.resizedWidth(c); ok || column.Width.Sizing != ColumnFraction {
+			continue
+		}
+		share := float32(0)
+		if shares > 0 {
+			share = remaining * max(0, column.Width.Value) / shares
+		}
+		t.columnWidths[c] = column.clamp(share)
+	}
+	var x float32
+	for c := range t.Columns {
+		t.columnX[c] = x
+		x += t.columnWidths[c]
+	}
+
+	// Each row is as tall as its tallest cell.
+	t.rowY = make([]float32, len(t.order)+1)
+	for r := range t.order {
+		height := t.FontSize
+		for c := range t.Columns {
+			cell := t.CellComponent(r, c)
+			space := math.Vec2f32{X: max(0, t.columnWidths[c]-2*TableCellPaddingX)}
+			size := le.calculateSizeRecursive(cell, space)
+			height = max(height, size.Y+2*cell.Margin().Y)
+		}
+		t.rowY[r+1] = t.rowY[r] + height + 2*TableCellPaddingY
+	}
+
+	t.contentHeight = t.rowY[len(t.order)]
+	if bodyHeight < 0 {
+		bodyHeight = t.contentHeight
+	}
+	t.viewport = math.Vec2f32{X: x, Y: bodyHeight}
+	if bodyHeight < t.contentHeight {
+		t.viewport.X += t.ScrollbarWidth
+	}
+	t.clampOffset()
+	return math.Vec2f32{X: t.viewport.X, Y: t.HeaderHeight() + bodyHeight + t.FooterHeight()}
+}
@@ -0,0 +1,426 @@
+package ui
+
+import (
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/internal/markdown"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Markdown Theme
+// ——————————————————————————————————————————————————————————————————————————————
+
+type MarkdownTheme struct {
+	TextColor       color.RGBA
+	FontSize        float32
+	LineHeight      float32
+	HeadingSizes    [6]float32
+	HeadingColor    color.RGBA
+	LinkColor       color.RGBA
+	CodeFont        Font
+	CodeFontSize    float32
+	CodeColor       color.RGBA
+	CodeBackground  color.RGBA
+	QuoteColor      color.RGBA
+	QuoteBackground color.RGBA
+	RuleColor       color.RGBA
+	TableFontSize   float32
+	BlockGap        float32
+	ListIndent      float32
+	CodePadding     math.Vec2f32
+	CodeRadius      float32
+	QuotePadding    math.Vec2f32
+}
+
+func DefaultMarkdownTheme() MarkdownTheme {
+	theme := CurrentTheme()
+	return MarkdownTheme{
+		TextColor:       theme.Text,
+		FontSize:        theme.FontSizeBody,
+		LineHeight:      1.3,
+		HeadingSizes:    [6]float32{32, 26, 22, 19, 17, 16},
+		HeadingColor:    theme.Text,
+		LinkColor:       theme.Link,
+		CodeFontSize:    theme.FontSizeBody - 1,
+		CodeColor:       theme.Code,
+		CodeBackground:  theme.Surface,
+		QuoteColor:      theme.TextMuted,
+		QuoteBackground: theme.Tinted(0.06),
+		RuleColor:       theme.TextDisabled,
+		TableFontSize:   theme.FontSizeSmall,
+		BlockGap:        theme.Space(2.5),
+		ListIndent:      theme.Space(3),
+		CodePadding:     math.Vec2f32{X: theme.Space(2), Y: theme.Space(1.5)},
+		CodeRadius:      theme.RadiusSmall,
+		QuotePadding:    math.Vec2f32{X: theme.Space(2.5), Y: theme.Space(1.5)},
+	}
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Markdown Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Markdown renders CommonMark source. It is a derived component: the layout
+// engine expands it into Container, RichText, Text, Image and Table.
+type Markdown struct {
+	Component
+	Source      string
+	Theme       MarkdownTheme
+	OnLinkClick func(url string)
+	// ImageDir resolves relative image paths.
+	ImageDir string
+}
+
+func NewMarkdown(source string) *Markdown {
+	m := &Markdown{
+		Component: newComponentBase(MarkdownKind),
+		Source:    source,
+		Theme:     DefaultMarkdownTheme(),
+	}
+	m.Component.setDisplay(DisplayBlock)
+	return m
+}
+
+func (m *Markdown) SetID(id string) *Markdown {
+	m.Component.setID(id)
+	return m
+}
+
+func (m *Markdown) SetSource(source string) *Markdown {
+	m.Source = source
+	return m
+}
+
+func (m *Markdown) SetTheme(theme MarkdownTheme) *Markdown {
+	m.Theme = theme
+	return m
+}
+
+func (m *Markdown) SetOnLinkClick(callback func(url string)) *Markdown {
+	m.OnLinkClick = callback
+	return m
+}
+
+func (m *Markdown) SetImageDir(dir string) *Markdown {
+	m.ImageDir = dir
+	return m
+}
+
+func (m *Markdown) SetDisplay(d Display) *Markdown {
+	m.Component.setDisplay(d)
+	return m
+}
+
+func (m *Markdown) SetPosition(pos Position) *Markdown {
+	m.Component.setPos(pos)
+	return m
+}
+
+func (m *Markdown) SetSize(size math.Vec2f32) *Markdown {
+	m.Component.setSize(size)
+	return m
+}
+
+func (m *Markdown) SetWidthPercent(widthPercent float32) *Markdown {
+	m.Component.setWidthPercent(widthPercent)
+	return m
+}
+
+func (m *Markdown) SetPadding(padding math.Vec2f32) *Markdown {
+	m.Component.setPadding(padding)
+	return m
+}
+
+func (m *Markdown) SetBackgroundColor(color color.RGBA) *Markdown {
+	m.Component.setBackgroundColor(color)
+	return m
+}
+
+func (m *Markdown) SetZIndex(zIndex int) *Markdown {
+	m.Component.setZIndex(zIndex)
+	return m
+}
+
+func (m *Markdown) SetTooltip(tooltip *Tooltip) *Markdown {
+	m.Component.setTooltip(tooltip)
+	return m
+}
+
+func (m *Markdown) SetTransform(transform *Transform) *Markdown {
+	m.Component.setTransform(transform)
+	return m
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Expansion into primitives
+// ——————————————————————————————————————————————————————————————————————————————
+
+var markdownCache = struct {
+	sync.Mutex
+	docs map[string]*markdown.Document
+}{docs: make(map[string]*markdown.Document)}
+
+// parseMarkdown caches documents since the same source is rebuilt every frame.
+func parseMarkdown(source string) *markdown.Document {
+	markdownCache.Lock()
+	defer markdownCache.Unlock()
+	if doc, ok := markdownCache.docs[source]; ok {
+		return doc
+	}
+	if len(markdownCache.docs) > 64 {
+		markdownCache.docs = make(map[string]*markdown.Document)
+	}
+	doc := markdown.Parse(source)
+	markdownCache.docs[source] = doc
+	return doc
+}
+
+// inlineStyle is the style inherited by nested inline elements.
+type inlineStyle struct {
+	font       Font
+	fontSize   float32
+	color      color.RGBA
+	background color.RGBA
+	underline  bool
+	onClick    func(span *Span)
+}
+
+func (m *Markdown) expand() *Container {
+	root := NewContainer().
+		SetID(m.ID()).
+		SetDisplay(m.Display()).
+		SetSize(m.Size()).
+		SetPosition(m.Pos()).
+		SetBackgroundColor(m.BackgroundColor()).
+		SetPadding(m.Padding()).
+		SetZIndex(m.ZIndex()).
+		SetWidthPercent(m.WidthPercent()).
+		SetGap(math.Vec2f32{Y: m.Theme.BlockGap})
+
+	base := inlineStyle{fontSize: m.Theme.FontSize, color: m.Theme.TextColor}
+	m.appendBlocks(root, parseMarkdown(m.Source).Blocks, base)
+	return root
+}
+
+func (m *Markdown) appendBlocks(parent *Container, blocks []markdown.Block, style inlineStyle) {
+	for _, block := range blocks {
+		parent.AddChild(m.expandBlock(block, style, nil))
+	}
+}
+
+// expandBlock converts one block. prefix, if set, is placed before the
+// block's first line (list markers).
+func (m *Markdown) expandBlock(block markdown.Block, style inlineStyle, prefix *Span) IComponent {
+	theme := m.Theme
+	switch b := block.(type) {
+	case *markdown.Heading:
+		style.fontSize = theme.HeadingSizes[b.Level-1]
+		style.font.Weight = FontWeightBold
+		style.color = theme.HeadingColor
+		return m.paragraph(b.Inlines, style, prefix)
+
+	case *markdown.Paragraph:
+		if img, ok := singleImage(b.Inlines); ok && prefix == nil {
+			if component := m.image(img); component != nil {
+				return component
+			}
+		}
+		return m.paragraph(b.Inlines, style, prefix)
+
+	case *markdown.List:
+		list := NewContainer().
+			SetDisplay(DisplayBlock).
+			SetPadding(math.Vec2f32{X: theme.ListIndent}).
+			SetGap(math.Vec2f32{Y: theme.BlockGap / 2})
+		for i, item := range b.Items {
+			marker := "- "
+			if b.Ordered {
+				marker = strconv.Itoa(b.Start+i) + ". "
+			}
+			markerSpan := NewSpan(marker).SetColor(style.color).SetFontSize(style.fontSize)
+			itemContainer := NewContainer().SetDisplay(DisplayBlock).SetGap(math.Vec2f32{Y: theme.BlockGap / 2})
+			if len(item) == 0 {
+				itemContainer.AddChild(m.paragraph(nil, style, markerSpan))
+			}
+			for j, child := range item {
+				if j == 0 {
+					itemContainer.AddChild(m.expandBlock(child, style, markerSpan))
+				} else {
+					itemContainer.AddChild(m.expandBlock(child, style, nil))
+				}
+			}
+			list.AddChild(itemContainer)
+		}
+		return list
+
+	case *markdown.CodeBlock:
+		return NewContainer().
+			SetDisplay(DisplayBlock).
+			SetBackgroundColor(theme.CodeBackground).
+			SetBorderRadius(theme.CodeRadius).
+			SetPadding(theme.CodePadding).
+			AddChild(NewText(b.Text).SetColor(theme.CodeColor).SetFontSize(theme.CodeFontSize))
+
+	case *markdown.BlockQuote:
+		quote := NewContainer().
+			SetDisplay(DisplayBlock).
+			SetBackgroundColor(theme.QuoteBackground).
+			SetBorder(math.Vec2f32{X: 1, Y: 0}).
+			SetBorderColor(theme.RuleColor).
+			SetPadding(theme.QuotePadding).
+			SetGap(math.Vec2f32{Y: theme.BlockGap})
+		style.color = theme.QuoteColor
+		if prefix != nil {
+			quote.AddChild(m.paragraph(nil, style, prefix))
+		}
+		m.appendBlocks(quote, b.Blocks, style)
+		return quote
+
+	case *markdown.Table:
+		header := make([]string, len(b.Header))
+		for i, cell := range b.Header {
+			header[i] = markdown.PlainText(cell)
+		}
+		table := NewTable().
+			SetDisplay(DisplayBlock).
+			SetHeader(header).
+			SetFontSize(theme.TableFontSize).
+			SetFontColor(theme.TextColor)
+		for _, row := range b.Rows {
+			cells := make([]string, len(row))
+			for i, cell := range row {
+				cells[i] = markdown.PlainText(cell)
+			}
+			table.AddRow(Row{Cells: cells})
+		}
+		return table
+
+	case *markdown.ThematicBreak:
+		return NewContainer().
+			SetDisplay(DisplayBlock).
+			SetWidthPercent(100).
+			SetSize(math.Vec2f32{Y: 1}).
+			SetBackgroundColor(theme.RuleColor)
+	}
+	return NewContainer().SetDisplay(DisplayBlock)
+}
+
+func (m *Markdown) paragraph(inlines []markdown.Inline, style inlineStyle, prefix *Span) *RichText {
+	rt := NewRichText().
+		SetFontSize(style.fontSize).
+		SetColor(style.color).
+		SetLineHeight(m.Theme.LineHeight).
+		SetDisplay(DisplayBlock)
+	if prefix != nil {
+		rt.AddSpan(prefix)
+	}
+	m.appendSpans(rt, inlines, style)
+	return rt
+}
+
+func (m *Markdown) appendSpans(rt *RichText, inlines []markdown.Inline, style inlineStyle) {
+	for _, in := range inlines {
+		switch n := in.(type) {
+		case *markdown.Text:
+			rt.AddSpan(m.span(n.Text, style))
+		case *markdown.LineBreak:
+			rt.AddSpan(m.span("\n", style))
+		case *markdown.Emphasis:
+			inner := style
+			if n.Strong {
+				inner.font.Weight = FontWeightBold
+			} else {
+				inner.font.Italic = true
+			}
+			m.appendSpans(rt, n.Children, inner)
+		case *markdown.Code:
+			inner := style
+			inner.font = m.Theme.CodeFont
+			inner.color = m.Theme.CodeColor
+			inner.background = m.Theme.CodeBackground
+			rt.AddSpan(m.span(n.Text, inner))
+		case *markdown.Link:
+			inner := style
+			inner.color = m.Theme.LinkColor
+			inner.underline = true
+			url := n.URL
+			inner.onClick = func(*Span) {
+				if m.OnLinkClick != nil {
+					m.OnLinkClick(url)
+				}
+			}
+			m.appendSpans(rt, n.Children, inner)
+		case *markdown.Image:
+			// Inline images cannot flow with text; show the alt text instead.
+			rt.AddSpan(m.span("["+n.Alt+"]", style))
+		}
+	}
+}
+
+func (m *Markdown) span(text string, style inlineStyle) *Span {
+	s := NewSpan(text).
+		SetFont(style.font).
+		SetFontSize(style.fontSize).
+		SetColor(style.color).
+		SetBackground(style.background).
+		SetUnderline(style.underline)
+	if style.onClick != nil {
+		s.SetOnClick(style.onClick)
+	}
+	return s
+}
+
+func singleImage(inlines []markdown.Inline) (*markdown.Image, bool) {
+	if len(inlines) != 1 {
+		return nil, false
+	}
+	img, ok := inlines[0].(*markdown.Image)
+	return img, ok
+}
+
+// image returns a block image, or nil if the file cannot be read (remote
+// URLs, missing files) so the caller can fall back to alt text.
+func (m *Markdown) image(img *markdown.Image) IComponent {
+	if strings.Contains(img.URL, "://") {
+		return nil
+	}
+	path := img.URL
+	if m.ImageDir != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(m.ImageDir, path)
+	}
+	size, ok := imageSize(path)
+	if !ok {
+		return nil
+	}
+	return NewImage(path).SetSize(size).SetDisplay(DisplayBlock)
+}
+
+var imageSizeCache sync.Map // path -> math.Vec2f32
+
+// imageSize reads the dimensions of an image file from its header.
+func imageSize(path string) (math.Vec2f32, bool) {
+	if size, ok := imageSizeCache.Load(path); ok {
+		return size.(math.Vec2f32), true
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return math.Vec2f32{}, false
+	}
+	defer f.Close()
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return math.Vec2f32{}, false
+	}
+	size := math.Vec2f32{X: float32(cfg.Width), Y: float32(cfg.Height)}
+	imageSizeCache.Store(path, size)
+	return size, true
+}
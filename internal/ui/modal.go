@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Modal Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Modal is a dialog shown above the whole window on a dimmed backdrop.
+// Modals are not placed in the component tree: open them with
+// App.OpenModal, which keeps them on a stack of overlays until closed.
+// While a modal is open, pointer input and keyboard focus stay inside it.
+type Modal struct {
+	Component
+	Title           string
+	TitleFontSize   float32
+	TitleColor      color.RGBA
+	Content         []IComponent
+	Width           float32 // dialog width, 0 to fit the content
+	BackdropColor   color.RGBA
+	CloseOnEscape   bool
+	CloseOnBackdrop bool
+	OnClose         func()
+}
+
+func NewModal(title string, content ...IComponent) *Modal {
+	theme := CurrentTheme()
+	m := &Modal{
+		Component:       newComponentBase(ModalKind),
+		Title:           title,
+		TitleFontSize:   theme.FontSizeTitle,
+		TitleColor:      theme.Text,
+		Content:         content,
+		BackdropColor:   theme.Backdrop,
+		CloseOnEscape:   true,
+		CloseOnBackdrop: true,
+	}
+	m.Component.setPadding(math.Vec2f32{X: theme.Space(5), Y: theme.Space(4)})
+	m.Component.setGap(math.Vec2f32{Y: theme.Space(3)})
+	m.Component.setBorderRadius(theme.RadiusMedium)
+	m.Component.setBorder(math.Vec2f32{X: 1, Y: 1})
+	m.Component.setBorderColor(theme.Border)
+	m.Component.setBackgroundColor(theme.Surface)
+	return m
+}
+
+// Expand builds the overlay tree of the modal: a backdrop covering the
+// window with the dialog inside it. The dialog is returned separately so
+// that it can be centred once its size is known.
+func (m *Modal) Expand(windowSize math.Vec2f32) (backdrop, dialog *Container) {
+	dialog = NewContainer().
+		SetID("dialog").
+		SetDisplay(DisplayBlock).
+		SetSize(math.Vec2f32{X: m.Width}).
+		SetBackgroundColor(m.BackgroundColor()).
+		SetPadding(m.Padding()).
+		SetBorder(m.Border()).
+		SetBorderColor(m.BorderColor()).
+		SetBorderRadius(m.BorderRadius()).
+		SetGap(m.Gap()).
+		SetDirection(m.Direction())
+	if m.Title != "" {
+		dialog.AddChild(NewText(m.Title).
+			SetFontSize(m.TitleFontSize).
+			SetColor(m.TitleColor).
+			SetDisplay(DisplayBlock))
+	}
+	dialog.AddChildren(m.Content...)
+
+	backdrop = NewContainer().
+		SetID(m.ID()).
+		SetPosition(Position{Type: PositionTypeAbsolute}).
+		SetSize(windowSize).
+		SetBackgroundColor(m.BackdropColor).
+		AddChild(dialog)
+	return backdrop, dialog
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (m *Modal) SetID(id string) *Modal {
+	m.Component.setID(id)
+	return m
+}
+
+func (m *Modal) SetTitle(title string) *Modal {
+	m.Title = title
+	return m
+}
+
+func (m *Modal) SetTitleFontSize(size float32) *Modal {
+	if size > 0 {
+		m.TitleFontSize = size
+	}
+	return m
+}
+
+func (m *Modal) SetTitleColor(color color.RGBA) *Modal {
+	m.TitleColor = color
+	return m
+}
+
+func (m *Modal) AddChild(child IComponent) *Modal {
+	m.Content = append(m.Content, child)
+	return m
+}
+
+func (m *Modal) AddChildren(children ...IComponent) *Modal {
+	m.Content = append(m.Content, children...)
+	return m
+}
+
+func (m *Modal) SetWidth(width float32) *Modal {
+	m.Width = max(0, width)
+	return m
+}
+
+func (m *Modal) SetBackdropColor(color color.RGBA) *Modal {
+	m.BackdropColor = color
+	return m
+}
+
+func (m *Modal) SetCloseOnEscape(close bool) *Modal {
+	m.CloseOnEscape = close
+	return m
+}
+
+func (m *Modal) SetCloseOnBackdrop(close bool) *Modal {
+	m.CloseOnBackdrop = close
+	return m
+}
+
+func (m *Modal) SetOnClose(callback func()) *Modal {
+	m.OnClose = callback
+	return m
+}
+
+func (m *Modal) SetBackgroundColor(color color.RGBA) *Modal {
+	m.Component.setBackgroundColor(color)
+	return m
+}
+
+func (m *Modal) SetPadding(padding math.Vec2f32) *Modal {
+	m.Component.setPadding(padding)
+	return m
+}
+
+func (m *Modal) SetGap(gap math.Vec2f32) *Modal {
+	m.Component.setGap(gap)
+	return m
+}
+
+func (m *Modal) SetBorderRadius(radius float32) *Modal {
+	m.Component.setBorderRadius(radius)
+	return m
+}
+
+func (m *Modal) SetDirection(direction Direction) *Modal {
+	m.Component.setDirection(direction)
+	return m
+}
@@ -0,0 +1,81 @@
+package ui
+
+import "github.com/aj-2000/mogi/math"
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Overlay Placement
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Placement is the side of an anchor a popup is placed on.
+type Placement int
+
+const (
+	PlacementBottom Placement = iota
+	PlacementTop
+	PlacementRight
+	PlacementLeft
+)
+
+func (p Placement) opposite() Placement {
+	switch p {
+	case PlacementBottom:
+		return PlacementTop
+	case PlacementTop:
+		return PlacementBottom
+	case PlacementRight:
+		return PlacementLeft
+	default:
+		return PlacementRight
+	}
+}
+
+// PopupAlign is how a popup above or below its anchor lines up with it.
+// Popups on the left or right are always centred on the anchor.
+type PopupAlign int
+
+const (
+	PopupAlignStart PopupAlign = iota
+	PopupAlignCenter
+)
+
+// PopupOffset is the gap between an anchor and a popup placed beside it.
+const PopupOffset float32 = 4
+
+// placeOn returns the top-left of a popup of the given size on side of the
+// anchor rect.
+func placeOn(side Placement, align PopupAlign, anchorPos, anchorSize, size math.Vec2f32) math.Vec2f32 {
+	x := anchorPos.X
+	if align == PopupAlignCenter {
+		x += (anchorSize.X - size.X) / 2
+	}
+	switch side {
+	case PlacementTop:
+		return math.Vec2f32{X: x, Y: anchorPos.Y - size.Y - PopupOffset}
+	case PlacementRight:
+		return math.Vec2f32{X: anchorPos.X + anchorSize.X + PopupOffset, Y: anchorPos.Y + (anchorSize.Y-size.Y)/2}
+	case PlacementLeft:
+		return math.Vec2f32{X: anchorPos.X - size.X - PopupOffset, Y: anchorPos.Y + (anchorSize.Y-size.Y)/2}
+	default:
+		return math.Vec2f32{X: x, Y: anchorPos.Y + anchorSize.Y + PopupOffset}
+	}
+}
+
+func fitsIn(pos, size, window math.Vec2f32) bool {
+	return math.Rect{Size: window}.ContainsRect(math.Rect{Pos: pos, Size: size})
+}
+
+// PlacePopup positions a popup of the given size next to an anchor rect
+// (absolute position and size) inside a window. It uses the preferred side,
+// flips to the opposite side if the popup does not fit there but fits on the
+// other, and finally shifts it to stay inside the window.
+func PlacePopup(side Placement, align PopupAlign, anchorPos, anchorSize, size, window math.Vec2f32) math.Vec2f32 {
+	pos := placeOn(side, align, anchorPos, anchorSize, size)
+	if !fitsIn(pos, size, window) {
+		if flipped := placeOn(side.opposite(), align, anchorPos, anchorSize, size); fitsIn(flipped, size, window) {
+			pos = flipped
+		}
+	}
+	pos.X = max(0, min(pos.X, window.X-size.X))
+	pos.Y = max(0, min(pos.Y, window.Y-size.Y))
+	return pos
+}
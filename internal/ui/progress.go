@@ -0,0 +1,412 @@
+package ui
+
+import (
+	gomath "math"
+	"strconv"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Animation
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Animated is implemented by components that move on their own. Every frame
+// the app advances them by the time since the last one, so they move at the
+// same speed whatever the frame rate.
+type Animated interface {
+	IComponent
+	// Advance moves the animation on by dt seconds.
+	Advance(dt float32)
+	saveAnimation() *animationState
+	restoreAnimation(state *animationState)
+}
+
+// animationState is what an animated component keeps across frames.
+type animationState struct {
+	// Phase is how far through its cycle the animation is, from 0 to 1.
+	Phase float32
+	// Shown is the value on screen while it catches up with a new one.
+	Shown   float32
+	started bool
+}
+
+// animator holds the state of an Animated component.
+type animator struct {
+	anim animationState
+}
+
+func (a *animator) saveAnimation() *animationState {
+	state := a.anim
+	return &state
+}
+
+func (a *animator) restoreAnimation(state *animationState) { a.anim = *state }
+
+// Phase returns how far through its cycle the animation is, from 0 to 1.
+func (a *animator) Phase() float32 { return a.anim.Phase }
+
+// cycle moves the phase on by dt seconds at speed cycles per second.
+func (a *animator) cycle(dt, speed float32) {
+	a.anim.Phase = float32(gomath.Mod(float64(a.anim.Phase+dt*speed), 1))
+}
+
+// AdvanceAnimations advances every Animated component in the tree by dt
+// seconds.
+func AdvanceAnimations(comp IComponent, dt float32) {
+	if comp == nil {
+		return
+	}
+	if a, ok := comp.(Animated); ok {
+		a.Advance(dt)
+	}
+	for _, child := range comp.Children() {
+		AdvanceAnimations(child, dt)
+	}
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// ProgressBar Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// ProgressShape is how a ProgressBar is drawn.
+type ProgressShape int
+
+const (
+	ProgressLinear ProgressShape = iota
+	// ProgressCircular fills a ring clockwise from the top.
+	ProgressCircular
+)
+
+const (
+	// ProgressLabelGap is the space between a linear progress bar and its
+	// label.
+	ProgressLabelGap float32 = 8
+	// progressCatchUp is how quickly, per second, the bar closes the gap to
+	// a new value.
+	progressCatchUp float32 = 12
+	// ProgressSegment is the part of the track the moving segment of an
+	// indeterminate linear bar covers.
+	ProgressSegment float32 = 0.35
+)
+
+// ProgressBar shows how far along a task is, as a filled track or ring. The
+// fill glides to a new value rather than jumping. An indeterminate bar,
+// for work of unknown length, shows a segment sweeping along the track (or
+// around the ring) instead.
+type ProgressBar struct {
+	Component
+	animator
+	Shape ProgressShape
+	// Value is the fraction done, from 0 to 1.
+	Value         float32
+	Indeterminate bool
+	// Length is the length of a linear track; Diameter the size of a ring.
+	Length    float32
+	Diameter  float32
+	Thickness float32
+	// Speed is how many sweeps an indeterminate bar makes per second.
+	Speed      float32
+	ShowLabel  bool
+	FontSize   float32
+	TextColor  color.RGBA
+	TrackColor color.RGBA
+	FillColor  color.RGBA
+}
+
+func NewProgressBar(value float32) *ProgressBar {
+	theme := CurrentTheme()
+	return &ProgressBar{
+		Component:  newComponentBase(ProgressBarKind),
+		Value:      max(0, min(value, 1)),
+		Length:     200,
+		Diameter:   48,
+		Thickness:  6,
+		Speed:      0.7,
+		FontSize:   theme.FontSizeSmall,
+		TextColor:  theme.Text,
+		TrackColor: theme.Track,
+		FillColor:  theme.Primary,
+	}
+}
+
+// NewIndeterminateProgressBar returns a bar for work of unknown length.
+func NewIndeterminateProgressBar() *ProgressBar {
+	p := NewProgressBar(0)
+	p.Indeterminate = true
+	return p
+}
+
+// Advance sweeps an indeterminate bar and moves the fill of a determinate
+// one towards Value, covering the same share of the gap each second.
+func (p *ProgressBar) Advance(dt float32) {
+	p.cycle(dt, p.Speed)
+	if !p.anim.started {
+		p.anim.Shown, p.anim.started = p.Value, true
+		return
+	}
+	p.anim.Shown += (p.Value - p.anim.Shown) * (1 - float32(gomath.Exp(float64(-dt*progressCatchUp))))
+}
+
+// Shown returns the fraction the fill shows, which trails Value while it
+// catches up.
+func (p *ProgressBar) Shown() float32 {
+	if !p.anim.started {
+		return p.Value
+	}
+	return max(0, min(p.anim.Shown, 1))
+}
+
+// Label returns the percentage shown next to or inside the bar.
+func (p *ProgressBar) Label() string {
+	return strconv.Itoa(int(gomath.Round(float64(p.Shown()*100)))) + "%"
+}
+
+// Segment returns where the moving segment of an indeterminate linear bar
+// starts and ends, as fractions of the track; it runs in from before the
+// start and out past the end, so it is cut off at both.
+func (p *ProgressBar) Segment() (from, to float32) {
+	start := p.Phase()*(1+ProgressSegment) - ProgressSegment
+	return max(0, start), min(1, start+ProgressSegment)
+}
+
+// Arc returns the angles, in radians clockwise from the positive x axis,
+// that the fill of a circular bar spans.
+func (p *ProgressBar) Arc() (start, end float32) {
+	top := float32(-gomath.Pi / 2)
+	if !p.Indeterminate {
+		return top, top + 2*gomath.Pi*p.Shown()
+	}
+	// A segment that grows and shrinks as it goes round.
+	phase := float64(p.Phase())
+	start = top + float32(2*gomath.Pi*phase)
+	sweep := float32(gomath.Pi * (0.3 + 0.5*(1-gomath.Cos(2*gomath.Pi*phase))/2))
+	return start, start + sweep
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Spinner Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Spinner is a ring of dots with a bright head going round, fading behind
+// it, to show that something is busy.
+type Spinner struct {
+	Component
+	animator
+	Diameter float32
+	Dots     int
+	// Speed is how many turns the spinner makes per second.
+	Speed float32
+	Color color.RGBA
+}
+
+func NewSpinner() *Spinner {
+	return &Spinner{
+		Component: newComponentBase(SpinnerKind),
+		Diameter:  24,
+		Dots:      8,
+		Speed:     1,
+		Color:     CurrentTheme().Text,
+	}
+}
+
+func (s *Spinner) Advance(dt float32) { s.cycle(dt, s.Speed) }
+
+// DotAlpha returns the opacity of dot i: full at the head, fading with the
+// distance behind it.
+func (s *Spinner) DotAlpha(i int) float32 {
+	n := float32(max(1, s.Dots))
+	behind := float32(gomath.Mod(float64(s.Phase()*n-float32(i)+n), float64(n)))
+	return 1 - 0.85*behind/n
+}
+
+// DotCenter returns the center of dot i, relative to the content box, going
+// clockwise from the top.
+func (s *Spinner) DotCenter(i int) math.Vec2f32 {
+	radius := s.Diameter/2 - s.DotRadius()
+	angle := 2*gomath.Pi*float64(i)/float64(max(1, s.Dots)) - gomath.Pi/2
+	return math.Vec2f32{
+		X: s.Diameter/2 + radius*float32(gomath.Cos(angle)),
+		Y: s.Diameter/2 + radius*float32(gomath.Sin(angle)),
+	}
+}
+
+func (s *Spinner) DotRadius() float32 { return s.Diameter / 10 }
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (p *ProgressBar) SetID(id string) *ProgressBar {
+	p.Component.setID(id)
+	return p
+}
+
+// SetValue sets the fraction done, from 0 to 1.
+func (p *ProgressBar) SetValue(value float32) *ProgressBar {
+	p.Value = max(0, min(value, 1))
+	return p
+}
+
+func (p *ProgressBar) SetIndeterminate(indeterminate bool) *ProgressBar {
+	p.Indeterminate = indeterminate
+	return p
+}
+
+func (p *ProgressBar) SetShape(shape ProgressShape) *ProgressBar {
+	p.Shape = shape
+	return p
+}
+
+func (p *ProgressBar) SetLength(length float32) *ProgressBar {
+	p.Length = length
+	return p
+}
+
+func (p *ProgressBar) SetDiameter(diameter float32) *ProgressBar {
+	p.Diameter = diameter
+	return p
+}
+
+func (p *ProgressBar) SetThickness(thickness float32) *ProgressBar {
+	p.Thickness = thickness
+	return p
+}
+
+func (p *ProgressBar) SetSpeed(speed float32) *ProgressBar {
+	p.Speed = speed
+	return p
+}
+
+func (p *ProgressBar) SetShowLabel(show bool) *ProgressBar {
+	p.ShowLabel = show
+	return p
+}
+
+func (p *ProgressBar) SetFontSize(size float32) *ProgressBar {
+	if size > 0 {
+		p.FontSize = size
+	}
+	return p
+}
+
+func (p *ProgressBar) SetTextColor(color color.RGBA) *ProgressBar {
+	p.TextColor = color
+	return p
+}
+
+func (p *ProgressBar) SetTrackColor(color color.RGBA) *ProgressBar {
+	p.TrackColor = color
+	return p
+}
+
+func (p *ProgressBar) SetFillColor(color color.RGBA) *ProgressBar {
+	p.FillColor = color
+	return p
+}
+
+func (p *ProgressBar) SetBackgroundColor(color color.RGBA) *ProgressBar {
+	p.Component.setBackgroundColor(color)
+	return p
+}
+
+func (p *ProgressBar) SetDisplay(d Display) *ProgressBar {
+	p.Component.setDisplay(d)
+	return p
+}
+
+func (p *ProgressBar) SetPosition(pos Position) *ProgressBar {
+	p.Component.setPos(pos)
+	return p
+}
+
+func (p *ProgressBar) SetMargin(margin math.Vec2f32) *ProgressBar {
+	p.Component.setMargin(margin)
+	return p
+}
+
+func (p *ProgressBar) SetPadding(padding math.Vec2f32) *ProgressBar {
+	p.Component.setPadding(padding)
+	return p
+}
+
+func (p *ProgressBar) SetWidthPercent(widthPercent float32) *ProgressBar {
+	p.Component.setWidthPercent(widthPercent)
+	return p
+}
+
+func (p *ProgressBar) SetZIndex(zIndex int) *ProgressBar {
+	p.Component.setZIndex(zIndex)
+	return p
+}
+
+func (p *ProgressBar) SetTooltip(tooltip *Tooltip) *ProgressBar {
+	p.Component.setTooltip(tooltip)
+	return p
+}
+
+func (p *ProgressBar) SetTransform(transform *Transform) *ProgressBar {
+	p.Component.setTransform(transform)
+	return p
+}
+
+func (s *Spinner) SetID(id string) *Spinner {
+	s.Component.setID(id)
+	return s
+}
+
+func (s *Spinner) SetDiameter(diameter float32) *Spinner {
+	s.Diameter = diameter
+	return s
+}
+
+func (s *Spinner) SetDots(dots int) *Spinner {
+	s.Dots = max(1, dots)
+	return s
+}
+
+func (s *Spinner) SetSpeed(speed float32) *Spinner {
+	s.Speed = speed
+	return s
+}
+
+func (s *Spinner) SetColor(color color.RGBA) *Spinner {
+	s.Color = color
+	return s
+}
+
+func (s *Spinner) SetDisplay(d Display) *Spinner {
+	s.Component.setDisplay(d)
+	return s
+}
+
+func (s *Spinner) SetPosition(pos Position) *Spinner {
+	s.Component.setPos(pos)
+	return s
+}
+
+func (s *Spinner) SetMargin(margin math.Vec2f32) *Spinner {
+	s.Component.setMargin(margin)
+	return s
+}
+
+func (s *Spinner) SetPadding(padding math.Vec2f32) *Spinner {
+	s.Component.setPadding(padding)
+	return s
+}
+
+func (s *Spinner) SetZIndex(zIndex int) *Spinner {
+	s.Component.setZIndex(zIndex)
+	return s
+}
+
+func (s *Spinner) SetTooltip(tooltip *Tooltip) *Spinner {
+	s.Component.setTooltip(tooltip)
+	return s
+}
+
+func (s *Spinner) SetTransform(transform *Transform) *Spinner {
+	s.Component.setTransform(transform)
+	return s
+}
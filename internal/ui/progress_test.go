@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/aj-2000/mogi/math"
+)
+
+// TestAnimationsInModal builds a "Loading…" modal each frame the way the app
+// does and checks that the spinner inside it keeps turning.
+func TestAnimationsInModal(t *testing.T) {
+	le := NewLayoutEngine(monospace)
+	window := math.Vec2f32{X: 800, Y: 600}
+	for frame := 1; frame <= 6; frame++ {
+		le.BeginLayout()
+		spinner := NewSpinner().SetSpeed(0.5)
+		backdrop, _ := NewModal("Loading…", spinner).Expand(window)
+		root := le.BuildOverlay(backdrop, "modal#1", 0.5)
+		le.Layout(root, math.Vec2f32{}, window)
+		le.CopyStateFromComponentsRecursive(root)
+		le.EndLayout()
+
+		want := float32(frame%4) * 0.25
+		if got := spinner.Phase(); got != want {
+			t.Fatalf("frame %d: spinner phase = %v, want %v", frame, got, want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// RadioGroup Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// RadioGroup lets the user pick one of several options, stacked vertically.
+// The selection is kept between frames unless SetSelected is called every
+// frame.
+type RadioGroup struct {
+	Component
+	Options       []string
+	Selected      int // index into Options, -1 for none
+	Disabled      bool
+	FontSize      float32
+	TextColor     color.RGBA
+	AccentColor   color.RGBA
+	HoverColor    color.RGBA
+	DisabledColor color.RGBA
+	FocusColor    color.RGBA
+	OnChange      func(self *RadioGroup, index int, value string)
+	// HoveredOption and PressedOption index Options; -1 means none.
+	HoveredOption int
+	PressedOption int
+	IsFocused     bool
+	controlled    bool
+}
+
+func NewRadioGroup(options ...string) *RadioGroup {
+	theme := CurrentTheme()
+	r := &RadioGroup{
+		Component:     newComponentBase(RadioGroupKind),
+		Options:       options,
+		Selected:      -1,
+		FontSize:      theme.FontSizeBody,
+		TextColor:     theme.Text,
+		AccentColor:   theme.Primary,
+		HoverColor:    theme.PrimaryHover,
+		DisabledColor: theme.TextDisabled,
+		FocusColor:    theme.Focus,
+		HoveredOption: -1,
+		PressedOption: -1,
+	}
+	r.Component.setBorderColor(theme.Outline)
+	r.Component.setGap(math.Vec2f32{X: theme.Space(2), Y: theme.Space(1.5)})
+	return r
+}
+
+// Value returns the selected option, or "" if none is selected.
+func (r *RadioGroup) Value() string {
+	if r.Selected < 0 || r.Selected >= len(r.Options) {
+		return ""
+	}
+	return r.Options[r.Selected]
+}
+
+// Select selects option i and calls OnChange if the selection changed.
+// It does nothing while disabled or for an out of range index.
+func (r *RadioGroup) Select(i int) {
+	if r.Disabled || i < 0 || i >= len(r.Options) || i == r.Selected {
+		return
+	}
+	r.Selected = i
+	if r.OnChange != nil {
+		r.OnChange(r, i, r.Options[i])
+	}
+}
+
+// IndicatorSize is the diameter of each radio circle.
+func (r *RadioGroup) IndicatorSize() float32 { return r.FontSize + 2 }
+
+// RowHeight is the height of one option.
+func (r *RadioGroup) RowHeight() float32 { return max(r.IndicatorSize(), r.FontSize) }
+
+// OptionAt returns the option under point (relative to the content box),
+// or -1.
+func (r *RadioGroup) OptionAt(point math.Vec2f32) int {
+	if point.X < 0 || point.Y < 0 {
+		return -1
+	}
+	step := r.RowHeight() + r.Gap().Y
+	i := int(point.Y / step)
+	if i >= len(r.Options) || point.Y-float32(i)*step > r.RowHeight() {
+		return -1
+	}
+	return i
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (r *RadioGroup) SetID(id string) *RadioGroup {
+	r.Component.setID(id)
+	return r
+}
+
+func (r *RadioGroup) SetOptions(options ...string) *RadioGroup {
+	r.Options = options
+	return r
+}
+
+// SetSelected makes the group controlled: the given selection wins over the
+// one kept from the previous frame.
+func (r *RadioGroup) SetSelected(index int) *RadioGroup {
+	r.Selected = index
+	r.controlled = true
+	return r
+}
+
+func (r *RadioGroup) SetDisabled(disabled bool) *RadioGroup {
+	r.Disabled = disabled
+	return r
+}
+
+func (r *RadioGroup) SetOnChange(callback func(self *RadioGroup, index int, value string)) *RadioGroup {
+	r.OnChange = callback
+	return r
+}
+
+func (r *RadioGroup) SetFontSize(size float32) *RadioGroup {
+	if size > 0 {
+		r.FontSize = size
+	}
+	return r
+}
+
+func (r *RadioGroup) SetTextColor(color color.RGBA) *RadioGroup {
+	r.TextColor = color
+	return r
+}
+
+func (r *RadioGroup) SetAccentColor(color color.RGBA) *RadioGroup {
+	r.AccentColor = color
+	return r
+}
+
+func (r *RadioGroup) SetHoverColor(color color.RGBA) *RadioGroup {
+	r.HoverColor = color
+	return r
+}
+
+func (r *RadioGroup) SetGap(gap math.Vec2f32) *RadioGroup {
+	r.Component.setGap(gap)
+	return r
+}
+
+func (r *RadioGroup) SetDisplay(d Display) *RadioGroup {
+	r.Component.setDisplay(d)
+	return r
+}
+
+func (r *RadioGroup) SetPosition(pos Position) *RadioGroup {
+	r.Component.setPos(pos)
+	return r
+}
+
+func (r *RadioGroup) SetMargin(margin math.Vec2f32) *RadioGroup {
+	r.Component.setMargin(margin)
+	return r
+}
+
+func (r *RadioGroup) SetPadding(padding math.Vec2f32) *RadioGroup {
+	r.Component.setPadding(padding)
+	return r
+}
+
+func (r *RadioGroup) SetZIndex(zIndex int) *RadioGroup {
+	r.Component.setZIndex(zIndex)
+	return r
+}
+
+func (r *RadioGroup) SetTooltip(tooltip *Tooltip) *RadioGroup {
+	r.Component.setTooltip(tooltip)
+	return r
+}
+
+func (r *RadioGroup) SetTransform(transform *Transform) *RadioGroup {
+	r.Component.setTransform(transform)
+	return r
+}
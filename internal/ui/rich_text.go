@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Span
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Span is a run of text with its own style inside a RichText paragraph.
+// Unset font size and color are inherited from the RichText.
+type Span struct {
+	Text          string
+	Font          Font
+	FontSize      float32 // 0 inherits RichText.FontSize
+	Color         color.RGBA
+	Background    color.RGBA
+	Underline     bool
+	Strikethrough bool
+	OnClick       func(span *Span)
+	colorSet      bool
+}
+
+func NewSpan(text string) *Span {
+	return &Span{Text: text}
+}
+
+// NewLink creates an underlined, clickable span.
+func NewLink(text string, onClick func(span *Span)) *Span {
+	return NewSpan(text).
+		SetColor(CurrentTheme().Link).
+		SetUnderline(true).
+		SetOnClick(onClick)
+}
+
+func (s *Span) SetFont(font Font) *Span {
+	s.Font = font
+	return s
+}
+
+func (s *Span) SetFontFamily(family string) *Span {
+	s.Font.Family = family
+	return s
+}
+
+func (s *Span) SetFontWeight(weight FontWeight) *Span {
+	s.Font.Weight = weight
+	return s
+}
+
+func (s *Span) SetBold(bold bool) *Span {
+	if bold {
+		s.Font.Weight = FontWeightBold
+	} else {
+		s.Font.Weight = FontWeightRegular
+	}
+	return s
+}
+
+func (s *Span) SetFontSize(size float32) *Span {
+	if size < 0 {
+		size = 0
+	}
+	s.FontSize = size
+	return s
+}
+
+func (s *Span) SetColor(color color.RGBA) *Span {
+	s.Color = color
+	s.colorSet = true
+	return s
+}
+
+// SetBackground highlights the span with a filled rectangle behind its text.
+func (s *Span) SetBackground(color color.RGBA) *Span {
+	s.Background = color
+	return s
+}
+
+func (s *Span) SetUnderline(underline bool) *Span {
+	s.Underline = underline
+	return s
+}
+
+func (s *Span) SetStrikethrough(strikethrough bool) *Span {
+	s.Strikethrough = strikethrough
+	return s
+}
+
+func (s *Span) SetOnClick(callback func(span *Span)) *Span {
+	s.OnClick = callback
+	return s
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// RichText Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// RichText lays out styled spans as one flowing paragraph. Lines wrap at
+// whitespace across span boundaries and spans of different sizes share a
+// common baseline.
+type RichText struct {
+	Component
+	Spans      []*Span
+	Color      color.RGBA
+	FontSize   float32
+	LineHeight float32 // Multiple of the tallest span on each line
+	Wrapped    bool
+	// HoveredSpan and PressedSpan index Spans; -1 means none.
+	HoveredSpan int
+	PressedSpan int
+	fragments   []RichTextFragment
+	rtl         bool // laid out as a right-to-left paragraph
+}
+
+func NewRichText(spans ...*Span) *RichText {
+	theme := CurrentTheme()
+	return &RichText{
+		Component:   newComponentBase(RichTextKind),
+		Spans:       spans,
+		Color:       theme.Text,
+		FontSize:    theme.FontSizeBody,
+		LineHeight:  1.0,
+		Wrapped:     true,
+		HoveredSpan: -1,
+		PressedSpan: -1,
+	}
+}
+
+// Fragments returns the laid-out pieces of the paragraph, relative to its
+// content box. They are valid after layout.
+func (r *RichText) Fragments() []RichTextFragment { return r.fragments }
+
+// SpanFontSize returns the effective font size of span i.
+func (r *RichText) SpanFontSize(i int) float32 {
+	if r.Spans[i].FontSize > 0 {
+		return r.Spans[i].FontSize
+	}
+	return r.FontSize
+}
+
+// SpanColor returns the effective text color of span i.
+func (r *RichText) SpanColor(i int) color.RGBA {
+	if r.Spans[i].colorSet {
+		return r.Spans[i].Color
+	}
+	return r.Color
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (r *RichText) SetID(id string) *RichText {
+	r.Component.setID(id)
+	return r
+}
+
+func (r *RichText) AddSpan(span *Span) *RichText {
+	r.Spans = append(r.Spans, span)
+	return r
+}
+
+func (r *RichText) AddSpans(spans ...*Span) *RichText {
+	r.Spans = append(r.Spans, spans...)
+	return r
+}
+
+func (r *RichText) SetColor(color color.RGBA) *RichText {
+	r.Color = color
+	return r
+}
+
+func (r *RichText) SetFontSize(size float32) *RichText {
+	if size <= 0 {
+		size = 16.0 // Reset to default if invalid
+	}
+	r.FontSize = size
+	return r
+}
+
+func (r *RichText) SetLineHeight(lineHeight float32) *RichText {
+	if lineHeight <= 0 {
+		lineHeight = 1.0
+	}
+	r.LineHeight = lineHeight
+	return r
+}
+
+func (r *RichText) SetTextWrapped(wrapped bool) *RichText {
+	r.Wrapped = wrapped
+	return r
+}
+
+func (r *RichText) SetDisplay(d Display) *RichText {
+	r.Component.setDisplay(d)
+	return r
+}
+
+func (r *RichText) SetPosition(pos Position) *RichText {
+	r.Component.setPos(pos)
+	return r
+}
+
+func (r *RichText) SetSize(size math.Vec2f32) *RichText {
+	r.Component.setSize(size)
+	return r
+}
+
+func (r *RichText) SetMargin(margin math.Vec2f32) *RichText {
+	r.Component.setMargin(margin)
+	return r
+}
+
+func (r *RichText) SetPadding(padding math.Vec2f32) *RichText {
+	r.Component.setPadding(padding)
+	return r
+}
+
+func (r *RichText) SetBackgroundColor(color color.RGBA) *RichText {
+	r.Component.setBackgroundColor(color)
+	return r
+}
+
+func (r *RichText) SetZIndex(zIndex int) *RichText {
+	r.Component.setZIndex(zIndex)
+	return r
+}
+
+func (r *RichText) SetTooltip(tooltip *Tooltip) *RichText {
+	r.Component.setTooltip(tooltip)
+	return r
+}
+
+func (r *RichText) SetTransform(transform *Transform) *RichText {
+	r.Component.setTransform(transform)
+	return r
+}
+
+func (r *RichText) SetDirection(direction Direction) *RichText {
+	r.Component.setDirection(direction)
+	return r
+}
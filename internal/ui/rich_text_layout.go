@@ -0,0 +1,316 @@
+package ui
+
+import (
+	"slices"
+	"unicode"
+
+	"github.com/aj-2000/mogi/internal/bidi"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Rich text layout
+// ——————————————————————————————————————————————————————————————————————————————
+
+// RichTextFragment is a contiguous piece of a single span on a single line.
+type RichTextFragment struct {
+	Span int
+	Text string
+	// Pos is the top-left of the glyph box, relative to the content box.
+	Pos math.Vec2f32
+	// Size is the advance width and the font size of the span.
+	Size math.Vec2f32
+	// Baseline is the y of the line's baseline, relative to the content box.
+	Baseline float32
+	// x is the offset of the fragment from the left end of its line, which
+	// is lineWidth wide.
+	x, lineWidth float32
+}
+
+type richItemKind int
+
+const (
+	richItemWord richItemKind = iota
+	richItemSpace
+	richItemNewline
+)
+
+// richPiece is the part of a word (or a space) that belongs to one span.
+type richPiece struct {
+	span  int
+	text  string
+	width float32
+}
+
+type richItem struct {
+	kind   richItemKind
+	pieces []richPiece
+	width  float32
+}
+
+// MeasureFontText measures text in the given font, falling back to the
+// default font when no font-aware measurement is installed.
+func (le *LayoutEngine) MeasureFontText(font Font, text string, fontSize float32) float32 {
+	if le.MeasureText != nil {
+		return le.MeasureText(font, text, fontSize)
+	}
+	return le.CalculateTextWidth(text, fontSize)
+}
+
+// Ascent returns the distance from the top of the glyph box to the baseline.
+func (le *LayoutEngine) Ascent(font Font, fontSize float32) float32 {
+	if le.FontAscent != nil {
+		return le.FontAscent(font, fontSize)
+	}
+	return fontSize * 0.8
+}
+
+// tokenizeRichText splits spans into words, collapsed whitespace and hard
+// line breaks. Words may contain pieces from several adjacent spans.
+func (le *LayoutEngine) tokenizeRichText(r *RichText) []richItem {
+	var items []richItem
+	var word []rune
+	wordSpan := -1
+
+	flushPiece := func() {
+		if len(word) == 0 {
+			return
+		}
+		span := r.Spans[wordSpan]
+		text := string(word)
+		piece := richPiece{span: wordSpan, text: text, width: le.MeasureFontText(span.Font, text, r.SpanFontSize(wordSpan))}
+		if n := len(items); n > 0 && items[n-1].kind == richItemWord {
+			items[n-1].pieces = append(items[n-1].pieces, piece)
+			items[n-1].width += piece.width
+		} else {
+			items = append(items, richItem{kind: richItemWord, pieces: []richPiece{piece}, width: piece.width})
+		}
+		word = word[:0]
+	}
+
+	for i, span := range r.Spans {
+		for _, ch := range span.Text {
+			switch {
+			case ch == '\n':
+				flushPiece()
+				items = append(items, richItem{kind: richItemNewline})
+			case unicode.IsSpace(ch):
+				flushPiece()
+				if n := len(items); n > 0 && items[n-1].kind == richItemSpace {
+					continue
+				}
+				width := le.MeasureFontText(span.Font, " ", r.SpanFontSize(i))
+				items = append(items, richItem{kind: richItemSpace, pieces: []richPiece{{span: i, text: " ", width: width}}, width: width})
+			default:
+				if wordSpan != i {
+					flushPiece()
+					wordSpan = i
+				}
+				word = append(word, ch)
+			}
+		}
+		flushPiece()
+		wordSpan = -1
+	}
+	return items
+}
+
+// LayoutRichText breaks the spans of r into lines no wider than maxWidth,
+// stores the resulting fragments on r and returns the content size. Each
+// line is reordered for display as a whole, so fragments read in visual
+// order from left to right; RTL lines are moved against the right edge by
+// alignFragments once the content box is known.
+func (le *LayoutEngine) LayoutRichText(r *RichText, maxWidth float32) math.Vec2f32 {
+	items := le.tokenizeRichText(r)
+
+	var lines [][]richPiece
+	var line []richPiece
+	var pendingSpace *richPiece
+	lineWidth := float32(0)
+
+	flush := func() {
+		lines = append(lines, line)
+		line = nil
+		lineWidth = 0
+		pendingSpace = nil
+	}
+
+	for _, item := range items {
+		switch item.kind {
+		case richItemNewline:
+			flush()
+		case richItemSpace:
+			if len(line) > 0 {
+				space := item.pieces[0]
+				pendingSpace = &space
+			}
+		case richItemWord:
+			need := item.width
+			if pendingSpace != nil {
+				need += pendingSpace.width
+			}
+			if r.Wrapped && len(line) > 0 && lineWidth+need > maxWidth {
+				flush()
+			}
+			if pendingSpace != nil {
+				line = append(line, *pendingSpace)
+				lineWidth += pendingSpace.width
+				pendingSpace = nil
+			}
+			if r.Wrapped && len(line) == 0 && item.width > maxWidth {
+				// The word alone is too wide: break it between characters.
+				chunks := le.breakRichWord(r, item, maxWidth)
+				for _, chunk := range chunks[:len(chunks)-1] {
+					line = chunk.pieces
+					flush()
+				}
+				item = chunks[len(chunks)-1]
+			}
+			line = append(line, item.pieces...)
+			lineWidth += item.width
+		}
+	}
+	flush()
+
+	var content []byte
+	for _, span := range r.Spans {
+		content = append(content, span.Text...)
+	}
+	base := textDirection(r, string(content))
+	r.rtl = base == bidi.RightToLeft
+
+	r.fragments = r.fragments[:0]
+	var size math.Vec2f32
+	for _, pieces := range lines {
+		// Align every piece on the line to the deepest ascent.
+		maxAscent := float32(0)
+		maxDescent := float32(0)
+		if len(pieces) == 0 {
+			maxAscent = le.Ascent(Font{}, r.FontSize)
+			maxDescent = r.FontSize - maxAscent
+		}
+		for _, p := range pieces {
+			fontSize := r.SpanFontSize(p.span)
+			ascent := le.Ascent(r.Spans[p.span].Font, fontSize)
+			maxAscent = max(maxAscent, ascent)
+			maxDescent = max(maxDescent, fontSize-ascent)
+		}
+		naturalHeight := maxAscent + maxDescent
+		lineHeight := naturalHeight * r.LineHeight
+		baseline := size.Y + (lineHeight-naturalHeight)/2 + maxAscent
+
+		x := float32(0)
+		lineStart := len(r.fragments)
+		for _, run := range le.visualRuns(r, pieces, base) {
+			fontSize := r.SpanFontSize(run.span)
+			r.fragments = append(r.fragments, RichTextFragment{
+				Span:     run.span,
+				Text:     run.text,
+				Pos:      math.Vec2f32{X: x, Y: baseline - le.Ascent(r.Spans[run.span].Font, fontSize)},
+				Size:     math.Vec2f32{X: run.width, Y: fontSize},
+				Baseline: baseline,
+				x:        x,
+			})
+			x += run.width
+		}
+		for i := lineStart; i < len(r.fragments); i++ {
+			r.fragments[i].lineWidth = x
+		}
+
+		size.X = max(size.X, x)
+		size.Y += lineHeight
+	}
+	return size
+}
+
+// breakRichWord splits a word wider than maxWidth into chunks that each
+// fit, as breakWord does for plain text. Every chunk holds at least one
+// character so that layout always makes progress.
+func (le *LayoutEngine) breakRichWord(r *RichText, word richItem, maxWidth float32) []richItem {
+	var chunks []richItem
+	chunk := richItem{kind: richItemWord}
+	for _, p := range word.pieces {
+		font, fontSize := r.Spans[p.span].Font, r.SpanFontSize(p.span)
+		runes := []rune(p.text)
+		for start := 0; start < len(runes); {
+			end, width := start, float32(0)
+			for end < len(runes) {
+				w := le.MeasureFontText(font, string(runes[start:end+1]), fontSize)
+				if chunk.width+w > maxWidth && (end > start || len(chunk.pieces) > 0) {
+					break
+				}
+				end, width = end+1, w
+			}
+			if end > start {
+				chunk.pieces = append(chunk.pieces, richPiece{span: p.span, text: string(runes[start:end]), width: width})
+				chunk.width += width
+				start = end
+			}
+			if start < len(runes) {
+				chunks = append(chunks, chunk)
+				chunk = richItem{kind: richItemWord}
+			}
+		}
+	}
+	return append(chunks, chunk)
+}
+
+// visualRuns splits a line into runs of one span at one bidi level, with
+// the levels resolved over the whole line, and returns them in visual
+// order with their text shaped and reordered.
+func (le *LayoutEngine) visualRuns(r *RichText, pieces []richPiece, base bidi.Direction) []richPiece {
+	var runes []rune
+	var spans []int
+	for _, p := range pieces {
+		for _, ch := range p.text {
+			runes = append(runes, ch)
+			spans = append(spans, p.span)
+		}
+	}
+	levels := bidi.Levels(runes, base)
+
+	var runs []richPiece
+	var runLevels []uint8
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && spans[end] == spans[start] && levels[end] == levels[start] {
+			end++
+		}
+		runs = append(runs, richPiece{span: spans[start], text: string(runes[start:end])})
+		runLevels = append(runLevels, levels[start])
+		start = end
+	}
+
+	visual := make([]richPiece, len(runs))
+	for i, j := range bidi.VisualOrder(runLevels) {
+		run := runs[j]
+		run.width = le.MeasureFontText(r.Spans[run.span].Font, run.text, r.SpanFontSize(run.span))
+		shaped := []rune(bidi.Shape(run.text))
+		run.text = string(bidi.Reorder(shaped, slices.Repeat([]uint8{runLevels[j]}, len(shaped))))
+		visual[i] = run
+	}
+	return visual
+}
+
+// alignFragments places the laid out lines in a content box width wide:
+// at its left edge, or at its right edge in RTL paragraphs.
+func (r *RichText) alignFragments(width float32) {
+	for i := range r.fragments {
+		f := &r.fragments[i]
+		f.Pos.X = f.x
+		if r.rtl {
+			f.Pos.X = width - f.lineWidth + f.x
+		}
+	}
+}
+
+// SpanAt returns the index of the span under point (relative to the content
+// box), or -1.
+func (r *RichText) SpanAt(point math.Vec2f32) int {
+	for _, f := range r.fragments {
+		if (math.Rect{Pos: f.Pos, Size: f.Size}).Contains(point) {
+			return f.Span
+		}
+	}
+	return -1
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fragmentsString writes fragments as "text@x", with " / " between lines.
+func fragmentsString(r *RichText) string {
+	var b strings.Builder
+	for i, f := range r.Fragments() {
+		if i > 0 {
+			if f.Baseline != r.Fragments()[i-1].Baseline {
+				b.WriteString(" / ")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+		fmt.Fprintf(&b, "%s@%g", f.Text, f.Pos.X)
+	}
+	return b.String()
+}
+
+func TestLayoutRichText(t *testing.T) {
+	tests := []struct {
+		name  string
+		spans []string
+		rtl   bool
+		wrap  float32 // width lines wrap at
+		width float32 // of the content box
+		want  string
+	}{
+		{
+			name:  "words wrap",
+			spans: []string{"ab cd ", "ef"},
+			wrap:  50,
+			width: 50,
+			want:  "ab cd@0 / ef@0",
+		},
+		{
+			name:  "long word breaks across spans",
+			spans: []string{"ab", "cdefgh"},
+			wrap:  30,
+			width: 30,
+			want:  "ab@0 c@20 / def@0 / gh@0",
+		},
+		{
+			name:  "long word after a short one",
+			spans: []string{"a bcdef"},
+			wrap:  30,
+			width: 30,
+			want:  "a@0 / bcd@0 / ef@0",
+		},
+		{
+			name:  "hebrew word split across spans",
+			spans: []string{"abc ", "אב", "ג ", "def"},
+			wrap:  200,
+			width: 200,
+			want:  "abc @0 ג@40 בא@50  @70 def@80",
+		},
+		{
+			name:  "rtl lines end at the content box edge",
+			spans: []string{"אבג ", "דה"},
+			wrap:  40,
+			width: 100,
+			want:  "גבא@70 / הד@80",
+		},
+		{
+			name:  "rtl line of several spans",
+			spans: []string{"אב ", "abc"},
+			rtl:   true,
+			wrap:  100,
+			width: 100,
+			want:  "abc@40  בא@70",
+		},
+	}
+	le := NewLayoutEngine(monospace)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRichText().SetTextWrapped(true)
+			for _, text := range tt.spans {
+				r.AddSpan(NewSpan(text))
+			}
+			if tt.rtl {
+				r.SetDirection(DirectionRTL)
+			}
+			le.LayoutRichText(r, tt.wrap)
+			r.alignFragments(tt.width)
+			if got := fragmentsString(r); got != tt.want {
+				t.Errorf("fragments = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,430 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Select Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// TypeAheadTimeout is how long, in seconds, typed characters keep adding to
+// a select's type-ahead search before it starts over.
+const TypeAheadTimeout = 1.0
+
+// DefaultSelectRows is how many options a select's popup shows at once
+// before it scrolls.
+const DefaultSelectRows = 8
+
+// Select shows the chosen option and opens a popup list of all options in
+// the overlay layer when clicked. A searchable select (see NewComboBox)
+// filters the list by the text typed while it is open.
+//
+// Like the other inputs, the selection and open state are kept between
+// frames; call SetSelected every frame to control the selection instead.
+type Select struct {
+	Component
+	Options     []string
+	Selected    int // index into Options, -1 for none
+	Placeholder string
+	Searchable  bool
+	Query       string // search text of a searchable select
+	Open        bool
+	// Highlighted is the option (index into Options) under the keyboard
+	// cursor or the mouse while the popup is open; -1 for none.
+	Highlighted   int
+	PressedOption int // option row pressed in the popup, -1 for none
+	// MaxRows is how many options the popup shows at once; it scrolls to
+	// the rest. 0 shows them all.
+	MaxRows int
+	// ScrollRow is the first of VisibleOptions shown in the popup.
+	ScrollRow        int
+	Disabled         bool
+	FontSize         float32
+	TextColor        color.RGBA
+	PlaceholderColor color.RGBA
+	HoverColor       color.RGBA
+	HighlightColor   color.RGBA
+	PopupColor       color.RGBA
+	DisabledColor    color.RGBA
+	FocusColor       color.RGBA
+	OnChange         func(self *Select, index int, value string)
+	IsMouseOver      bool
+	IsPressed        bool
+	IsFocused        bool
+	controlled       bool
+	typeAhead        string
+	typedAt          float64
+}
+
+func NewSelect(options ...string) *Select {
+	theme := CurrentTheme()
+	s := &Select{
+		Component:        newComponentBase(SelectKind),
+		Options:          options,
+		Selected:         -1,
+		Highlighted:      -1,
+		PressedOption:    -1,
+		MaxRows:          DefaultSelectRows,
+		FontSize:         theme.FontSizeBody,
+		TextColor:        theme.Text,
+		PlaceholderColor: theme.TextMuted,
+		HoverColor:       theme.SurfaceHover,
+		HighlightColor:   theme.Primary,
+		PopupColor:       theme.Surface,
+		DisabledColor:    theme.TextDisabled,
+		FocusColor:       theme.Focus,
+	}
+	s.Component.setDisplay(DisplayBlock)
+	s.Component.setPadding(math.Vec2f32{X: theme.Space(2.5), Y: theme.Space(1.5)})
+	s.Component.setBorder(math.Vec2f32{X: 1, Y: 1})
+	s.Component.setBorderRadius(theme.RadiusSmall)
+	s.Component.setBorderColor(theme.Outline)
+	s.Component.setBackgroundColor(theme.Surface)
+	return s
+}
+
+// SelectArrowWidth is the space a select reserves for its arrow.
+func SelectArrowWidth(fontSize float32) float32 { return fontSize }
+
+// NewComboBox returns a searchable Select: while open, typed text filters
+// the options.
+func NewComboBox(options ...string) *Select {
+	s := NewSelect(options...)
+	s.Component.kind = ComboBoxKind
+	s.Searchable = true
+	return s
+}
+
+// Value returns the selected option, or "" if none is selected.
+func (s *Select) Value() string {
+	if s.Selected < 0 || s.Selected >= len(s.Options) {
+		return ""
+	}
+	return s.Options[s.Selected]
+}
+
+// Label is the text shown in the closed box: the search text of an open
+// combo box, the selected option, or the placeholder.
+func (s *Select) Label() string {
+	if s.Searchable && s.Open {
+		return s.Query
+	}
+	if v := s.Value(); v != "" {
+		return v
+	}
+	return s.Placeholder
+}
+
+// VisibleOptions returns the indices of the options listed in the popup. A
+// searchable select lists the options containing the query, ignoring case.
+func (s *Select) VisibleOptions() []int {
+	query := strings.ToLower(s.Query)
+	visible := make([]int, 0, len(s.Options))
+	for i, option := range s.Options {
+		if !s.Searchable || query == "" || strings.Contains(strings.ToLower(option), query) {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// Choose selects option i, closes the popup and calls OnChange if the
+// selection changed.
+func (s *Select) Choose(i int) {
+	s.Close()
+	if s.Disabled || i < 0 || i >= len(s.Options) || i == s.Selected {
+		return
+	}
+	s.Selected = i
+	if s.OnChange != nil {
+		s.OnChange(s, i, s.Options[i])
+	}
+}
+
+// SetOpen opens the popup, highlighting the selected option, or closes it.
+func (s *Select) SetOpen(open bool) {
+	if !open {
+		s.Close()
+		return
+	}
+	if s.Disabled || s.Open {
+		return
+	}
+	s.Open = true
+	s.Query = ""
+	s.Highlighted = s.Selected
+	s.ScrollRow = 0
+	s.scrollToHighlight()
+}
+
+// Close closes the popup and clears the search text.
+func (s *Select) Close() {
+	s.Open = false
+	s.Query = ""
+	s.Highlighted = -1
+	s.ScrollRow = 0
+}
+
+// PopupRows returns the range of VisibleOptions the popup shows.
+func (s *Select) PopupRows() (first, last int) {
+	n := len(s.VisibleOptions())
+	rows := n
+	if s.MaxRows > 0 {
+		rows = min(n, s.MaxRows)
+	}
+	first = max(0, min(s.ScrollRow, n-rows))
+	return first, first + rows
+}
+
+// ScrollRows scrolls the popup by delta rows, clamped to the list.
+func (s *Select) ScrollRows(delta int) {
+	first, last := s.PopupRows()
+	s.ScrollRow = max(0, min(first+delta, len(s.VisibleOptions())-(last-first)))
+}
+
+// scrollToHighlight scrolls the popup just far enough to show the
+// highlighted option.
+func (s *Select) scrollToHighlight() {
+	pos := slices.Index(s.VisibleOptions(), s.Highlighted)
+	if pos < 0 {
+		return
+	}
+	first, last := s.PopupRows()
+	if pos < first {
+		s.ScrollRow = pos
+	} else if pos >= last {
+		s.ScrollRow = pos - (last - first) + 1
+	}
+}
+
+// MoveHighlight moves the highlight by delta visible options, clamped to
+// the list, and scrolls the popup to it.
+func (s *Select) MoveHighlight(delta int) {
+	visible := s.VisibleOptions()
+	if len(visible) == 0 {
+		s.Highlighted = -1
+		return
+	}
+	pos := -1
+	for i, option := range visible {
+		if option == s.Highlighted {
+			pos = i
+		}
+	}
+	if pos < 0 {
+		if delta > 0 {
+			pos = -1
+		} else {
+			pos = len(visible)
+		}
+	}
+	pos = max(0, min(len(visible)-1, pos+delta))
+	s.Highlighted = visible[pos]
+	s.scrollToHighlight()
+}
+
+// Type handles text typed while the select has focus at time now (in
+// seconds). A searchable select appends it to the query; otherwise it is a
+// type-ahead search that highlights (or, when closed, selects) the next
+// option starting with the typed prefix.
+func (s *Select) Type(text string, now float64) {
+	if text == "" || s.Disabled {
+		return
+	}
+	if s.Searchable {
+		s.SetOpen(true)
+		if s.Query == "" {
+			text = strings.TrimLeft(text, " ")
+		}
+		s.Query += text
+		s.Highlighted = -1
+		s.MoveHighlight(1)
+		return
+	}
+
+	if now-s.typedAt > TypeAheadTimeout {
+		s.typeAhead = ""
+	}
+	s.typedAt = now
+	s.typeAhead += strings.ToLower(text)
+
+	current := s.Highlighted
+	if !s.Open {
+		current = s.Selected
+	}
+	// A repeated single letter cycles through matches; a longer prefix
+	// stays on the current option while it still matches.
+	start := current + 1
+	if len(s.typeAhead) > 1 {
+		start = max(current, 0)
+	}
+	for n := range s.Options {
+		i := (start + n) % len(s.Options)
+		if strings.HasPrefix(strings.ToLower(s.Options[i]), s.typeAhead) {
+			if s.Open {
+				s.Highlighted = i
+				s.scrollToHighlight()
+			} else {
+				s.Choose(i)
+			}
+			return
+		}
+	}
+}
+
+// Backspace removes the last character of a searchable select's query.
+func (s *Select) Backspace() {
+	if !s.Searchable || s.Query == "" {
+		return
+	}
+	runes := []rune(s.Query)
+	s.Query = string(runes[:len(runes)-1])
+	s.Highlighted = -1
+	s.MoveHighlight(1)
+}
+
+// Popup builds the option list shown in the overlay layer, width wide:
+// the rows of PopupRows. Each row is a Container whose position in
+// Children, counted from the first of PopupRows, matches VisibleOptions.
+func (s *Select) Popup(width float32) *Container {
+	popup := NewContainer().
+		SetDisplay(DisplayBlock).
+		SetBackgroundColor(s.PopupColor).
+		SetBorder(math.Vec2f32{X: 1, Y: 1}).
+		SetBorderColor(s.BorderColor()).
+		SetBorderRadius(s.BorderRadius()).
+		SetSize(math.Vec2f32{X: width})
+	rowWidth := width - 2*popup.Border().X
+	first, last := s.PopupRows()
+	for _, i := range s.VisibleOptions()[first:last] {
+		background := color.Transparent
+		if i == s.Highlighted {
+			background = s.HighlightColor
+		} else if i == s.Selected {
+			background = s.HoverColor
+		}
+		popup.AddChild(NewContainer().
+			SetDisplay(DisplayBlock).
+			SetSize(math.Vec2f32{X: rowWidth}).
+			SetPadding(s.Padding()).
+			SetBackgroundColor(background).
+			AddChild(NewText(s.Options[i]).SetFontSize(s.FontSize).SetColor(s.TextColor)))
+	}
+	if len(popup.Children()) == 0 {
+		popup.AddChild(NewContainer().
+			SetDisplay(DisplayBlock).
+			SetPadding(s.Padding()).
+			AddChild(NewText("No matches").SetFontSize(s.FontSize).SetColor(s.PlaceholderColor)))
+	}
+	popup.SetDirection(s.Direction())
+	return popup
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (s *Select) SetID(id string) *Select {
+	s.Component.setID(id)
+	return s
+}
+
+func (s *Select) SetOptions(options ...string) *Select {
+	s.Options = options
+	return s
+}
+
+// SetSelected makes the select controlled: the given selection wins over
+// the one kept from the previous frame.
+func (s *Select) SetSelected(index int) *Select {
+	s.Selected = index
+	s.controlled = true
+	return s
+}
+
+func (s *Select) SetPlaceholder(placeholder string) *Select {
+	s.Placeholder = placeholder
+	return s
+}
+
+func (s *Select) SetDisabled(disabled bool) *Select {
+	s.Disabled = disabled
+	return s
+}
+
+func (s *Select) SetOnChange(callback func(self *Select, index int, value string)) *Select {
+	s.OnChange = callback
+	return s
+}
+
+func (s *Select) SetFontSize(size float32) *Select {
+	if size > 0 {
+		s.FontSize = size
+	}
+	return s
+}
+
+func (s *Select) SetTextColor(color color.RGBA) *Select {
+	s.TextColor = color
+	return s
+}
+
+func (s *Select) SetHighlightColor(color color.RGBA) *Select {
+	s.HighlightColor = color
+	return s
+}
+
+func (s *Select) SetPopupColor(color color.RGBA) *Select {
+	s.PopupColor = color
+	return s
+}
+
+func (s *Select) SetBackgroundColor(color color.RGBA) *Select {
+	s.Component.setBackgroundColor(color)
+	return s
+}
+
+func (s *Select) SetSize(size math.Vec2f32) *Select {
+	s.Component.setSize(size)
+	return s
+}
+
+func (s *Select) SetDisplay(d Display) *Select {
+	s.Component.setDisplay(d)
+	return s
+}
+
+func (s *Select) SetPosition(pos Position) *Select {
+	s.Component.setPos(pos)
+	return s
+}
+
+func (s *Select) SetMargin(margin math.Vec2f32) *Select {
+	s.Component.setMargin(margin)
+	return s
+}
+
+func (s *Select) SetPadding(padding math.Vec2f32) *Select {
+	s.Component.setPadding(padding)
+	return s
+}
+
+func (s *Select) SetZIndex(zIndex int) *Select {
+	s.Component.setZIndex(zIndex)
+	return s
+}
+
+func (s *Select) SetTooltip(tooltip *Tooltip) *Select {
+	s.Component.setTooltip(tooltip)
+	return s
+}
+
+func (s *Select) SetTransform(transform *Transform) *Select {
+	s.Component.setTransform(transform)
+	return s
+}
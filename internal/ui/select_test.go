@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+)
+
+func manyOptions(n int) []string {
+	options := make([]string, n)
+	for i := range options {
+		options[i] = fmt.Sprintf("option %02d", i)
+	}
+	return options
+}
+
+// TestSelectPopupScrollsToHighlight moves the highlight through a long
+// list and checks that the popup builds MaxRows rows, the highlighted one
+// among them.
+func TestSelectPopupScrollsToHighlight(t *testing.T) {
+	tests := []struct {
+		name      string
+		selected  int
+		moves     []int
+		wantFirst int
+	}{
+		{name: "opens at the top", selected: -1, wantFirst: 0},
+		{name: "opens at the selection", selected: 20, wantFirst: 13},
+		{name: "down past the last row", selected: 0, moves: []int{8}, wantFirst: 1},
+		{name: "end", selected: 0, moves: []int{30}, wantFirst: 22},
+		{name: "end then up within the rows", selected: 0, moves: []int{30, -3}, wantFirst: 22},
+		{name: "end then home", selected: 0, moves: []int{30, -30}, wantFirst: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSelect(manyOptions(30)...)
+			s.Selected = tt.selected
+			s.SetOpen(true)
+			for _, d := range tt.moves {
+				s.MoveHighlight(d)
+			}
+			first, last := s.PopupRows()
+			if first != tt.wantFirst || last-first != DefaultSelectRows {
+				t.Errorf("rows %d to %d, want %d to %d", first, last, tt.wantFirst, tt.wantFirst+DefaultSelectRows)
+			}
+			if n := len(s.Popup(100).Children()); n != DefaultSelectRows {
+				t.Errorf("popup has %d rows, want %d", n, DefaultSelectRows)
+			}
+			if s.Highlighted >= 0 && (s.Highlighted < first || s.Highlighted >= last) {
+				t.Errorf("highlight %d is outside rows %d to %d", s.Highlighted, first, last)
+			}
+		})
+	}
+}
+
+func TestSelectPopupScrollRows(t *testing.T) {
+	s := NewSelect(manyOptions(12)...)
+	s.SetOpen(true)
+	s.ScrollRows(3)
+	if first, _ := s.PopupRows(); first != 3 {
+		t.Errorf("scrolled to %d, want 3", first)
+	}
+	s.ScrollRows(10)
+	if first, last := s.PopupRows(); first != 4 || last != 12 {
+		t.Errorf("scrolled past the end to %d to %d, want 4 to 12", first, last)
+	}
+	s.ScrollRows(-10)
+	if first, _ := s.PopupRows(); first != 0 {
+		t.Errorf("scrolled past the top to %d, want 0", first)
+	}
+
+	// A short or filtered list shows all of its rows.
+	s = NewComboBox(manyOptions(12)...)
+	s.SetOpen(true)
+	s.ScrollRows(4)
+	s.Type("1", 0)
+	if first, last := s.PopupRows(); first != 0 || last != len(s.VisibleOptions()) {
+		t.Errorf("filtered rows %d to %d, want all %d", first, last, len(s.VisibleOptions()))
+	}
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	gomath "math"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Skeleton Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// SkeletonShape is the outline of the content a Skeleton stands in for.
+type SkeletonShape int
+
+const (
+	SkeletonRect SkeletonShape = iota
+	// SkeletonCircle stands in for an avatar or icon; it pulses rather than
+	// shimmers.
+	SkeletonCircle
+	// SkeletonText stands in for a paragraph, a bar per line with the last
+	// one shorter.
+	SkeletonText
+)
+
+const (
+	// SkeletonLastLine is how much of the width the last line of a text
+	// skeleton covers.
+	SkeletonLastLine float32 = 0.6
+	// SkeletonBand is how much of the width the shimmer band covers.
+	SkeletonBand float32 = 0.4
+)
+
+// Skeleton is a placeholder in the shape of content that is still loading,
+// with a highlight sweeping across it.
+type Skeleton struct {
+	Component
+	animator
+	Shape SkeletonShape
+	// Width and Height are the size of a rect or circle, and the width and
+	// height of each line of text.
+	Width   float32
+	Height  float32
+	Lines   int
+	LineGap float32
+	// Speed is how many times the shimmer crosses per second.
+	Speed          float32
+	BaseColor      color.RGBA
+	HighlightColor color.RGBA
+}
+
+func NewSkeleton(shape SkeletonShape) *Skeleton {
+	theme := CurrentTheme()
+	s := &Skeleton{
+		Component:      newComponentBase(SkeletonKind),
+		Shape:          shape,
+		Width:          200,
+		Height:         16,
+		Lines:          3,
+		LineGap:        theme.Space(2),
+		Speed:          0.8,
+		BaseColor:      theme.SurfaceRaised,
+		HighlightColor: theme.Tinted(0.12),
+	}
+	if shape == SkeletonCircle {
+		s.Width, s.Height = 40, 40
+	}
+	s.setBorderRadius(theme.RadiusSmall)
+	return s
+}
+
+func (s *Skeleton) Advance(dt float32) { s.cycle(dt, s.Speed) }
+
+// Bars returns the rectangles, relative to the content box, that make up the
+// skeleton when its content box is width wide.
+func (s *Skeleton) Bars(width float32) []math.Vec2f32 {
+	switch s.Shape {
+	case SkeletonText:
+		n := max(1, s.Lines)
+		bars := make([]math.Vec2f32, 0, 2*n)
+		for i := range n {
+			w := width
+			if i == n-1 && n > 1 {
+				w *= SkeletonLastLine
+			}
+			bars = append(bars, math.Vec2f32{X: 0, Y: float32(i) * (s.Height + s.LineGap)}, math.Vec2f32{X: w, Y: s.Height})
+		}
+		return bars
+	case SkeletonCircle:
+		d := min(width, s.Height)
+		return []math.Vec2f32{{}, {X: d, Y: d}}
+	default:
+		return []math.Vec2f32{{}, {X: width, Y: s.Height}}
+	}
+}
+
+// ContentHeight returns the height of the skeleton's content box.
+func (s *Skeleton) ContentHeight() float32 {
+	if s.Shape != SkeletonText {
+		return s.Height
+	}
+	n := float32(max(1, s.Lines))
+	return n*s.Height + (n-1)*s.LineGap
+}
+
+// Band returns where the shimmer band starts, as a fraction of the width;
+// it enters from the left edge and leaves past the right one.
+func (s *Skeleton) Band() float32 {
+	return s.Phase()*(1+SkeletonBand) - SkeletonBand
+}
+
+// Pulse returns how strongly a circle skeleton is lit, rising and falling
+// between 0 and 1 once a cycle.
+func (s *Skeleton) Pulse() float32 {
+	return float32(1-gomath.Cos(2*gomath.Pi*float64(s.Phase()))) / 2
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (s *Skeleton) SetID(id string) *Skeleton {
+	s.Component.setID(id)
+	return s
+}
+
+func (s *Skeleton) SetSize(width, height float32) *Skeleton {
+	s.Width, s.Height = width, height
+	return s
+}
+
+func (s *Skeleton) SetLines(lines int) *Skeleton {
+	s.Lines = max(1, lines)
+	return s
+}
+
+func (s *Skeleton) SetLineGap(gap float32) *Skeleton {
+	s.LineGap = gap
+	return s
+}
+
+func (s *Skeleton) SetSpeed(speed float32) *Skeleton {
+	s.Speed = speed
+	return s
+}
+
+func (s *Skeleton) SetBaseColor(color color.RGBA) *Skeleton {
+	s.BaseColor = color
+	return s
+}
+
+func (s *Skeleton) SetHighlightColor(color color.RGBA) *Skeleton {
+	s.HighlightColor = color
+	return s
+}
+
+func (s *Skeleton) SetBorderRadius(radius float32) *Skeleton {
+	s.Component.setBorderRadius(radius)
+	return s
+}
+
+func (s *Skeleton) SetDisplay(d Display) *Skeleton {
+	s.Component.setDisplay(d)
+	return s
+}
+
+func (s *Skeleton) SetPosition(pos Position) *Skeleton {
+	s.Component.setPos(pos)
+	return s
+}
+
+func (s *Skeleton) SetMargin(margin math.Vec2f32) *Skeleton {
+	s.Component.setMargin(margin)
+	return s
+}
+
+func (s *Skeleton) SetWidthPercent(widthPercent float32) *Skeleton {
+	s.Component.setWidthPercent(widthPercent)
+	return s
+}
+
+func (s *Skeleton) SetZIndex(zIndex int) *Skeleton {
+	s.Component.setZIndex(zIndex)
+	return s
+}
+
+func (s *Skeleton) SetTransform(transform *Transform) *Skeleton {
+	s.Component.setTransform(transform)
+	return s
+}
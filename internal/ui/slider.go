@@ -0,0 +1,622 @@
+package ui
+
+import (
+	"fmt"
+	gomath "math"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Slider Base
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Orientation is the axis a slider moves along.
+type Orientation int
+
+const (
+	OrientationHorizontal Orientation = iota
+	OrientationVertical
+)
+
+// SliderValueGap is the space between a slider track and its value label.
+const SliderValueGap float32 = 8
+
+// SliderBase holds the range, geometry and look shared by Slider and
+// RangeSlider. Geometry helpers work in the content box of the component:
+// the track runs along the main axis from 0 to Length and is centred on the
+// cross axis. Horizontal sliders grow towards the end edge (right in LTR,
+// left in RTL); vertical sliders grow upwards.
+type SliderBase struct {
+	Min, Max       float32
+	Step           float32 // 0 for continuous values
+	Orientation    Orientation
+	Length         float32 // length of the track
+	TrackThickness float32
+	ThumbSize      float32
+	Ticks          int // number of tick intervals, 0 for none
+	ShowValue      bool
+	ValueFormat    string // fmt verb for the value label
+	Disabled       bool
+	FontSize       float32
+	TextColor      color.RGBA
+	TrackColor     color.RGBA
+	AccentColor    color.RGBA // filled part of the track
+	ThumbColor     color.RGBA
+	HoverColor     color.RGBA
+	DisabledColor  color.RGBA
+	FocusColor     color.RGBA
+	IsFocused      bool
+	rtl            bool
+}
+
+func newSliderBase() SliderBase {
+	theme := CurrentTheme()
+	return SliderBase{
+		Max:            100,
+		Length:         200,
+		TrackThickness: 4,
+		ThumbSize:      16,
+		ValueFormat:    "%.0f",
+		FontSize:       theme.FontSizeSmall,
+		TextColor:      theme.Text,
+		TrackColor:     theme.Track,
+		AccentColor:    theme.Primary,
+		ThumbColor:     theme.Knob,
+		HoverColor:     theme.PrimaryHover,
+		DisabledColor:  theme.TextDisabled,
+		FocusColor:     theme.Focus,
+	}
+}
+
+func (b *SliderBase) IsVertical() bool { return b.Orientation == OrientationVertical }
+
+// Snap clamps v to [Min, Max] and rounds it to the nearest step.
+func (b *SliderBase) Snap(v float32) float32 {
+	lo, hi := min(b.Min, b.Max), max(b.Min, b.Max)
+	v = max(lo, min(hi, v))
+	if b.Step > 0 {
+		steps := gomath.Round(float64((v - b.Min) / b.Step))
+		v = max(lo, min(hi, b.Min+float32(steps)*b.Step))
+	}
+	return v
+}
+
+// Fraction maps v to [0, 1] along the range.
+func (b *SliderBase) Fraction(v float32) float32 {
+	if b.Max == b.Min {
+		return 0
+	}
+	return max(0, min(1, (v-b.Min)/(b.Max-b.Min)))
+}
+
+// KeyStep is the amount an arrow key moves a thumb.
+func (b *SliderBase) KeyStep() float32 {
+	if b.Step > 0 {
+		return b.Step
+	}
+	return (b.Max - b.Min) / 100
+}
+
+// Format returns the value label for v.
+func (b *SliderBase) Format(v float32) string {
+	return fmt.Sprintf(b.ValueFormat, v)
+}
+
+// TickValues returns the values at which tick marks are drawn.
+func (b *SliderBase) TickValues() []float32 {
+	if b.Ticks <= 0 {
+		return nil
+	}
+	values := make([]float32, b.Ticks+1)
+	for i := range values {
+		values[i] = b.Min + (b.Max-b.Min)*float32(i)/float32(b.Ticks)
+	}
+	return values
+}
+
+// axisOffset is the distance of v's thumb centre from the start of the track.
+func (b *SliderBase) axisOffset(v float32) float32 {
+	travel := max(0, b.Length-b.ThumbSize)
+	f := b.Fraction(v)
+	if b.IsVertical() || b.rtl {
+		f = 1 - f
+	}
+	return b.ThumbSize/2 + f*travel
+}
+
+// ThumbCenter returns the centre of v's thumb in a content box of the given
+// size.
+func (b *SliderBase) ThumbCenter(v float32, content math.Vec2f32) math.Vec2f32 {
+	if b.IsVertical() {
+		return math.Vec2f32{X: content.X / 2, Y: b.axisOffset(v)}
+	}
+	return math.Vec2f32{X: b.axisOffset(v), Y: content.Y / 2}
+}
+
+// TrackRect returns the position and size of the track in a content box of
+// the given size.
+func (b *SliderBase) TrackRect(content math.Vec2f32) (math.Vec2f32, math.Vec2f32) {
+	if b.IsVertical() {
+		return math.Vec2f32{X: (content.X - b.TrackThickness) / 2}, math.Vec2f32{X: b.TrackThickness, Y: b.Length}
+	}
+	return math.Vec2f32{Y: (content.Y - b.TrackThickness) / 2}, math.Vec2f32{X: b.Length, Y: b.TrackThickness}
+}
+
+// OnTrack reports whether point (relative to the content box) is over the
+// track area, which spans the full cross axis.
+func (b *SliderBase) OnTrack(point math.Vec2f32) bool {
+	axis := point.X
+	if b.IsVertical() {
+		axis = point.Y
+	}
+	return axis >= 0 && axis <= b.Length
+}
+
+// ValueAt returns the snapped value under point (relative to the content
+// box). Points beyond the track clamp to its ends.
+func (b *SliderBase) ValueAt(point math.Vec2f32) float32 {
+	axis := point.X
+	if b.IsVertical() {
+		axis = point.Y
+	}
+	travel := b.Length - b.ThumbSize
+	if travel <= 0 {
+		return b.Min
+	}
+	f := max(0, min(1, (axis-b.ThumbSize/2)/travel))
+	if b.IsVertical() || b.rtl {
+		f = 1 - f
+	}
+	return b.Snap(b.Min + f*(b.Max-b.Min))
+}
+
+// ContentSize is the natural content size given the width of the widest
+// value label.
+func (b *SliderBase) ContentSize(labelWidth float32) math.Vec2f32 {
+	if b.IsVertical() {
+		size := math.Vec2f32{X: max(b.ThumbSize, b.TrackThickness), Y: b.Length}
+		if b.ShowValue {
+			size.X = max(size.X, labelWidth)
+			size.Y += SliderValueGap + b.FontSize
+		}
+		return size
+	}
+	size := math.Vec2f32{X: b.Length, Y: max(b.ThumbSize, b.TrackThickness)}
+	if b.ShowValue {
+		size.X += SliderValueGap + labelWidth
+		size.Y = max(size.Y, b.FontSize)
+	}
+	return size
+}
+
+// TrackContent returns the part of the content box that holds the track and
+// thumbs, excluding the value label.
+func (b *SliderBase) TrackContent(content math.Vec2f32) math.Vec2f32 {
+	if b.IsVertical() {
+		return math.Vec2f32{X: content.X, Y: b.Length}
+	}
+	return math.Vec2f32{X: b.Length, Y: content.Y}
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Slider Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Slider picks a single number from a range. Its value is kept between
+// frames unless SetValue is called every frame.
+type Slider struct {
+	Component
+	SliderBase
+	Value       float32
+	OnChange    func(self *Slider, value float32)
+	IsMouseOver bool
+	IsDragging  bool
+	controlled  bool
+}
+
+func NewSlider(minValue, maxValue float32) *Slider {
+	s := &Slider{
+		Component:  newComponentBase(SliderKind),
+		SliderBase: newSliderBase(),
+	}
+	s.Min, s.Max = minValue, maxValue
+	s.Value = minValue
+	return s
+}
+
+// Slide moves the thumb to v, snapped to the range, and calls OnChange if
+// the value changed. It does nothing while disabled.
+func (s *Slider) Slide(v float32) {
+	if s.Disabled {
+		return
+	}
+	v = s.Snap(v)
+	if v == s.Value {
+		return
+	}
+	s.Value = v
+	if s.OnChange != nil {
+		s.OnChange(s, v)
+	}
+}
+
+// ValueLabel is the text shown next to the track when ShowValue is set.
+func (s *Slider) ValueLabel() string { return s.Format(s.Value) }
+
+// Geometry returns the slider's SliderBase with the component's direction
+// applied.
+func (s *Slider) Geometry() *SliderBase {
+	s.rtl = s.IsRTL()
+	return &s.SliderBase
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// RangeSlider Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// RangeSliderSeparator separates the low and high values in the label.
+const RangeSliderSeparator = " - "
+
+// RangeSlider picks a low and a high value with two thumbs. The thumbs
+// cannot cross.
+type RangeSlider struct {
+	Component
+	SliderBase
+	Low, High    float32
+	OnChange     func(self *RangeSlider, low, high float32)
+	HoveredThumb int // 0 low, 1 high, -1 none
+	DraggedThumb int // 0 low, 1 high, -1 none
+	ActiveThumb  int // thumb moved by the keyboard
+	controlled   bool
+}
+
+func NewRangeSlider(minValue, maxValue float32) *RangeSlider {
+	r := &RangeSlider{
+		Component:    newComponentBase(RangeSliderKind),
+		SliderBase:   newSliderBase(),
+		HoveredThumb: -1,
+		DraggedThumb: -1,
+	}
+	r.Min, r.Max = minValue, maxValue
+	r.Low, r.High = minValue, maxValue
+	return r
+}
+
+// Thumb returns the value of thumb i.
+func (r *RangeSlider) Thumb(i int) float32 {
+	if i == 1 {
+		return r.High
+	}
+	return r.Low
+}
+
+// SlideThumb moves thumb i to v, snapped to the range and kept on its side
+// of the other thumb, and calls OnChange if the value changed.
+func (r *RangeSlider) SlideThumb(i int, v float32) {
+	if r.Disabled {
+		return
+	}
+	v = r.Snap(v)
+	low, high := r.Low, r.High
+	if i == 1 {
+		high = max(v, low)
+	} else {
+		low = min(v, high)
+	}
+	if low == r.Low && high == r.High {
+		return
+	}
+	r.Low, r.High = low, high
+	if r.OnChange != nil {
+		r.OnChange(r, low, high)
+	}
+}
+
+// NearestThumb returns the thumb closest to v. When the thumbs overlap, the
+// one that can move towards v is picked.
+func (r *RangeSlider) NearestThumb(v float32) int {
+	dLow, dHigh := v-r.Low, r.High-v
+	if dLow < 0 {
+		dLow = -dLow
+	}
+	if dHigh < 0 {
+		dHigh = -dHigh
+	}
+	if dHigh < dLow || (dHigh == dLow && v > r.High) {
+		return 1
+	}
+	return 0
+}
+
+// ThumbAt returns the thumb whose circle contains point (relative to the
+// content box), or -1.
+func (r *RangeSlider) ThumbAt(point math.Vec2f32, content math.Vec2f32) int {
+	geometry := r.Geometry()
+	radius := r.ThumbSize / 2
+	for _, i := range []int{1, 0} {
+		c := geometry.ThumbCenter(r.Thumb(i), geometry.TrackContent(content))
+		dx, dy := point.X-c.X, point.Y-c.Y
+		if dx*dx+dy*dy <= radius*radius {
+			return i
+		}
+	}
+	return -1
+}
+
+// ValueLabel is the text shown next to the track when ShowValue is set.
+func (r *RangeSlider) ValueLabel() string {
+	return r.Format(r.Low) + RangeSliderSeparator + r.Format(r.High)
+}
+
+// Geometry returns the slider's SliderBase with the component's direction
+// applied.
+func (r *RangeSlider) Geometry() *SliderBase {
+	r.rtl = r.IsRTL()
+	return &r.SliderBase
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (s *Slider) SetID(id string) *Slider {
+	s.Component.setID(id)
+	return s
+}
+
+// SetValue makes the slider controlled: the given value wins over the one
+// kept from the previous frame.
+func (s *Slider) SetValue(v float32) *Slider {
+	s.Value = s.Snap(v)
+	s.controlled = true
+	return s
+}
+
+func (s *Slider) SetRange(minValue, maxValue float32) *Slider {
+	s.Min, s.Max = minValue, maxValue
+	s.Value = s.Snap(s.Value)
+	return s
+}
+
+func (s *Slider) SetStep(step float32) *Slider {
+	s.Step = max(0, step)
+	return s
+}
+
+func (s *Slider) SetOrientation(o Orientation) *Slider {
+	s.Orientation = o
+	return s
+}
+
+func (s *Slider) SetLength(length float32) *Slider {
+	if length > 0 {
+		s.Length = length
+	}
+	return s
+}
+
+func (s *Slider) SetThumbSize(size float32) *Slider {
+	if size > 0 {
+		s.ThumbSize = size
+	}
+	return s
+}
+
+func (s *Slider) SetTicks(n int) *Slider {
+	s.Ticks = max(0, n)
+	return s
+}
+
+func (s *Slider) SetShowValue(show bool) *Slider {
+	s.ShowValue = show
+	return s
+}
+
+func (s *Slider) SetValueFormat(format string) *Slider {
+	s.ValueFormat = format
+	return s
+}
+
+func (s *Slider) SetDisabled(disabled bool) *Slider {
+	s.Disabled = disabled
+	return s
+}
+
+func (s *Slider) SetOnChange(callback func(self *Slider, value float32)) *Slider {
+	s.OnChange = callback
+	return s
+}
+
+func (s *Slider) SetFontSize(size float32) *Slider {
+	if size > 0 {
+		s.FontSize = size
+	}
+	return s
+}
+
+func (s *Slider) SetTextColor(color color.RGBA) *Slider {
+	s.TextColor = color
+	return s
+}
+
+func (s *Slider) SetTrackColor(color color.RGBA) *Slider {
+	s.TrackColor = color
+	return s
+}
+
+func (s *Slider) SetAccentColor(color color.RGBA) *Slider {
+	s.AccentColor = color
+	return s
+}
+
+func (s *Slider) SetThumbColor(color color.RGBA) *Slider {
+	s.ThumbColor = color
+	return s
+}
+
+func (s *Slider) SetDisplay(d Display) *Slider {
+	s.Component.setDisplay(d)
+	return s
+}
+
+func (s *Slider) SetPosition(pos Position) *Slider {
+	s.Component.setPos(pos)
+	return s
+}
+
+func (s *Slider) SetMargin(margin math.Vec2f32) *Slider {
+	s.Component.setMargin(margin)
+	return s
+}
+
+func (s *Slider) SetPadding(padding math.Vec2f32) *Slider {
+	s.Component.setPadding(padding)
+	return s
+}
+
+func (s *Slider) SetZIndex(zIndex int) *Slider {
+	s.Component.setZIndex(zIndex)
+	return s
+}
+
+func (s *Slider) SetTooltip(tooltip *Tooltip) *Slider {
+	s.Component.setTooltip(tooltip)
+	return s
+}
+
+func (s *Slider) SetTransform(transform *Transform) *Slider {
+	s.Component.setTransform(transform)
+	return s
+}
+
+func (r *RangeSlider) SetID(id string) *RangeSlider {
+	r.Component.setID(id)
+	return r
+}
+
+// SetValues makes the slider controlled: the given values win over the ones
+// kept from the previous frame.
+func (r *RangeSlider) SetValues(low, high float32) *RangeSlider {
+	r.Low, r.High = r.Snap(min(low, high)), r.Snap(max(low, high))
+	r.controlled = true
+	return r
+}
+
+func (r *RangeSlider) SetRange(minValue, maxValue float32) *RangeSlider {
+	r.Min, r.Max = minValue, maxValue
+	r.Low, r.High = r.Snap(r.Low), r.Snap(r.High)
+	return r
+}
+
+func (r *RangeSlider) SetStep(step float32) *RangeSlider {
+	r.Step = max(0, step)
+	return r
+}
+
+func (r *RangeSlider) SetOrientation(o Orientation) *RangeSlider {
+	r.Orientation = o
+	return r
+}
+
+func (r *RangeSlider) SetLength(length float32) *RangeSlider {
+	if length > 0 {
+		r.Length = length
+	}
+	return r
+}
+
+func (r *RangeSlider) SetThumbSize(size float32) *RangeSlider {
+	if size > 0 {
+		r.ThumbSize = size
+	}
+	return r
+}
+
+func (r *RangeSlider) SetTicks(n int) *RangeSlider {
+	r.Ticks = max(0, n)
+	return r
+}
+
+func (r *RangeSlider) SetShowValue(show bool) *RangeSlider {
+	r.ShowValue = show
+	return r
+}
+
+func (r *RangeSlider) SetValueFormat(format string) *RangeSlider {
+	r.ValueFormat = format
+	return r
+}
+
+func (r *RangeSlider) SetDisabled(disabled bool) *RangeSlider {
+	r.Disabled = disabled
+	return r
+}
+
+func (r *RangeSlider) SetOnChange(callback func(self *RangeSlider, low, high float32)) *RangeSlider {
+	r.OnChange = callback
+	return r
+}
+
+func (r *RangeSlider) SetFontSize(size float32) *RangeSlider {
+	if size > 0 {
+		r.FontSize = size
+	}
+	return r
+}
+
+func (r *RangeSlider) SetTextColor(color color.RGBA) *RangeSlider {
+	r.TextColor = color
+	return r
+}
+
+func (r *RangeSlider) SetTrackColor(color color.RGBA) *RangeSlider {
+	r.TrackColor = color
+	return r
+}
+
+func (r *RangeSlider) SetAccentColor(color color.RGBA) *RangeSlider {
+	r.AccentColor = color
+	return r
+}
+
+func (r *RangeSlider) SetThumbColor(color color.RGBA) *RangeSlider {
+	r.ThumbColor = color
+	return r
+}
+
+func (r *RangeSlider) SetDisplay(d Display) *RangeSlider {
+	r.Component.setDisplay(d)
+	return r
+}
+
+func (r *RangeSlider) SetPosition(pos Position) *RangeSlider {
+	r.Component.setPos(pos)
+	return r
+}
+
+func (r *RangeSlider) SetMargin(margin math.Vec2f32) *RangeSlider {
+	r.Component.setMargin(margin)
+	return r
+}
+
+func (r *RangeSlider) SetPadding(padding math.Vec2f32) *RangeSlider {
+	r.Component.setPadding(padding)
+	return r
+}
+
+func (r *RangeSlider) SetZIndex(zIndex int) *RangeSlider {
+	r.Component.setZIndex(zIndex)
+	return r
+}
+
+func (r *RangeSlider) SetTooltip(tooltip *Tooltip) *RangeSlider {
+	r.Component.setTooltip(tooltip)
+	return r
+}
+
+func (r *RangeSlider) SetTransform(transform *Transform) *RangeSlider {
+	r.Component.setTransform(transform)
+	return r
+}
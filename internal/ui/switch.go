@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Switch Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Switch is a labelled on/off toggle. Like Checkbox, its position is kept
+// between frames unless SetOn is called every frame.
+type Switch struct {
+	Component
+	Label         string
+	On            bool
+	Disabled      bool
+	FontSize      float32
+	TextColor     color.RGBA
+	AccentColor   color.RGBA // track colour when on
+	TrackColor    color.RGBA // track colour when off
+	KnobColor     color.RGBA
+	HoverColor    color.RGBA
+	DisabledColor color.RGBA
+	FocusColor    color.RGBA
+	OnChange      func(self *Switch, on bool)
+	IsMouseOver   bool
+	IsPressed     bool
+	IsFocused     bool
+	controlled    bool
+}
+
+func NewSwitch(label string) *Switch {
+	theme := CurrentTheme()
+	return &Switch{
+		Component:     newComponentBase(SwitchKind),
+		Label:         label,
+		FontSize:      theme.FontSizeBody,
+		TextColor:     theme.Text,
+		AccentColor:   theme.Primary,
+		TrackColor:    theme.Track,
+		KnobColor:     theme.Knob,
+		HoverColor:    theme.PrimaryHover,
+		DisabledColor: theme.TextDisabled,
+		FocusColor:    theme.Focus,
+	}
+}
+
+// Toggle flips the switch and calls OnChange. It does nothing while disabled.
+func (s *Switch) Toggle() {
+	if s.Disabled {
+		return
+	}
+	s.On = !s.On
+	if s.OnChange != nil {
+		s.OnChange(s, s.On)
+	}
+}
+
+// TrackSize is the size of the rounded track the knob slides in.
+func (s *Switch) TrackSize() math.Vec2f32 {
+	h := s.FontSize + 2
+	return math.Vec2f32{X: h * 1.8, Y: h}
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (s *Switch) SetID(id string) *Switch {
+	s.Component.setID(id)
+	return s
+}
+
+func (s *Switch) SetLabel(label string) *Switch {
+	s.Label = label
+	return s
+}
+
+// SetOn makes the switch controlled: the given position wins over the one
+// kept from the previous frame.
+func (s *Switch) SetOn(on bool) *Switch {
+	s.On = on
+	s.controlled = true
+	return s
+}
+
+func (s *Switch) SetDisabled(disabled bool) *Switch {
+	s.Disabled = disabled
+	return s
+}
+
+func (s *Switch) SetOnChange(callback func(self *Switch, on bool)) *Switch {
+	s.OnChange = callback
+	return s
+}
+
+func (s *Switch) SetFontSize(size float32) *Switch {
+	if size > 0 {
+		s.FontSize = size
+	}
+	return s
+}
+
+func (s *Switch) SetTextColor(color color.RGBA) *Switch {
+	s.TextColor = color
+	return s
+}
+
+func (s *Switch) SetAccentColor(color color.RGBA) *Switch {
+	s.AccentColor = color
+	return s
+}
+
+func (s *Switch) SetHoverColor(color color.RGBA) *Switch {
+	s.HoverColor = color
+	return s
+}
+
+func (s *Switch) SetDisplay(d Display) *Switch {
+	s.Component.setDisplay(d)
+	return s
+}
+
+func (s *Switch) SetPosition(pos Position) *Switch {
+	s.Component.setPos(pos)
+	return s
+}
+
+func (s *Switch) SetMargin(margin math.Vec2f32) *Switch {
+	s.Component.setMargin(margin)
+	return s
+}
+
+func (s *Switch) SetPadding(padding math.Vec2f32) *Switch {
+	s.Component.setPadding(padding)
+	return s
+}
+
+func (s *Switch) SetZIndex(zIndex int) *Switch {
+	s.Component.setZIndex(zIndex)
+	return s
+}
+
+func (s *Switch) SetTooltip(tooltip *Tooltip) *Switch {
+	s.Component.setTooltip(tooltip)
+	return s
+}
+
+func (s *Switch) SetTransform(transform *Transform) *Switch {
+	s.Component.setTransform(transform)
+	return s
+}
@@ -1,78 +1,870 @@
 package ui
 
 import (
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/aj-2000/mogi/color"
 	"github.com/aj-2000/mogi/math"
 )
 
+// ——————————————————————————————————————————————————————————————————————————————
+// Table Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// TableCellPaddingX and TableCellPaddingY are the space around the content
+// of each cell, header cells included.
+const (
+	TableCellPaddingX float32 = 8
+	TableCellPaddingY float32 = 6
+	// TableResizeHandle is how far either side of a column's end edge the
+	// header can be grabbed to resize the column.
+	TableResizeHandle float32 = 4
+)
+
+// ColumnSizing is how a column's width is decided.
+type ColumnSizing int
+
+const (
+	// ColumnAuto fits the header and the widest cell.
+	ColumnAuto ColumnSizing = iota
+	// ColumnFixed is a width in pixels.
+	ColumnFixed
+	// ColumnFraction shares the width left by the other columns among the
+	// fraction columns, in proportion to their values.
+	ColumnFraction
+)
+
+// ColumnWidth is a column's sizing and, for fixed and fraction columns, its
+// value.
+type ColumnWidth struct {
+	Sizing ColumnSizing
+	Value  float32
+}
+
+func AutoWidth() ColumnWidth                { return ColumnWidth{Sizing: ColumnAuto} }
+func FixedWidth(pixels float32) ColumnWidth { return ColumnWidth{Sizing: ColumnFixed, Value: pixels} }
+func FractionWidth(share float32) ColumnWidth {
+	return ColumnWidth{Sizing: ColumnFraction, Value: share}
+}
+
+// SortDirection is the order of a sorted column.
+type SortDirection int
+
+const (
+	SortNone SortDirection = iota
+	SortAscending
+	SortDescending
+)
+
+// TableColumn describes one column of a Table. MinWidth and MaxWidth bound
+// its width however it is sized or resized; a MaxWidth of 0 means no limit.
+type TableColumn struct {
+	Key       string // identifies the column across frames; defaults to Title
+	Title     string
+	Width     ColumnWidth
+	MinWidth  float32
+	MaxWidth  float32
+	Align     TextAlign
+	Sortable  bool
+	Resizable bool
+	// Compare orders two cells when sorting. If nil, cells that are both
+	// numbers compare as numbers and others as text.
+	Compare func(a, b string) int
+	// Aggregate is shown under the column in the table's footer.
+	Aggregate Aggregate
+}
+
+// NewColumn returns an auto-sized, sortable and resizable column.
+func NewColumn(title string) *TableColumn {
+	return &TableColumn{Title: title, Align: TextAlignStart, MinWidth: 24, Sortable: true, Resizable: true}
+}
+
+func (c *TableColumn) key() string {
+	if c.Key != "" {
+		return c.Key
+	}
+	return c.Title
+}
+
+func (c *TableColumn) clamp(width float32) float32 {
+	width = max(width, c.MinWidth)
+	if c.MaxWidth > 0 {
+		width = min(width, c.MaxWidth)
+	}
+	return width
+}
+
+func (c *TableColumn) compare(a, b string) int {
+	if c.Compare != nil {
+		return c.Compare(a, b)
+	}
+	return CompareCells(a, b)
+}
+
+// CompareCells compares two cells as numbers if both are numbers (digit
+// group commas allowed), and as text otherwise.
+func CompareCells(a, b string) int {
+	x, errA := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(a), ",", ""), 64)
+	y, errB := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(b), ",", ""), 64)
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func (c *TableColumn) SetKey(key string) *TableColumn {
+	c.Key = key
+	return c
+}
+
+func (c *TableColumn) SetWidth(width ColumnWidth) *TableColumn {
+	c.Width = width
+	return c
+}
+
+func (c *TableColumn) SetMinWidth(width float32) *TableColumn {
+	c.MinWidth = width
+	return c
+}
+
+func (c *TableColumn) SetMaxWidth(width float32) *TableColumn {
+	c.MaxWidth = width
+	return c
+}
+
+func (c *TableColumn) SetAlign(align TextAlign) *TableColumn {
+	c.Align = align
+	return c
+}
+
+func (c *TableColumn) SetSortable(sortable bool) *TableColumn {
+	c.Sortable = sortable
+	return c
+}
+
+func (c *TableColumn) SetResizable(resizable bool) *TableColumn {
+	c.Resizable = resizable
+	return c
+}
+
+func (c *TableColumn) SetCompare(compare func(a, b string) int) *TableColumn {
+	c.Compare = compare
+	return c
+}
+
+func (c *TableColumn) SetAggregate(aggregate Aggregate) *TableColumn {
+	c.Aggregate = aggregate
+	return c
+}
+
+// Row is one row of a Table. Cells holds the text of each cell, which is
+// also what sorting compares; a non-nil entry of Content is shown instead of
+// the text of its cell.
+type Row struct {
+	Key     string // identifies the row across sorting and frames; defaults to its index
+	Cells   []string
+	Content []IComponent
+}
+
+// tableState is the part of a Table kept between frames. Rows and columns
+// are tracked by key, so the selection and resized widths survive sorting
+// and reordering.
+type tableState struct {
+	scroll       VirtualScroll
+	sortColumn   string
+	sortDir      SortDirection
+	selected     map[string]bool
+	cursor       string
+	anchor       string
+	widths       map[string]float32
+	hovered      int
+	hoveredHead  int
+	pressedHead  int
+	resizing     int
+	resizeOrigin [2]float32
+	page         int
+	filter       string
+}
+
+// Table shows rows of cells under a header that stays in place while the
+// rows scroll (when the table has a fixed height). Columns are sized from
+// their definitions and can be resized by dragging the end edge of their
+// header; clicking a sortable header cycles through ascending, descending
+// and unsorted. Rows can be selected by clicking them.
+//
+// Rows come from Rows or, if set, from Data. They can be filtered and split
+// into pages of PageSize rows, and columns with an Aggregate are summarized
+// in a footer.
+//
+// Sorting, selection and resized widths are restored from the previous frame
+// when IDs are assigned, before the cells are mounted. Call SetSort or
+// SetSelected every frame to control them instead.
 type Table struct {
 	Component
-	Rows        []Row
-	Header      []string
-	HeaderColor color.RGBA
-	RowColor    color.RGBA
+	VirtualScroll
+	Columns []*TableColumn
+	Rows    []Row
+	// Data replaces Rows when set.
+	Data TableData
+	// Filter shows only rows with a cell containing it, ignoring case.
+	// FilterFunc, if set, decides instead.
+	Filter     string
+	FilterFunc func(self *Table, row int) bool
+	// PageSize is how many rows a page shows; 0 shows all rows.
+	PageSize    int
+	Page        int
+	MultiSelect bool
+	Striped     bool
 	FontSize    float32
 	FontColor   color.RGBA
+	HeaderColor color.RGBA
+	// HeaderTextColor is the color of the column titles and sort indicators.
+	HeaderTextColor color.RGBA
+	RowColor        color.RGBA
+	StripeColor     color.RGBA
+	HoverColor      color.RGBA
+	SelectedColor   color.RGBA
+	GridColor       color.RGBA
+	FocusColor      color.RGBA
+	OnSort          func(self *Table, column int, dir SortDirection)
+	OnSelect        func(self *Table, keys []string)
+	OnPageChange    func(self *Table, page int)
+	// SortColumn is the index of the sorted column, or -1.
+	SortColumn int
+	SortDir    SortDirection
+	// Cursor is the key of the row moved by the keyboard.
+	Cursor string
+	// Interaction state: HoveredRow is a display row, the others are
+	// column indices; -1 for none.
+	HoveredRow     int
+	HoveredHeader  int
+	PressedHeader  int
+	ResizingColumn int
+	IsFocused      bool
+	selected       map[string]bool
+	anchor         string
+	widths         map[string]float32
+	resizeOrigin   [2]float32 // pointer x and column width when a resize began
+	sorted         []int      // the rows that pass the filter, sorted, on every page
+	order          []int      // order[r] is the row shown as display row r, on this page
+	aggregates     []string
+	cells          [][]IComponent
+	columnX        []float32
+	columnWidths   []float32
+	rowY           []float32 // rowY[r] is the top of display row r; rowY[len(order)] the end
+	controlledSort bool
+	controlledSel  bool
+	controlledPage bool
+	// theme is the theme in use when the table was created, which cells
+	// are built with.
+	theme Theme
 }
 
-type Row struct {
-	Cells []string
+func NewTable() *Table {
+	theme := CurrentTheme()
+	t := &Table{
+		Component:       newComponentBase(TableKind),
+		VirtualScroll:   newVirtualScroll(),
+		Striped:         true,
+		FontSize:        theme.FontSizeBody,
+		FontColor:       theme.Text,
+		HeaderColor:     theme.SurfaceRaised,
+		HeaderTextColor: theme.Text,
+		RowColor:        color.Transparent,
+		StripeColor:     theme.Tinted(0.05),
+		HoverColor:      theme.Tinted(0.1),
+		SelectedColor:   theme.Selection(),
+		GridColor:       theme.Tinted(0.15),
+		FocusColor:      theme.Focus,
+		SortColumn:      -1,
+		HoveredRow:      -1,
+		HoveredHeader:   -1,
+		PressedHeader:   -1,
+		ResizingColumn:  -1,
+		selected:        make(map[string]bool),
+		widths:          make(map[string]float32),
+		theme:           theme,
+	}
+	t.Component.setDisplay(DisplayBlock)
+	return t
 }
 
-func NewTable() *Table {
-	return &Table{
-		Component:   newComponentBase(TableKind),
-		Rows:        []Row{},
-		Header:      []string{},
-		HeaderColor: color.RGBA{R: 0, G: 0, B: 0, A: 255},
-		RowColor:    color.RGBA{R: 255, G: 255, B: 255, A: 255},
-		FontSize:    16,
-		FontColor:   color.RGBA{R: 0, G: 0, B: 0, A: 255},
+// HeaderHeight is the height of the header row.
+func (t *Table) HeaderHeight() float32 { return t.FontSize + 2*TableCellPaddingY }
+
+// SortIndicatorWidth is the space kept after the title of a sortable column
+// for its sort arrow.
+func (t *Table) SortIndicatorWidth() float32 { return t.FontSize * 0.75 }
+
+// FooterHeight is the height of the aggregates row and the pager under
+// the rows, if the table has them.
+func (t *Table) FooterHeight() float32 {
+	var height float32
+	if t.HasAggregates() {
+		height += t.HeaderHeight()
+	}
+	if t.PageSize > 0 {
+		height += t.HeaderHeight()
+	}
+	return height
+}
+
+// HasAggregates reports whether any column has an aggregate.
+func (t *Table) HasAggregates() bool {
+	return slices.ContainsFunc(t.Columns, func(c *TableColumn) bool { return c.Aggregate != AggregateNone })
+}
+
+// RowCount is the number of rows in Rows or Data, before filtering.
+func (t *Table) RowCount() int {
+	if t.Data != nil {
+		return t.Data.RowCount()
+	}
+	return len(t.Rows)
+}
+
+// RowKey returns the key of row i.
+func (t *Table) RowKey(i int) string {
+	switch {
+	case t.Data != nil:
+		if keyer, ok := t.Data.(TableRowKeyer); ok {
+			return keyer.RowKey(i)
+		}
+	case t.Rows[i].Key != "":
+		return t.Rows[i].Key
+	}
+	return strconv.Itoa(i)
+}
+
+// DisplayRows returns the rows shown on this page, in the order they are
+// shown.
+func (t *Table) DisplayRows() []int { return t.order }
+
+// DisplayRowKey returns the key of display row r.
+func (t *Table) DisplayRowKey(r int) string { return t.RowKey(t.order[r]) }
+
+// DisplayRowIndex returns the display row of the row with key, or -1.
+func (t *Table) DisplayRowIndex(key string) int {
+	return slices.IndexFunc(t.order, func(i int) bool { return t.RowKey(i) == key })
+}
+
+// MatchCount is the number of rows that pass the filter, on every page.
+func (t *Table) MatchCount() int { return len(t.sorted) }
+
+// PageCount is the number of pages, at least 1.
+func (t *Table) PageCount() int {
+	if t.PageSize <= 0 || len(t.sorted) == 0 {
+		return 1
+	}
+	return (len(t.sorted) + t.PageSize - 1) / t.PageSize
+}
+
+// Cell returns the text of column c in row i.
+func (t *Table) Cell(i, c int) string {
+	if t.Data != nil {
+		return t.Data.Cell(i, c)
+	}
+	if c < len(t.Rows[i].Cells) {
+		return t.Rows[i].Cells[c]
 	}
+	return ""
 }
 
+// AggregateText returns the footer text of column c.
+func (t *Table) AggregateText(c int) string {
+	if c < len(t.aggregates) {
+		return t.aggregates[c]
+	}
+	return ""
+}
+
+func (t *Table) matches(i int) bool {
+	if t.FilterFunc != nil {
+		return t.FilterFunc(t, i)
+	}
+	if t.Filter == "" {
+		return true
+	}
+	query := strings.ToLower(t.Filter)
+	for c := range t.Columns {
+		if strings.Contains(strings.ToLower(t.Cell(i, c)), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// arrangeRows filters the rows, orders them by the sorted column (keeping
+// the given order for equal cells), summarizes them and picks the rows of
+// the current page.
+func (t *Table) arrangeRows() {
+	t.sorted = t.sorted[:0]
+	for i := range t.RowCount() {
+		if t.matches(i) {
+			t.sorted = append(t.sorted, i)
+		}
+	}
+	if t.SortColumn >= 0 && t.SortColumn < len(t.Columns) && t.SortDir != SortNone {
+		column := t.Columns[t.SortColumn]
+		slices.SortStableFunc(t.sorted, func(a, b int) int {
+			cmp := column.compare(t.Cell(a, t.SortColumn), t.Cell(b, t.SortColumn))
+			if t.SortDir == SortDescending {
+				return -cmp
+			}
+			return cmp
+		})
+	}
+
+	t.aggregates = make([]string, len(t.Columns))
+	for c, column := range t.Columns {
+		if column.Aggregate == AggregateNone {
+			continue
+		}
+		values := make([]string, len(t.sorted))
+		for k, i := range t.sorted {
+			values[k] = t.Cell(i, c)
+		}
+		t.aggregates[c] = column.Aggregate.aggregate(values)
+	}
+
+	t.Page = max(0, min(t.Page, t.PageCount()-1))
+	t.order = t.sorted
+	if t.PageSize > 0 {
+		start := t.Page * t.PageSize
+		t.order = t.sorted[start:min(start+t.PageSize, len(t.sorted))]
+	}
+}
+
+// SetPageIndex shows page, within the page count, scrolled to the top.
+func (t *Table) SetPageIndex(page int) {
+	page = max(0, min(page, t.PageCount()-1))
+	if page == t.Page {
+		return
+	}
+	if !t.controlledPage {
+		t.Page = page
+		t.Offset = 0
+	}
+	if t.OnPageChange != nil {
+		t.OnPageChange(t, page)
+	}
+}
+
+// PagerButtonAt returns -1 or 1 if point (relative to the content box) is on
+// the previous or next page button of the pager, and 0 otherwise.
+func (t *Table) PagerButtonAt(point math.Vec2f32) int {
+	if t.PageSize <= 0 {
+		return 0
+	}
+	// The pager is the last row, below the rows and the aggregates.
+	top := t.viewport.Y + t.FooterHeight()
+	if point.Y < top || point.Y > top+t.HeaderHeight() {
+		return 0
+	}
+	prev, next := t.PagerButtons()
+	switch {
+	case point.X >= prev && point.X < prev+t.HeaderHeight():
+		return -1
+	case point.X >= next && point.X < next+t.HeaderHeight():
+		return 1
+	}
+	return 0
+}
+
+// PagerButtons returns the x of the previous and next page buttons, which
+// are square and as tall as the pager, at the end of the pager.
+func (t *Table) PagerButtons() (prev, next float32) {
+	side := t.HeaderHeight()
+	if t.IsRTL() {
+		return side, 0
+	}
+	return t.viewport.X - 2*side, t.viewport.X - side
+}
+
+// buildCell returns the component shown in column c of row i.
+func (t *Table) buildCell(i, c int) IComponent {
+	defer UseTheme(UseTheme(t.theme))
+	if t.Data == nil {
+		if row := t.Rows[i]; c < len(row.Content) && row.Content[c] != nil {
+			return row.Content[c]
+		}
+	}
+	return NewText(t.Cell(i, c)).
+		SetTextWrapped(true).
+		SetFontSize(t.FontSize).
+		SetColor(t.FontColor).
+		SetAlign(t.Columns[c].Align)
+}
+
+func (t *Table) mountCells(cells [][]IComponent) {
+	t.cells = cells
+	var children []IComponent
+	for _, row := range cells {
+		children = append(children, row...)
+	}
+	mountChildren(&t.Component, t, children)
+}
+
+// CellComponent returns the component in column c of display row r.
+func (t *Table) CellComponent(r, c int) IComponent { return t.cells[r][c] }
+
+// ColumnRect returns the x and width of column c within the content box.
+// In RTL the columns start from the right edge.
+func (t *Table) ColumnRect(c int) (x, width float32) {
+	x, width = t.columnX[c], t.columnWidths[c]
+	if t.IsRTL() {
+		x = t.viewport.X - x - width
+	}
+	return x, width
+}
+
+// RowRect returns the top and height of display row r within the body,
+// before scrolling.
+func (t *Table) RowRect(r int) (y, height float32) {
+	return t.rowY[r], t.rowY[r+1] - t.rowY[r]
+}
+
+// HeaderAt returns the column whose header is at point (relative to the
+// content box) and whether point is on its resize handle. column is -1 off
+// the header.
+func (t *Table) HeaderAt(point math.Vec2f32) (column int, onResize bool) {
+	if point.Y < 0 || point.Y > t.HeaderHeight() {
+		return -1, false
+	}
+	for c := range t.columnX {
+		x, width := t.ColumnRect(c)
+		edge := x + width
+		if t.IsRTL() {
+			edge = x
+		}
+		if t.Columns[c].Resizable && point.X >= edge-TableResizeHandle && point.X <= edge+TableResizeHandle {
+			return c, true
+		}
+	}
+	for c := range t.columnX {
+		if x, width := t.ColumnRect(c); point.X >= x && point.X < x+width {
+			return c, false
+		}
+	}
+	return -1, false
+}
+
+// RowAt returns the display row at point (relative to the content box), or
+// -1.
+func (t *Table) RowAt(point math.Vec2f32) int {
+	y := point.Y - t.HeaderHeight()
+	if y < 0 || y > t.viewport.Y || point.X < 0 || point.X > t.viewport.X {
+		return -1
+	}
+	y += t.Offset
+	r, found := slices.BinarySearch(t.rowY, y)
+	if !found {
+		r--
+	}
+	if r < 0 || r >= len(t.order) {
+		return -1
+	}
+	return r
+}
+
+// ToggleSort cycles column c through ascending, descending and unsorted.
+func (t *Table) ToggleSort(c int) {
+	if c < 0 || c >= len(t.Columns) || !t.Columns[c].Sortable {
+		return
+	}
+	dir := SortAscending
+	if c == t.SortColumn {
+		dir = (t.SortDir + 1) % 3
+	}
+	if !t.controlledSort {
+		t.SortColumn, t.SortDir = c, dir
+		if dir == SortNone {
+			t.SortColumn = -1
+		}
+	}
+	if t.OnSort != nil {
+		t.OnSort(t, c, dir)
+	}
+}
+
+// SelectedKeys returns the keys of the selected rows that pass the filter,
+// in display order, on every page.
+func (t *Table) SelectedKeys() []string {
+	var keys []string
+	for _, i := range t.sorted {
+		if key := t.RowKey(i); t.selected[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// IsSelected reports whether the row with key is selected.
+func (t *Table) IsSelected(key string) bool { return t.selected[key] }
+
+// SelectRow handles a click or key press on display row r: it moves the
+// cursor there and selects the row alone, or with MultiSelect extends the
+// selection from the anchor (extend) or toggles the row in it (toggle).
+func (t *Table) SelectRow(r int, extend, toggle bool) {
+	if r < 0 || r >= len(t.order) {
+		return
+	}
+	key := t.DisplayRowKey(r)
+	t.Cursor = key
+	t.ScrollRowIntoView(r)
+	if !t.MultiSelect {
+		extend, toggle = false, false
+	}
+	anchor := t.DisplayRowIndex(t.anchor)
+	switch {
+	case extend && anchor >= 0:
+		clear(t.selected)
+		for j := min(anchor, r); j <= max(anchor, r); j++ {
+			t.selected[t.DisplayRowKey(j)] = true
+		}
+	case toggle:
+		if t.selected[key] {
+			delete(t.selected, key)
+		} else {
+			t.selected[key] = true
+		}
+		t.anchor = key
+	default:
+		if len(t.selected) == 1 && t.selected[key] {
+			return
+		}
+		clear(t.selected)
+		t.selected[key] = true
+		t.anchor = key
+	}
+	if t.OnSelect != nil {
+		t.OnSelect(t, t.SelectedKeys())
+	}
+}
+
+// ScrollTo scrolls the body to offset, within its limits.
+func (t *Table) ScrollTo(offset float32) {
+	t.Offset = offset
+	t.clampOffset()
+}
+
+// ScrollRowIntoView scrolls the least needed to show display row r.
+func (t *Table) ScrollRowIntoView(r int) {
+	if r < 0 || r+1 >= len(t.rowY) {
+		return
+	}
+	y, height := t.RowRect(r)
+	switch {
+	case y < t.Offset:
+		t.ScrollTo(y)
+	case y+height > t.Offset+t.viewport.Y:
+		t.ScrollTo(y + height - t.viewport.Y)
+	}
+}
+
+// ScrollbarX returns the x of the scrollbar within the body.
+func (t *Table) ScrollbarX() float32 { return t.scrollbarX(t.IsRTL()) }
+
+// StartResize begins resizing column c with the pointer at x.
+func (t *Table) StartResize(c int, x float32) {
+	t.ResizingColumn = c
+	t.resizeOrigin = [2]float32{x, t.columnWidths[c]}
+}
+
+// ResizeTo sets the width of the column being resized from the pointer at x.
+// The width is kept between frames and replaces the column's sizing.
+func (t *Table) ResizeTo(x float32) {
+	if t.ResizingColumn < 0 || t.ResizingColumn >= len(t.Columns) {
+		return
+	}
+	delta := x - t.resizeOrigin[0]
+	if t.IsRTL() {
+		delta = -delta
+	}
+	column := t.Columns[t.ResizingColumn]
+	t.widths[column.key()] = column.clamp(t.resizeOrigin[1] + delta)
+}
+
+// resizedWidth returns the width column c was resized to, if it was.
+func (t *Table) resizedWidth(c int) (float32, bool) {
+	width, ok := t.widths[t.Columns[c].key()]
+	return width, ok
+}
+
+func (t *Table) saveState() *tableState {
+	st := &tableState{
+		scroll:       t.VirtualScroll,
+		sortDir:      t.SortDir,
+		selected:     t.selected,
+		cursor:       t.Cursor,
+		anchor:       t.anchor,
+		widths:       t.widths,
+		hovered:      t.HoveredRow,
+		hoveredHead:  t.HoveredHeader,
+		pressedHead:  t.PressedHeader,
+		resizing:     t.ResizingColumn,
+		resizeOrigin: t.resizeOrigin,
+		page:         t.Page,
+		filter:       t.Filter,
+	}
+	if t.SortColumn >= 0 && t.SortColumn < len(t.Columns) {
+		st.sortColumn = t.Columns[t.SortColumn].key()
+	}
+	return st
+}
+
+func (t *Table) restoreState(st *tableState) {
+	t.VirtualScroll.restoreScroll(st.scroll)
+	t.Cursor = st.cursor
+	t.anchor = st.anchor
+	t.widths = st.widths
+	t.HoveredRow = st.hovered
+	t.HoveredHeader = st.hoveredHead
+	t.PressedHeader = st.pressedHead
+	t.ResizingColumn = st.resizing
+	t.resizeOrigin = st.resizeOrigin
+	if !t.controlledSel {
+		t.selected = st.selected
+	}
+	if !t.controlledPage {
+		t.Page = st.page
+		// A new filter starts again from the first page.
+		if st.filter != t.Filter {
+			t.Page, t.Offset = 0, 0
+		}
+	}
+	if t.controlledSort {
+		return
+	}
+	t.SortColumn, t.SortDir = -1, SortNone
+	for c, column := range t.Columns {
+		if column.key() == st.sortColumn {
+			t.SortColumn, t.SortDir = c, st.sortDir
+		}
+	}
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
 func (t *Table) SetID(id string) *Table {
 	t.Component.setID(id)
 	return t
 }
 
-func (t *Table) SetDisplay(d Display) *Table {
-	t.Component.setDisplay(d)
+func (t *Table) SetColumns(columns ...*TableColumn) *Table {
+	t.Columns = columns
 	return t
 }
 
-func (t *Table) SetPosition(p Position) *Table {
-	t.Component.setPos(p)
+// SetHeader sets auto-sized columns with the given titles.
+func (t *Table) SetHeader(header []string) *Table {
+	t.Columns = make([]*TableColumn, len(header))
+	for i, title := range header {
+		t.Columns[i] = NewColumn(title)
+	}
 	return t
 }
 
-func (t *Table) SetSize(s math.Vec2f32) *Table {
-	t.Component.setSize(s)
+func (t *Table) AddRow(row Row) *Table {
+	t.Rows = append(t.Rows, row)
 	return t
 }
 
-func (t *Table) SetBackgroundColor(c color.RGBA) *Table {
-	t.Component.setBackgroundColor(c)
+func (t *Table) AddRows(rows []Row) *Table {
+	t.Rows = append(t.Rows, rows...)
 	return t
 }
 
-func (t *Table) SetBorderColor(c color.RGBA) *Table {
-	t.Component.setBorderColor(c)
+// SetData reads rows from data instead of Rows. A table without columns
+// takes the data's.
+func (t *Table) SetData(data TableData) *Table {
+	t.Data = data
+	if len(t.Columns) == 0 {
+		t.Columns = data.Columns()
+	}
 	return t
 }
 
-func (t *Table) SetBorderWidth(w float32) *Table {
-	t.Component.setBorder(math.Vec2f32{X: w, Y: w})
+func (t *Table) SetFilter(filter string) *Table {
+	t.Filter = filter
 	return t
 }
 
-func (t *Table) SetBorderRadius(r float32) *Table {
-	t.Component.setBorderRadius(r)
+func (t *Table) SetFilterFunc(filter func(self *Table, row int) bool) *Table {
+	t.FilterFunc = filter
+	return t
+}
+
+func (t *Table) SetPageSize(size int) *Table {
+	t.PageSize = max(0, size)
+	return t
+}
+
+// SetPage makes the page controlled: the given page wins over the page kept
+// from the previous frame.
+func (t *Table) SetPage(page int) *Table {
+	t.Page = page
+	t.controlledPage = true
+	return t
+}
+
+func (t *Table) SetOnPageChange(callback func(self *Table, page int)) *Table {
+	t.OnPageChange = callback
+	return t
+}
+
+// SetSort makes sorting controlled: the given column (or -1) and direction
+// win over the sort kept from the previous frame.
+func (t *Table) SetSort(column int, dir SortDirection) *Table {
+	t.SortColumn, t.SortDir = column, dir
+	t.controlledSort = true
+	return t
+}
+
+// SetSelected makes the selection controlled: the given row keys win over
+// the selection kept from the previous frame.
+func (t *Table) SetSelected(keys ...string) *Table {
+	t.selected = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		t.selected[key] = true
+	}
+	t.controlledSel = true
+	return t
+}
+
+func (t *Table) SetMultiSelect(multi bool) *Table {
+	t.MultiSelect = multi
+	return t
+}
+
+func (t *Table) SetStriped(striped bool) *Table {
+	t.Striped = striped
+	return t
+}
+
+func (t *Table) SetOnSort(callback func(self *Table, column int, dir SortDirection)) *Table {
+	t.OnSort = callback
+	return t
+}
+
+func (t *Table) SetOnSelect(callback func(self *Table, keys []string)) *Table {
+	t.OnSelect = callback
 	return t
 }
 
 func (t *Table) SetFontSize(s float32) *Table {
-	t.FontSize = s
+	if s > 0 {
+		t.FontSize = s
+	}
 	return t
 }
 
@@ -81,13 +873,13 @@ func (t *Table) SetFontColor(c color.RGBA) *Table {
 	return t
 }
 
-func (t *Table) SetHeader(header []string) *Table {
-	t.Header = header
+func (t *Table) SetHeaderColor(c color.RGBA) *Table {
+	t.HeaderColor = c
 	return t
 }
 
-func (t *Table) SetHeaderColor(c color.RGBA) *Table {
-	t.HeaderColor = c
+func (t *Table) SetHeaderTextColor(c color.RGBA) *Table {
+	t.HeaderTextColor = c
 	return t
 }
 
@@ -96,13 +888,73 @@ func (t *Table) SetRowColor(c color.RGBA) *Table {
 	return t
 }
 
-func (t *Table) AddRow(row Row) *Table {
-	t.Rows = append(t.Rows, row)
+func (t *Table) SetStripeColor(c color.RGBA) *Table {
+	t.StripeColor = c
 	return t
 }
 
-func (t *Table) AddRows(rows []Row) *Table {
-	t.Rows = append(t.Rows, rows...)
+func (t *Table) SetSelectedColor(c color.RGBA) *Table {
+	t.SelectedColor = c
+	return t
+}
+
+func (t *Table) SetGridColor(c color.RGBA) *Table {
+	t.GridColor = c
+	return t
+}
+
+func (t *Table) SetDisplay(d Display) *Table {
+	t.Component.setDisplay(d)
+	return t
+}
+
+func (t *Table) SetPosition(p Position) *Table {
+	t.Component.setPos(p)
+	return t
+}
+
+func (t *Table) SetSize(s math.Vec2f32) *Table {
+	t.Component.setSize(s)
+	return t
+}
+
+func (t *Table) SetWidthPercent(widthPercent float32) *Table {
+	t.Component.setWidthPercent(widthPercent)
+	return t
+}
+
+func (t *Table) SetMargin(margin math.Vec2f32) *Table {
+	t.Component.setMargin(margin)
+	return t
+}
+
+func (t *Table) SetPadding(padding math.Vec2f32) *Table {
+	t.Component.setPadding(padding)
+	return t
+}
+
+func (t *Table) SetBackgroundColor(c color.RGBA) *Table {
+	t.Component.setBackgroundColor(c)
+	return t
+}
+
+func (t *Table) SetBorderColor(c color.RGBA) *Table {
+	t.Component.setBorderColor(c)
+	return t
+}
+
+func (t *Table) SetBorderWidth(w float32) *Table {
+	t.Component.setBorder(math.Vec2f32{X: w, Y: w})
+	return t
+}
+
+func (t *Table) SetBorderRadius(r float32) *Table {
+	t.Component.setBorderRadius(r)
+	return t
+}
+
+func (t *Table) SetDirection(direction Direction) *Table {
+	t.Component.setDirection(direction)
 	return t
 }
 
@@ -111,6 +963,16 @@ func (t *Table) SetZIndex(zIndex int) *Table {
 	return t
 }
 
+func (t *Table) SetTooltip(tooltip *Tooltip) *Table {
+	t.Component.setTooltip(tooltip)
+	return t
+}
+
+func (t *Table) SetTransform(transform *Transform) *Table {
+	t.Component.setTransform(transform)
+	return t
+}
+
 func (t *Table) Kind() ComponentKind {
 	return t.Component.kind
 }
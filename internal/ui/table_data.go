@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"encoding/csv"
+	"fmt"
+	gomath "math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Table Data Sources
+// ——————————————————————————————————————————————————————————————————————————————
+
+// TableData is where a Table reads its rows from. Cells are read as the
+// table needs them: every row for sorting, filtering and aggregates, but
+// components only for the rows of the current page.
+type TableData interface {
+	// Columns describes the data's columns; a Table without columns of
+	// its own uses these.
+	Columns() []*TableColumn
+	RowCount() int
+	Cell(row, column int) string
+}
+
+// TableRowKeyer is implemented by data whose rows have keys of their own,
+// which keep the selection on the right rows when the data changes. Rows of
+// other data are keyed by index.
+type TableRowKeyer interface {
+	RowKey(row int) string
+}
+
+// CSVData holds the records of a CSV file, the first of which names the
+// columns.
+type CSVData struct {
+	Header  []string
+	Records [][]string
+}
+
+// NewCSVData reads all records from r. The first record is the header.
+func NewCSVData(r *csv.Reader) (*CSVData, error) {
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return &CSVData{}, nil
+	}
+	return &CSVData{Header: records[0], Records: records[1:]}, nil
+}
+
+func (d *CSVData) Columns() []*TableColumn {
+	columns := make([]*TableColumn, len(d.Header))
+	for i, title := range d.Header {
+		columns[i] = NewColumn(title)
+	}
+	return columns
+}
+
+func (d *CSVData) RowCount() int { return len(d.Records) }
+
+func (d *CSVData) Cell(row, column int) string {
+	if record := d.Records[row]; column < len(record) {
+		return record[column]
+	}
+	return ""
+}
+
+// SliceData shows a slice of structs, one column per exported field. The
+// `table` struct tag renames or configures a field's column as a title
+// followed by comma-separated options:
+//
+//	Name   string  `table:"Full name,width=160"`
+//	Salary float64 `table:",align=end,agg=sum"`
+//	ID     int     `table:"-"`          // not shown
+//	SKU    string  `table:"SKU,key"`    // rows are keyed by this field
+//
+// Options are width=<pixels>, fraction=<share>, min=<pixels>, max=<pixels>,
+// align=start|center|end, agg=sum|avg|min|max|count, nosort, noresize and
+// key. Numeric fields are aligned to the end unless align is given.
+type SliceData struct {
+	rows    reflect.Value
+	fields  []int // struct field index of each column
+	columns []*TableColumn
+	key     int // field index of the key column, or -1
+}
+
+// NewSliceData returns the data of rows, which must be a slice of structs or
+// of pointers to structs.
+func NewSliceData(rows any) *SliceData {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("ui: NewSliceData needs a slice of structs, got %T", rows))
+	}
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("ui: NewSliceData needs a slice of structs, got %T", rows))
+	}
+
+	d := &SliceData{rows: v, key: -1}
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Field(i)
+		tag, tagged := field.Tag.Lookup("table")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		title, options, _ := strings.Cut(tag, ",")
+		if title == "" || !tagged {
+			title = field.Name
+		}
+		column := NewColumn(title)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if isNumeric(fieldType.Kind()) {
+			column.Align = TextAlignEnd
+		}
+		for _, option := range strings.Split(options, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+			number, _ := strconv.ParseFloat(value, 32)
+			switch name {
+			case "width":
+				column.Width = FixedWidth(float32(number))
+			case "fraction":
+				column.Width = FractionWidth(float32(number))
+			case "min":
+				column.MinWidth = float32(number)
+			case "max":
+				column.MaxWidth = float32(number)
+			case "align":
+				column.Align = map[string]TextAlign{"start": TextAlignStart, "center": TextAlignCenter, "end": TextAlignEnd}[value]
+			case "agg":
+				column.Aggregate = aggregateNames[value]
+			case "nosort":
+				column.Sortable = false
+			case "noresize":
+				column.Resizable = false
+			case "key":
+				d.key = i
+			}
+		}
+		d.fields = append(d.fields, i)
+		d.columns = append(d.columns, column)
+	}
+	return d
+}
+
+func isNumeric(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+func (d *SliceData) Columns() []*TableColumn { return d.columns }
+
+func (d *SliceData) RowCount() int { return d.rows.Len() }
+
+// row returns the struct of row i, or false for a nil pointer in a slice of
+// pointers.
+func (d *SliceData) row(i int) (reflect.Value, bool) {
+	v := reflect.Indirect(d.rows.Index(i))
+	return v, v.IsValid()
+}
+
+// Cell returns the formatted field, or "" for every cell of a nil row.
+func (d *SliceData) Cell(row, column int) string {
+	v, ok := d.row(row)
+	if !ok {
+		return ""
+	}
+	return formatValue(v.Field(d.fields[column]))
+}
+
+// RowKey returns the value of the key field, if one is tagged, or the index.
+// Nil rows have no key field and are keyed by their index too.
+func (d *SliceData) RowKey(row int) string {
+	v, ok := d.row(row)
+	if d.key < 0 || !ok {
+		return strconv.Itoa(row)
+	}
+	return formatValue(v.Field(d.key))
+}
+
+// formatValue formats a field, showing what a pointer field points to and
+// nothing for a nil one.
+func formatValue(v reflect.Value) string {
+	v = reflect.Indirect(v)
+	switch {
+	case !v.IsValid():
+		return ""
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Aggregates
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Aggregate is a summary of a column shown in a Table's footer. It is taken
+// over the rows that pass the filter, on every page.
+type Aggregate int
+
+const (
+	AggregateNone Aggregate = iota
+	AggregateSum
+	AggregateAverage
+	AggregateMin
+	AggregateMax
+	// AggregateCount counts the non-empty cells.
+	AggregateCount
+)
+
+var aggregateNames = map[string]Aggregate{
+	"sum":   AggregateSum,
+	"avg":   AggregateAverage,
+	"min":   AggregateMin,
+	"max":   AggregateMax,
+	"count": AggregateCount,
+}
+
+// aggregate summarizes values. Cells that are not numbers are skipped by
+// the numeric aggregates.
+func (a Aggregate) aggregate(values []string) string {
+	if a == AggregateNone {
+		return ""
+	}
+	var count int
+	var sum float64
+	low, high := gomath.Inf(1), gomath.Inf(-1)
+	for _, value := range values {
+		if a == AggregateCount {
+			if strings.TrimSpace(value) != "" {
+				count++
+			}
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(value), ",", ""), 64)
+		if err != nil {
+			continue
+		}
+		count++
+		sum += n
+		low, high = min(low, n), max(high, n)
+	}
+	switch {
+	case a == AggregateCount:
+		return strconv.Itoa(count)
+	case count == 0:
+		return ""
+	case a == AggregateSum:
+		return FormatAggregate(sum)
+	case a == AggregateAverage:
+		return FormatAggregate(sum / float64(count))
+	case a == AggregateMin:
+		return FormatAggregate(low)
+	default:
+		return FormatAggregate(high)
+	}
+}
+
+// FormatAggregate formats an aggregate with at most two decimals.
+func FormatAggregate(v float64) string {
+	return strconv.FormatFloat(gomath.Round(v*100)/100, 'f', -1, 64)
+}
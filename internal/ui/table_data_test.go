@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+type testEmployee struct {
+	SKU    string  `table:"SKU,key"`
+	Name   string  `table:"Name"`
+	Salary float64 `table:",agg=sum"`
+}
+
+func TestSliceDataNilRows(t *testing.T) {
+	rows := []*testEmployee{
+		{SKU: "a1", Name: "Ada", Salary: 10},
+		nil,
+		{SKU: "b2", Name: "Bo", Salary: 5},
+	}
+	data := NewSliceData(rows)
+
+	if got := data.RowCount(); got != 3 {
+		t.Fatalf("RowCount() = %d, want 3", got)
+	}
+	for c := range data.Columns() {
+		if got := data.Cell(1, c); got != "" {
+			t.Errorf("Cell(1, %d) = %q, want empty", c, got)
+		}
+	}
+	for row, want := range []string{"a1", "1", "b2"} {
+		if got := data.RowKey(row); got != want {
+			t.Errorf("RowKey(%d) = %q, want %q", row, got, want)
+		}
+	}
+
+	// The table sorts, filters and sums over the nil row without panicking.
+	table := NewTable().SetData(data).SetSort(2, SortDescending)
+	table.arrangeRows()
+	if got, want := table.DisplayRows(), []int{0, 2, 1}; !slices.Equal(got, want) {
+		t.Errorf("sorted rows = %v, want %v", got, want)
+	}
+	if got := table.AggregateText(2); got != "15" {
+		t.Errorf("AggregateText(2) = %q, want 15", got)
+	}
+	table.SetFilter("b")
+	table.arrangeRows()
+	if got, want := table.DisplayRows(), []int{2}; !slices.Equal(got, want) {
+		t.Errorf("filtered rows = %v, want %v", got, want)
+	}
+}
+
+func TestSliceDataNilRowsWithoutKey(t *testing.T) {
+	type item struct{ Name string }
+	data := NewSliceData([]*item{nil, {Name: "x"}})
+	if got := data.Cell(0, 0); got != "" {
+		t.Errorf("Cell(0, 0) = %q, want empty", got)
+	}
+	if got := data.Cell(1, 0); got != "x" {
+		t.Errorf("Cell(1, 0) = %q, want x", got)
+	}
+	if got := data.RowKey(0); got != "0" {
+		t.Errorf("RowKey(0) = %q, want 0", got)
+	}
+}
+
+func TestSliceDataPointerFields(t *testing.T) {
+	type item struct {
+		Name  *string
+		Count *int
+		Price *float64
+	}
+	name, count, price := "bolt", 12, 0.25
+	data := NewSliceData([]item{
+		{Name: &name, Count: &count, Price: &price},
+		{},
+	})
+
+	for c, want := range []string{"bolt", "12", "0.25"} {
+		if got := data.Cell(0, c); got != want {
+			t.Errorf("Cell(0, %d) = %q, want %q", c, got, want)
+		}
+		if got := data.Cell(1, c); got != "" {
+			t.Errorf("Cell(1, %d) = %q, want empty", c, got)
+		}
+	}
+	for c, want := range []TextAlign{TextAlignStart, TextAlignEnd, TextAlignEnd} {
+		if got := data.Columns()[c].Align; got != want {
+			t.Errorf("column %d is aligned %v, want %v", c, got, want)
+		}
+	}
+}
@@ -0,0 +1,654 @@
+package ui
+
+import (
+	"slices"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Tabs Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Tab is one page of a Tabs component. Only the active tab's content is
+// laid out and rendered; set Build instead of Content to also skip building
+// inactive pages.
+type Tab struct {
+	Key      string // identifies the tab across frames; defaults to Title
+	Title    string
+	Content  IComponent
+	Build    func() IComponent
+	Closable bool
+	Disabled bool
+}
+
+func (t *Tab) key() string {
+	if t.Key != "" {
+		return t.Key
+	}
+	return t.Title
+}
+
+// TabHeader is the place of a tab's header in the strip, before scrolling.
+type TabHeader struct {
+	Index int // index into Tabs.Tabs
+	X     float32
+	Width float32
+}
+
+// tabsState is the part of a Tabs component kept between frames. Tabs are
+// tracked by key so that reordering or removing tabs keeps the right one
+// active.
+type tabsState struct {
+	active       string
+	order        []string
+	closed       map[string]bool
+	scroll       float32
+	hovered      int
+	pressed      int
+	hoveredClose int
+	pressedClose int
+}
+
+// Tabs shows a strip of tab headers above the content of the active tab.
+// Tabs can be closable and, if Reorderable is set, dragged to a new place;
+// both are kept between frames. The strip scrolls when the headers do not
+// fit.
+//
+// The active tab is restored from the previous frame when IDs are assigned,
+// before its content is mounted, so inactive tabs are never built. Call
+// SetActive every frame to control it instead.
+type Tabs struct {
+	Component
+	Tabs           []*Tab
+	Active         int // index into Tabs
+	Reorderable    bool
+	FontSize       float32
+	TextColor      color.RGBA
+	HeaderColor    color.RGBA
+	ActiveColor    color.RGBA
+	HoverColor     color.RGBA
+	IndicatorColor color.RGBA
+	DisabledColor  color.RGBA
+	FocusColor     color.RGBA
+	// HeaderPadding is the space around a tab's title inside its header,
+	// and HeaderGap the space between headers. A close button sits half
+	// the horizontal padding away from the title.
+	HeaderPadding math.Vec2f32
+	HeaderGap     float32
+	OnTabChange   func(self *Tabs, index int)
+	// OnTabClose is called when a closable tab is closed. If it is nil the
+	// tab is hidden and stays hidden; otherwise the callback should remove
+	// it from Tabs.
+	OnTabClose func(self *Tabs, index int)
+	// Interaction state, as positions in VisibleTabs; -1 for none.
+	HoveredTab   int
+	PressedTab   int
+	HoveredClose int
+	PressedClose int
+	IsFocused    bool
+	// Scroll is how far the strip is scrolled, in pixels.
+	Scroll     float32
+	order      []string
+	closed     map[string]bool
+	headers    []TabHeader
+	controlled bool
+	// theme is the theme in use when the tabs were created, which lazy
+	// panels are built with.
+	theme Theme
+}
+
+func NewTabs(tabs ...*Tab) *Tabs {
+	theme := CurrentTheme()
+	t := &Tabs{
+		Component:      newComponentBase(TabsKind),
+		Tabs:           tabs,
+		FontSize:       theme.FontSizeBody,
+		TextColor:      theme.Text,
+		HeaderColor:    theme.SurfaceRaised,
+		ActiveColor:    theme.SurfaceActive,
+		HoverColor:     theme.SurfaceHover,
+		IndicatorColor: theme.Primary,
+		DisabledColor:  theme.TextDisabled,
+		FocusColor:     theme.Focus,
+		HeaderPadding:  math.Vec2f32{X: theme.Space(3), Y: theme.Space(2)},
+		HeaderGap:      theme.Space(0.5),
+		HoveredTab:     -1,
+		PressedTab:     -1,
+		HoveredClose:   -1,
+		PressedClose:   -1,
+		theme:          theme,
+	}
+	t.Component.setDisplay(DisplayBlock)
+	return t
+}
+
+// NewTab returns a tab showing content.
+func NewTab(title string, content IComponent) *Tab {
+	return &Tab{Title: title, Content: content}
+}
+
+// NewLazyTab returns a tab whose content is built only while it is active.
+func NewLazyTab(title string, build func() IComponent) *Tab {
+	return &Tab{Title: title, Build: build}
+}
+
+// StripHeight is the height of the header strip.
+func (t *Tabs) StripHeight() float32 { return t.FontSize + 2*t.HeaderPadding.Y }
+
+// StripWidth is the width available to the header strip.
+func (t *Tabs) StripWidth() float32 {
+	return max(0, t.Size().X-2*(t.Padding().X+t.Border().X))
+}
+
+// CloseSize is the side of a closable tab's close button.
+func (t *Tabs) CloseSize() float32 { return t.FontSize * 0.6 }
+
+// ScrollButtonWidth is the width of each strip scroll button.
+func (t *Tabs) ScrollButtonWidth() float32 { return t.StripHeight() * 0.75 }
+
+// VisibleTabs returns the indices of the tabs that are not closed, in
+// display order.
+func (t *Tabs) VisibleTabs() []int {
+	byKey := make(map[string]int, len(t.Tabs))
+	for i, tab := range t.Tabs {
+		byKey[tab.key()] = i
+	}
+	seen := make(map[int]bool, len(t.Tabs))
+	visible := make([]int, 0, len(t.Tabs))
+	for _, key := range t.order {
+		if i, ok := byKey[key]; ok && !seen[i] && !t.closed[key] {
+			seen[i] = true
+			visible = append(visible, i)
+		}
+	}
+	for i, tab := range t.Tabs {
+		if !seen[i] && !t.closed[tab.key()] {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// Headers returns the header of each visible tab, in display order. It is
+// filled in by the layout engine.
+func (t *Tabs) Headers() []TabHeader { return t.headers }
+
+// Overflowing reports whether the headers are wider than the strip.
+func (t *Tabs) Overflowing() bool {
+	if len(t.headers) == 0 {
+		return false
+	}
+	last := t.headers[len(t.headers)-1]
+	return last.X+last.Width > t.StripWidth()
+}
+
+// viewport returns the start and width of the part of the strip that shows
+// headers, which excludes the scroll buttons when the strip overflows.
+func (t *Tabs) viewport() (float32, float32) {
+	if !t.Overflowing() {
+		return 0, t.StripWidth()
+	}
+	button := t.ScrollButtonWidth()
+	return button, max(0, t.StripWidth()-2*button)
+}
+
+// HeaderRect returns the x offset within the strip and the width of the
+// header at position pos of VisibleTabs, after scrolling and mirroring for
+// RTL. ok is false if the header is not entirely inside the viewport.
+func (t *Tabs) HeaderRect(pos int) (x, width float32, ok bool) {
+	if pos < 0 || pos >= len(t.headers) {
+		return 0, 0, false
+	}
+	start, size := t.viewport()
+	h := t.headers[pos]
+	x = h.X - t.Scroll
+	if x < 0 || x+h.Width > size {
+		return 0, 0, false
+	}
+	x += start
+	if t.IsRTL() {
+		x = t.StripWidth() - x - h.Width
+	}
+	return x, h.Width, true
+}
+
+// CloseRect returns the x offset within the strip of the close button of
+// the header at pos. ok is false for tabs that cannot be closed or are not
+// visible.
+func (t *Tabs) CloseRect(pos int) (x float32, ok bool) {
+	hx, width, visible := t.HeaderRect(pos)
+	if !visible || !t.Tabs[t.headers[pos].Index].Closable {
+		return 0, false
+	}
+	if t.IsRTL() {
+		return hx + t.HeaderPadding.X, true
+	}
+	return hx + width - t.HeaderPadding.X - t.CloseSize(), true
+}
+
+// HeaderAt returns the header position under point (relative to the strip)
+// and whether the point is on its close button, or -1.
+func (t *Tabs) HeaderAt(point math.Vec2f32) (pos int, onClose bool) {
+	if point.Y < 0 || point.Y > t.StripHeight() {
+		return -1, false
+	}
+	for i := range t.headers {
+		x, width, ok := t.HeaderRect(i)
+		if !ok || point.X < x || point.X > x+width {
+			continue
+		}
+		if cx, closable := t.CloseRect(i); closable {
+			top := (t.StripHeight() - t.CloseSize()) / 2
+			onClose = point.X >= cx && point.X <= cx+t.CloseSize() && point.Y >= top && point.Y <= top+t.CloseSize()
+		}
+		return i, onClose
+	}
+	return -1, false
+}
+
+// ScrollButtonAt returns which scroll button x (relative to the strip) is
+// on: -1 for the one that scrolls back towards the first tab, 1 for the
+// other, or 0.
+func (t *Tabs) ScrollButtonAt(x float32) int {
+	if !t.Overflowing() {
+		return 0
+	}
+	button := t.ScrollButtonWidth()
+	dir := 0
+	switch {
+	case x >= 0 && x < button:
+		dir = -1
+	case x > t.StripWidth()-button && x <= t.StripWidth():
+		dir = 1
+	}
+	if t.IsRTL() {
+		dir = -dir
+	}
+	return dir
+}
+
+// DropTarget returns the position the header at from moves to when it is
+// dragged to point (relative to the strip), or from if it stays.
+func (t *Tabs) DropTarget(from int, point math.Vec2f32) int {
+	to, _ := t.HeaderAt(point)
+	if from < 0 || from >= len(t.headers) || to < 0 || to == from {
+		return from
+	}
+	x, width, _ := t.HeaderRect(to)
+	dragged := t.headers[from].Width
+	// Move only once the pointer is over the space the dragged header would
+	// take there, so that headers of different widths do not swap back and
+	// forth.
+	if (to > from) == t.IsRTL() {
+		if point.X > x+dragged {
+			return from
+		}
+	} else if point.X < x+width-dragged {
+		return from
+	}
+	return to
+}
+
+// ScrollBy scrolls the strip by whole headers, delta headers to the end.
+func (t *Tabs) ScrollBy(delta int) {
+	if len(t.headers) == 0 {
+		return
+	}
+	first := 0
+	for i, h := range t.headers {
+		if h.X >= t.Scroll {
+			first = i
+			break
+		}
+	}
+	first = max(0, min(len(t.headers)-1, first+delta))
+	t.Scroll = t.headers[first].X
+	t.clampScroll()
+}
+
+// ScrollIntoView scrolls the strip so the header at pos is fully visible.
+func (t *Tabs) ScrollIntoView(pos int) {
+	if pos < 0 || pos >= len(t.headers) {
+		return
+	}
+	_, size := t.viewport()
+	h := t.headers[pos]
+	if h.X < t.Scroll {
+		t.Scroll = h.X
+	} else if h.X+h.Width > t.Scroll+size {
+		t.Scroll = h.X + h.Width - size
+	}
+	t.clampScroll()
+}
+
+func (t *Tabs) clampScroll() {
+	if !t.Overflowing() {
+		t.Scroll = 0
+		return
+	}
+	last := t.headers[len(t.headers)-1]
+	_, size := t.viewport()
+	t.Scroll = max(0, min(t.Scroll, last.X+last.Width-size))
+}
+
+// Activate makes tab i active and calls OnTabChange if it changed.
+func (t *Tabs) Activate(i int) {
+	if i < 0 || i >= len(t.Tabs) || i == t.Active || t.Tabs[i].Disabled || t.closed[t.Tabs[i].key()] {
+		return
+	}
+	t.Active = i
+	for pos, h := range t.headers {
+		if h.Index == i {
+			t.ScrollIntoView(pos)
+		}
+	}
+	if t.OnTabChange != nil {
+		t.OnTabChange(t, i)
+	}
+}
+
+// ActivateNext activates the enabled tab delta steps away in display order,
+// stopping at the first or last one.
+func (t *Tabs) ActivateNext(delta int) {
+	visible := t.VisibleTabs()
+	step, steps := 1, delta
+	if delta < 0 {
+		step, steps = -1, -delta
+	}
+	target := -1
+	for pos := slices.Index(visible, t.Active) + step; pos >= 0 && pos < len(visible) && steps > 0; pos += step {
+		if !t.Tabs[visible[pos]].Disabled {
+			target = visible[pos]
+			steps--
+		}
+	}
+	if target >= 0 {
+		t.Activate(target)
+	}
+}
+
+// CloseTab closes tab i, activating its neighbour if it was active.
+func (t *Tabs) CloseTab(i int) {
+	if i < 0 || i >= len(t.Tabs) || !t.Tabs[i].Closable {
+		return
+	}
+	if i == t.Active {
+		visible := t.VisibleTabs()
+		for pos, index := range visible {
+			if index != i {
+				continue
+			}
+			if pos+1 < len(visible) {
+				t.Activate(visible[pos+1])
+			} else if pos > 0 {
+				t.Activate(visible[pos-1])
+			}
+		}
+	}
+	if t.OnTabClose != nil {
+		t.OnTabClose(t, i)
+		return
+	}
+	if t.closed == nil {
+		t.closed = make(map[string]bool)
+	}
+	t.closed[t.Tabs[i].key()] = true
+}
+
+// MoveTab moves the tab at display position from to position to.
+func (t *Tabs) MoveTab(from, to int) {
+	visible := t.VisibleTabs()
+	if from < 0 || to < 0 || from >= len(visible) || to >= len(visible) || from == to {
+		return
+	}
+	moved := visible[from]
+	visible = append(visible[:from], visible[from+1:]...)
+	visible = append(visible[:to], append([]int{moved}, visible[to:]...)...)
+	t.order = t.order[:0]
+	for _, i := range visible {
+		t.order = append(t.order, t.Tabs[i].key())
+	}
+}
+
+// Panel returns the mounted content of the active tab, or nil.
+func (t *Tabs) Panel() IComponent {
+	if len(t.children) == 0 {
+		return nil
+	}
+	return t.children[0]
+}
+
+// buildPanel builds the content of the active tab, wrapped in a container
+// named after the tab so that state inside different tabs does not mix.
+// It runs after the tree is built, so it puts back the theme the tabs were
+// created with.
+func (t *Tabs) buildPanel() IComponent {
+	if t.Active < 0 || t.Active >= len(t.Tabs) {
+		return nil
+	}
+	defer UseTheme(UseTheme(t.theme))
+	tab := t.Tabs[t.Active]
+	content := tab.Content
+	if tab.Build != nil {
+		content = tab.Build()
+	}
+	panel := NewContainer().SetID("panel:" + tab.key()).SetDisplay(DisplayBlock)
+	if content != nil {
+		panel.AddChild(content)
+	}
+	return panel
+}
+
+func (t *Tabs) mountPanel(panel IComponent) {
+	t.children = nil
+	if panel != nil {
+		t.children = []IComponent{panel}
+		panel.SetParent(t)
+	}
+}
+
+// ensureActiveVisible moves Active off a closed or disabled tab.
+func (t *Tabs) ensureActiveVisible() {
+	visible := t.VisibleTabs()
+	for _, i := range visible {
+		if i == t.Active && !t.Tabs[i].Disabled {
+			return
+		}
+	}
+	for _, i := range visible {
+		if !t.Tabs[i].Disabled {
+			t.Active = i
+			return
+		}
+	}
+	t.Active = -1
+}
+
+func (t *Tabs) saveState() *tabsState {
+	st := &tabsState{
+		order:        t.order,
+		closed:       t.closed,
+		scroll:       t.Scroll,
+		hovered:      t.HoveredTab,
+		pressed:      t.PressedTab,
+		hoveredClose: t.HoveredClose,
+		pressedClose: t.PressedClose,
+	}
+	if t.Active >= 0 && t.Active < len(t.Tabs) {
+		st.active = t.Tabs[t.Active].key()
+	}
+	return st
+}
+
+func (t *Tabs) restoreState(st *tabsState) {
+	t.order = st.order
+	t.closed = st.closed
+	t.Scroll = st.scroll
+	t.HoveredTab = st.hovered
+	t.PressedTab = st.pressed
+	t.HoveredClose = st.hoveredClose
+	t.PressedClose = st.pressedClose
+	if t.controlled {
+		return
+	}
+	for i, tab := range t.Tabs {
+		if tab.key() == st.active {
+			t.Active = i
+		}
+	}
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (t *Tabs) SetID(id string) *Tabs {
+	t.Component.setID(id)
+	return t
+}
+
+func (t *Tabs) AddTab(tab *Tab) *Tabs {
+	t.Tabs = append(t.Tabs, tab)
+	return t
+}
+
+// SetActive makes the tabs controlled: the given tab wins over the one kept
+// from the previous frame.
+func (t *Tabs) SetActive(index int) *Tabs {
+	t.Active = index
+	t.controlled = true
+	return t
+}
+
+func (t *Tabs) SetReorderable(reorderable bool) *Tabs {
+	t.Reorderable = reorderable
+	return t
+}
+
+func (t *Tabs) SetOnTabChange(callback func(self *Tabs, index int)) *Tabs {
+	t.OnTabChange = callback
+	return t
+}
+
+func (t *Tabs) SetOnTabClose(callback func(self *Tabs, index int)) *Tabs {
+	t.OnTabClose = callback
+	return t
+}
+
+func (t *Tabs) SetFontSize(size float32) *Tabs {
+	if size > 0 {
+		t.FontSize = size
+	}
+	return t
+}
+
+func (t *Tabs) SetTextColor(color color.RGBA) *Tabs {
+	t.TextColor = color
+	return t
+}
+
+func (t *Tabs) SetHeaderColor(color color.RGBA) *Tabs {
+	t.HeaderColor = color
+	return t
+}
+
+func (t *Tabs) SetActiveColor(color color.RGBA) *Tabs {
+	t.ActiveColor = color
+	return t
+}
+
+func (t *Tabs) SetIndicatorColor(color color.RGBA) *Tabs {
+	t.IndicatorColor = color
+	return t
+}
+
+func (t *Tabs) SetBackgroundColor(color color.RGBA) *Tabs {
+	t.Component.setBackgroundColor(color)
+	return t
+}
+
+func (t *Tabs) SetSize(size math.Vec2f32) *Tabs {
+	t.Component.setSize(size)
+	return t
+}
+
+func (t *Tabs) SetWidthPercent(widthPercent float32) *Tabs {
+	t.Component.setWidthPercent(widthPercent)
+	return t
+}
+
+func (t *Tabs) SetDisplay(d Display) *Tabs {
+	t.Component.setDisplay(d)
+	return t
+}
+
+func (t *Tabs) SetPosition(pos Position) *Tabs {
+	t.Component.setPos(pos)
+	return t
+}
+
+func (t *Tabs) SetMargin(margin math.Vec2f32) *Tabs {
+	t.Component.setMargin(margin)
+	return t
+}
+
+func (t *Tabs) SetPadding(padding math.Vec2f32) *Tabs {
+	t.Component.setPadding(padding)
+	return t
+}
+
+func (t *Tabs) SetBorder(border math.Vec2f32) *Tabs {
+	t.Component.setBorder(border)
+	return t
+}
+
+func (t *Tabs) SetBorderColor(color color.RGBA) *Tabs {
+	t.Component.setBorderColor(color)
+	return t
+}
+
+func (t *Tabs) SetBorderRadius(radius float32) *Tabs {
+	t.Component.setBorderRadius(radius)
+	return t
+}
+
+func (t *Tabs) SetZIndex(zIndex int) *Tabs {
+	t.Component.setZIndex(zIndex)
+	return t
+}
+
+func (t *Tabs) SetDirection(direction Direction) *Tabs {
+	t.Component.setDirection(direction)
+	return t
+}
+
+func (t *Tabs) SetTooltip(tooltip *Tooltip) *Tabs {
+	t.Component.setTooltip(tooltip)
+	return t
+}
+
+func (t *Tabs) SetTransform(transform *Transform) *Tabs {
+	t.Component.setTransform(transform)
+	return t
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Tab Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (t *Tab) SetKey(key string) *Tab {
+	t.Key = key
+	return t
+}
+
+func (t *Tab) SetClosable(closable bool) *Tab {
+	t.Closable = closable
+	return t
+}
+
+func (t *Tab) SetDisabled(disabled bool) *Tab {
+	t.Disabled = disabled
+	return t
+}
@@ -11,22 +11,35 @@ import (
 
 type Text struct {
 	Component
-	Content  string
-	Color    color.RGBA
-	FontSize float32
-	Wrapped  bool
+	Content       string
+	Color         color.RGBA
+	FontSize      float32
+	Wrapped       bool
+	Align         TextAlign
+	VerticalAlign TextVerticalAlign
+	LineHeight    float32 // Multiple of FontSize
+	MaxLines      int     // 0 means unlimited
+	Overflow      TextOverflow
 }
 
 func NewText(content string) *Text {
+	theme := CurrentTheme()
 	t := &Text{
-		Component: newComponentBase(TextKind),
-		Content:   content,
-		Color:     color.White, // Default black
-		FontSize:  16.0,        // Default font size
+		Component:  newComponentBase(TextKind),
+		Content:    content,
+		Color:      theme.Text,
+		FontSize:   theme.FontSizeBody,
+		LineHeight: 1.0,
+		Align:      TextAlignStart,
 	}
 	return t
 }
 
+// LineHeightPixels returns the distance between consecutive baselines.
+func (t *Text) LineHeightPixels() float32 {
+	return t.FontSize * t.LineHeight
+}
+
 // ——————————————————————————————————————————————————————————————————————————————
 // Fluent Setters
 // ——————————————————————————————————————————————————————————————————————————————
@@ -104,7 +117,79 @@ func (t *Text) SetZIndex(zIndex int) *Text {
 	return t
 }
 
+func (t *Text) SetTooltip(tooltip *Tooltip) *Text {
+	t.Component.setTooltip(tooltip)
+	return t
+}
+
+func (t *Text) SetTransform(transform *Transform) *Text {
+	t.Component.setTransform(transform)
+	return t
+}
+
+func (t *Text) SetOpacity(opacity float32) *Text {
+	t.Component.setOpacity(opacity)
+	return t
+}
+
+// SetTransition animates changes to the given properties, replacing the
+// transitions already set for them.
+func (t *Text) SetTransition(transitions ...*Transition) *Text {
+	t.Component.setTransitions(transitions...)
+	return t
+}
+
 func (t *Text) SetTextWrapped(wrapped bool) *Text {
 	t.Wrapped = wrapped
 	return t
 }
+
+func (t *Text) SetAlign(align TextAlign) *Text {
+	t.Align = align
+	return t
+}
+
+// SetVerticalAlign positions the lines inside a box taller than the text.
+func (t *Text) SetVerticalAlign(align TextVerticalAlign) *Text {
+	t.VerticalAlign = align
+	return t
+}
+
+// SetLineHeight sets the line height as a multiple of the font size.
+func (t *Text) SetLineHeight(lineHeight float32) *Text {
+	if lineHeight <= 0 {
+		lineHeight = 1.0 // Reset to default if invalid
+	}
+	t.LineHeight = lineHeight
+	return t
+}
+
+// SetMaxLines limits the number of lines; the last kept line ends with an
+// ellipsis when text is cut. 0 removes the limit.
+func (t *Text) SetMaxLines(maxLines int) *Text {
+	if maxLines < 0 {
+		maxLines = 0
+	}
+	t.MaxLines = maxLines
+	return t
+}
+
+func (t *Text) SetOverflow(overflow TextOverflow) *Text {
+	t.Overflow = overflow
+	return t
+}
+
+func (t *Text) SetMargin(margin math.Vec2f32) *Text {
+	t.Component.setMargin(margin)
+	return t
+}
+
+func (t *Text) SetPadding(padding math.Vec2f32) *Text {
+	t.Component.setPadding(padding)
+	return t
+}
+
+func (t *Text) SetDirection(direction Direction) *Text {
+	t.Component.setDirection(direction)
+	return t
+}
@@ -0,0 +1,280 @@
+package ui
+
+import (
+	stdmath "math"
+	"strings"
+
+	"github.com/aj-2000/mogi/internal/bidi"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Text layout
+// ——————————————————————————————————————————————————————————————————————————————
+//
+// Line breaking and alignment live here so that the size pass
+// (calculateSizeRecursive) and the renderer produce exactly the same lines.
+
+// Ellipsis is appended to truncated text. The bundled font atlas only covers
+// ASCII, so three dots are used instead of U+2026.
+const Ellipsis = "..."
+
+// TextLine is a single laid-out line of text.
+type TextLine struct {
+	Text  string
+	Width float32
+	// SoftBreak is true when the line ends because of wrapping rather than
+	// an explicit newline or the end of the text. Only such lines are justified.
+	SoftBreak bool
+}
+
+// TextRun is a piece of text positioned relative to the content box of its
+// component. A line yields one run, or one run per word when justified.
+type TextRun struct {
+	Text string
+	Pos  math.Vec2f32
+}
+
+// TextBlock is the result of breaking a Text into lines.
+type TextBlock struct {
+	Lines      []TextLine
+	LineHeight float32
+	Size       math.Vec2f32
+}
+
+// BreakText breaks content into lines no wider than maxWidth. Explicit "\n"
+// always starts a new line. With wrap set, lines break between words, and
+// words wider than maxWidth are broken between characters.
+func (le *LayoutEngine) BreakText(content string, fontSize, maxWidth float32, wrap bool) []TextLine {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	paragraphs := strings.Split(content, "\n")
+
+	var lines []TextLine
+	for _, paragraph := range paragraphs {
+		if !wrap {
+			lines = append(lines, TextLine{Text: paragraph, Width: le.CalculateTextWidth(paragraph, fontSize)})
+			continue
+		}
+		lines = append(lines, le.wrapParagraph(paragraph, fontSize, maxWidth)...)
+	}
+	return lines
+}
+
+func (le *LayoutEngine) wrapParagraph(paragraph string, fontSize, maxWidth float32) []TextLine {
+	words := strings.Fields(paragraph)
+	if len(words) == 0 {
+		return []TextLine{{}}
+	}
+
+	var lines []TextLine
+	current := ""
+	currentWidth := float32(0)
+
+	commit := func(soft bool) {
+		lines = append(lines, TextLine{Text: current, Width: currentWidth, SoftBreak: soft})
+		current = ""
+		currentWidth = 0
+	}
+
+	for _, w := range words {
+		candidate := w
+		if current != "" {
+			candidate = current + " " + w
+		}
+		if width := le.CalculateTextWidth(candidate, fontSize); width <= maxWidth {
+			current = candidate
+			currentWidth = width
+			continue
+		}
+
+		if current != "" {
+			commit(true)
+		}
+		if width := le.CalculateTextWidth(w, fontSize); width <= maxWidth {
+			current = w
+			currentWidth = width
+			continue
+		}
+
+		// The word alone is too wide: break it between characters.
+		chunks := le.breakWord(w, fontSize, maxWidth)
+		for _, chunk := range chunks[:len(chunks)-1] {
+			current = chunk
+			currentWidth = le.CalculateTextWidth(chunk, fontSize)
+			commit(true)
+		}
+		current = chunks[len(chunks)-1]
+		currentWidth = le.CalculateTextWidth(current, fontSize)
+	}
+	commit(false)
+	return lines
+}
+
+// breakWord splits word into chunks that each fit in maxWidth. Every chunk
+// holds at least one character so that layout always makes progress.
+func (le *LayoutEngine) breakWord(word string, fontSize, maxWidth float32) []string {
+	var chunks []string
+	runes := []rune(word)
+	start := 0
+	for start < len(runes) {
+		end := start + 1
+		for end < len(runes) && le.CalculateTextWidth(string(runes[start:end+1]), fontSize) <= maxWidth {
+			end++
+		}
+		chunks = append(chunks, string(runes[start:end]))
+		start = end
+	}
+	return chunks
+}
+
+// TruncateText shortens text so that text+Ellipsis fits in maxWidth.
+// Text that already fits is returned unchanged.
+func (le *LayoutEngine) TruncateText(text string, fontSize, maxWidth float32) string {
+	if le.CalculateTextWidth(text, fontSize) <= maxWidth {
+		return text
+	}
+	return le.ellipsize(text, fontSize, maxWidth)
+}
+
+// ellipsize returns the longest prefix of text that fits in maxWidth
+// together with a trailing Ellipsis.
+func (le *LayoutEngine) ellipsize(text string, fontSize, maxWidth float32) string {
+	runes := []rune(strings.TrimRight(text, " "))
+	if le.CalculateTextWidth(string(runes)+Ellipsis, fontSize) <= maxWidth {
+		return string(runes) + Ellipsis
+	}
+	// Binary search the longest prefix that still fits with the ellipsis.
+	lo, hi := 0, len(runes)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if le.CalculateTextWidth(string(runes[:mid])+Ellipsis, fontSize) <= maxWidth {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return strings.TrimRight(string(runes[:lo]), " ") + Ellipsis
+}
+
+// LayoutTextBlock breaks t into lines for a content box at most maxWidth
+// wide, applying MaxLines and ellipsis truncation.
+func (le *LayoutEngine) LayoutTextBlock(t *Text, maxWidth float32) TextBlock {
+	breakWidth := maxWidth
+	if !t.Wrapped && t.Overflow != TextOverflowEllipsis {
+		breakWidth = float32(stdmath.Inf(1))
+	}
+
+	lines := le.BreakText(t.Content, t.FontSize, breakWidth, t.Wrapped)
+
+	truncated := false
+	if t.MaxLines > 0 && len(lines) > t.MaxLines {
+		lines = lines[:t.MaxLines]
+		truncated = true
+	}
+
+	if t.Overflow == TextOverflowEllipsis || truncated {
+		for i := range lines {
+			text := lines[i].Text
+			if truncated && i == len(lines)-1 {
+				// The text continues past MaxLines: always mark the cut.
+				text = le.ellipsize(text, t.FontSize, maxWidth)
+			} else if t.Overflow == TextOverflowEllipsis {
+				text = le.TruncateText(text, t.FontSize, maxWidth)
+			}
+			if text != lines[i].Text {
+				lines[i].Text = text
+				lines[i].Width = le.CalculateTextWidth(text, t.FontSize)
+				lines[i].SoftBreak = false
+			}
+		}
+	}
+
+	block := TextBlock{Lines: lines, LineHeight: t.LineHeightPixels()}
+	for _, line := range lines {
+		block.Size.X = max(block.Size.X, line.Width)
+	}
+	block.Size.Y = float32(len(lines)) * block.LineHeight
+	return block
+}
+
+// textDirection returns the bidi base direction of text shown by c: the
+// writing direction of c, or that of the first strong character of content.
+func textDirection(c IComponent, content string) bidi.Direction {
+	switch c.Direction() {
+	case DirectionLTR:
+		return bidi.LeftToRight
+	case DirectionRTL:
+		return bidi.RightToLeft
+	}
+	return bidi.ParagraphDirection(content)
+}
+
+// LayoutText positions the lines of t inside a content box of the given size
+// according to its horizontal and vertical alignment. Runs hold text in
+// visual order, ready to be drawn left to right.
+func (le *LayoutEngine) LayoutText(t *Text, box math.Vec2f32) []TextRun {
+	block := le.LayoutTextBlock(t, box.X)
+	base := textDirection(t, t.Content)
+	rtl := base == bidi.RightToLeft
+
+	offsetY := float32(0)
+	switch t.VerticalAlign {
+	case TextVerticalAlignMiddle:
+		offsetY = (box.Y - block.Size.Y) / 2
+	case TextVerticalAlignBottom:
+		offsetY = box.Y - block.Size.Y
+	}
+	// Glyphs are drawn from the top of the font box; centre them in taller lines.
+	offsetY += (block.LineHeight - t.FontSize) / 2
+
+	runs := make([]TextRun, 0, len(block.Lines))
+	for i, line := range block.Lines {
+		y := offsetY + float32(i)*block.LineHeight
+		text := bidi.Visual(line.Text, base)
+		switch t.Align.Resolve(rtl) {
+		case TextAlignCenter:
+			runs = append(runs, TextRun{Text: text, Pos: math.Vec2f32{X: (box.X - line.Width) / 2, Y: y}})
+		case TextAlignRight:
+			runs = append(runs, TextRun{Text: text, Pos: math.Vec2f32{X: box.X - line.Width, Y: y}})
+		case TextAlignJustify:
+			runs = append(runs, le.justifyLine(line, t.FontSize, box.X, y, base)...)
+		default:
+			runs = append(runs, TextRun{Text: text, Pos: math.Vec2f32{Y: y}})
+		}
+	}
+	return runs
+}
+
+// justifyLine spreads the words of a soft-wrapped line over width. In RTL
+// paragraphs words are placed from the right edge.
+func (le *LayoutEngine) justifyLine(line TextLine, fontSize, width, y float32, base bidi.Direction) []TextRun {
+	rtl := base == bidi.RightToLeft
+	words := strings.Fields(line.Text)
+	if !line.SoftBreak || len(words) < 2 {
+		x := float32(0)
+		if rtl {
+			x = width - line.Width
+		}
+		return []TextRun{{Text: bidi.Visual(line.Text, base), Pos: math.Vec2f32{X: x, Y: y}}}
+	}
+
+	wordWidths := make([]float32, len(words))
+	total := float32(0)
+	for i, w := range words {
+		wordWidths[i] = le.CalculateTextWidth(w, fontSize)
+		total += wordWidths[i]
+	}
+	spacing := (width - total) / float32(len(words)-1)
+
+	runs := make([]TextRun, len(words))
+	x := float32(0)
+	for i, w := range words {
+		pos := x
+		if rtl {
+			pos = width - x - wordWidths[i]
+		}
+		runs[i] = TextRun{Text: bidi.Visual(w, base), Pos: math.Vec2f32{X: pos, Y: y}}
+		x += wordWidths[i] + spacing
+	}
+	return runs
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/aj-2000/mogi/math"
+)
+
+// monospace measures every rune as 10 pixels wide.
+func monospace(text string, _ float32) float32 {
+	return float32(utf8.RuneCountInString(text)) * 10
+}
+
+// runsString writes runs as "text@x" separated by spaces.
+func runsString(runs []TextRun) string {
+	parts := make([]string, len(runs))
+	for i, run := range runs {
+		parts[i] = fmt.Sprintf("%s@%g", run.Text, run.Pos.X)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestLayoutTextDirection(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  *Text
+		width float32
+		want  string
+	}{
+		{"latin starts left", NewText("abc"), 100, "abc@0"},
+		{"hebrew starts right", NewText("אבג"), 100, "גבא@70"},
+		{"mixed ltr paragraph", NewText("abc אבג"), 100, "abc גבא@0"},
+		{"mixed rtl paragraph", NewText("אבג abc"), 100, "abc גבא@30"},
+		{"forced rtl", NewText("abc").SetDirection(DirectionRTL), 100, "abc@70"},
+		{"forced ltr", NewText("אבג").SetDirection(DirectionLTR), 100, "גבא@0"},
+		{"end in rtl is left", NewText("אבג").SetAlign(TextAlignEnd), 100, "גבא@0"},
+		{"explicit left in rtl", NewText("אבג").SetAlign(TextAlignLeft), 100, "גבא@0"},
+		{"arabic is shaped", NewText("بب"), 100, "ﺐﺑ@80"},
+		{
+			"rtl justify from the right",
+			NewText("אב גד הו").SetTextWrapped(true).SetAlign(TextAlignJustify), 50,
+			"בא@30 דג@0 וה@30",
+		},
+		{
+			"lines wrap in logical order",
+			NewText("אב גד").SetTextWrapped(true), 30,
+			"בא@10 דג@10",
+		},
+	}
+	le := NewLayoutEngine(monospace)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := le.LayoutText(tt.text, math.Vec2f32{X: tt.width, Y: 100})
+			if got := runsString(runs); got != tt.want {
+				t.Errorf("LayoutText(%q) = %s, want %s", tt.text.Content, got, tt.want)
+			}
+		})
+	}
+}
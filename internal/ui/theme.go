@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"github.com/aj-2000/mogi/color"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Theme
+// ——————————————————————————————————————————————————————————————————————————————
+
+// ThemeMode is whether a theme draws dark content on a light background or
+// light content on a dark one.
+type ThemeMode int
+
+const (
+	ThemeDark ThemeMode = iota
+	ThemeLight
+)
+
+// Theme holds the design tokens built-in components take their default
+// colors and sizes from. Components read the theme in use when they are
+// constructed, so setters called on them afterwards still win.
+type Theme struct {
+	Mode ThemeMode
+
+	// Background is behind everything in the window.
+	Background color.RGBA
+	// Surface fills popups, dialogs and inputs; SurfaceRaised the headers
+	// and placeholders that sit on them.
+	Surface       color.RGBA
+	SurfaceRaised color.RGBA
+	SurfaceHover  color.RGBA
+	SurfaceActive color.RGBA
+
+	// Primary fills buttons and marks what is checked, filled or selected.
+	Primary        color.RGBA
+	PrimaryHover   color.RGBA
+	PrimaryPressed color.RGBA
+	// OnPrimary is text drawn on Primary.
+	OnPrimary color.RGBA
+
+	Text         color.RGBA
+	TextMuted    color.RGBA
+	TextDisabled color.RGBA
+	Link         color.RGBA
+	Code         color.RGBA
+
+	// Border edges surfaces; Outline the boxes of checkboxes, radio buttons
+	// and selects.
+	Border  color.RGBA
+	Outline color.RGBA
+	// Track is the empty part of sliders, switches and progress bars, and
+	// Knob the part that slides along it.
+	Track color.RGBA
+	Knob  color.RGBA
+	Focus color.RGBA
+	// Tint is the color of translucent layers over content, such as hovered
+	// rows, stripes and grid lines, drawn at a low alpha.
+	Tint     color.RGBA
+	Backdrop color.RGBA
+
+	// Spacing is the unit of the spacing scale; see Space.
+	Spacing float32
+
+	RadiusSmall  float32
+	RadiusMedium float32
+	RadiusLarge  float32
+
+	FontSizeCaption float32
+	FontSizeSmall   float32
+	FontSizeBody    float32
+	FontSizeTitle   float32
+}
+
+// DarkTheme is the default theme.
+func DarkTheme() Theme {
+	return Theme{
+		Mode:            ThemeDark,
+		Background:      color.RGBA{R: 0.07, G: 0.07, B: 0.08, A: 1},
+		Surface:         color.RGBA{R: 0.15, G: 0.15, B: 0.15, A: 1},
+		SurfaceRaised:   color.RGBA{R: 0.2, G: 0.2, B: 0.2, A: 1},
+		SurfaceHover:    color.RGBA{R: 0.24, G: 0.24, B: 0.24, A: 1},
+		SurfaceActive:   color.RGBA{R: 0.28, G: 0.28, B: 0.28, A: 1},
+		Primary:         color.RGBA{R: 0.2, G: 0.45, B: 0.9, A: 1},
+		PrimaryHover:    color.RGBA{R: 0.3, G: 0.5, B: 0.9, A: 1},
+		PrimaryPressed:  color.RGBA{R: 0.1, G: 0.3, B: 0.7, A: 1},
+		OnPrimary:       color.White,
+		Text:            color.White,
+		TextMuted:       color.RGBA{R: 0.75, G: 0.75, B: 0.75, A: 1},
+		TextDisabled:    color.Gray,
+		Link:            color.RGBA{R: 0.4, G: 0.6, B: 1, A: 1},
+		Code:            color.RGBA{R: 0.9, G: 0.8, B: 0.6, A: 1},
+		Border:          color.RGBA{R: 0.35, G: 0.35, B: 0.35, A: 1},
+		Outline:         color.RGBA{R: 0.6, G: 0.6, B: 0.6, A: 1},
+		Track:           color.RGBA{R: 0.35, G: 0.35, B: 0.35, A: 1},
+		Knob:            color.White,
+		Focus:           color.RGBA{R: 1, G: 0.8, B: 0.2, A: 1},
+		Tint:            color.White,
+		Backdrop:        color.RGBA{A: 0.5},
+		Spacing:         4,
+		RadiusSmall:     4,
+		RadiusMedium:    8,
+		RadiusLarge:     12,
+		FontSizeCaption: 12,
+		FontSizeSmall:   14,
+		FontSizeBody:    16,
+		FontSizeTitle:   20,
+	}
+}
+
+func LightTheme() Theme {
+	t := DarkTheme()
+	t.Mode = ThemeLight
+	t.Background = color.RGBA{R: 0.95, G: 0.95, B: 0.96, A: 1}
+	t.Surface = color.White
+	t.SurfaceRaised = color.RGBA{R: 0.91, G: 0.91, B: 0.93, A: 1}
+	t.SurfaceHover = color.RGBA{R: 0.87, G: 0.87, B: 0.9, A: 1}
+	t.SurfaceActive = color.RGBA{R: 0.82, G: 0.82, B: 0.86, A: 1}
+	t.Primary = color.RGBA{R: 0.15, G: 0.4, B: 0.85, A: 1}
+	t.PrimaryHover = color.RGBA{R: 0.25, G: 0.48, B: 0.9, A: 1}
+	t.PrimaryPressed = color.RGBA{R: 0.1, G: 0.3, B: 0.7, A: 1}
+	t.Text = color.RGBA{R: 0.1, G: 0.1, B: 0.12, A: 1}
+	t.TextMuted = color.RGBA{R: 0.4, G: 0.4, B: 0.45, A: 1}
+	t.TextDisabled = color.RGBA{R: 0.62, G: 0.62, B: 0.65, A: 1}
+	t.Link = color.RGBA{R: 0.1, G: 0.4, B: 0.85, A: 1}
+	t.Code = color.RGBA{R: 0.65, G: 0.25, B: 0.1, A: 1}
+	t.Border = color.RGBA{R: 0.8, G: 0.8, B: 0.82, A: 1}
+	t.Outline = color.RGBA{R: 0.45, G: 0.45, B: 0.5, A: 1}
+	t.Track = color.RGBA{R: 0.8, G: 0.8, B: 0.82, A: 1}
+	t.Focus = color.RGBA{R: 0.95, G: 0.6, B: 0, A: 1}
+	t.Tint = color.Black
+	t.Backdrop = color.RGBA{A: 0.35}
+	return t
+}
+
+// ThemeFor returns the built-in theme of mode.
+func ThemeFor(mode ThemeMode) Theme {
+	if mode == ThemeLight {
+		return LightTheme()
+	}
+	return DarkTheme()
+}
+
+// Space returns steps units of the spacing scale.
+func (t Theme) Space(steps float32) float32 { return steps * t.Spacing }
+
+// Tinted returns Tint at alpha, for a translucent layer over content.
+func (t Theme) Tinted(alpha float32) color.RGBA { return t.Tint.WithAlpha(alpha) }
+
+// Selection is the translucent Primary that selected rows are filled with.
+func (t Theme) Selection() color.RGBA { return t.Primary.WithAlpha(0.6) }
+
+// activeTheme is the theme components constructed now take their defaults
+// from. The tree is built on one goroutine, so a plain variable is enough.
+var activeTheme = DarkTheme()
+
+// CurrentTheme returns the theme components constructed now take their
+// defaults from.
+func CurrentTheme() Theme { return activeTheme }
+
+// UseTheme makes theme the one components take their defaults from and
+// returns the one in use before.
+func UseTheme(theme Theme) Theme {
+	previous := activeTheme
+	activeTheme = theme
+	return previous
+}
+
+// WithTheme builds a subtree with theme in use, for a part of the tree
+// themed apart from the rest, and then restores the theme in use before.
+// Parts of the subtree built later, such as lazy tab panels, virtual list
+// items and table cells, are built with the theme their Tabs, list or
+// table was created with.
+func WithTheme(theme Theme, build func() IComponent) IComponent {
+	previous := UseTheme(theme)
+	defer UseTheme(previous)
+	return build()
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// TestWithThemeReachesLazyBuilders builds components with lazily built
+// children inside WithTheme and builds the children afterwards, as the
+// layout engine does, outside it.
+func TestWithThemeReachesLazyBuilders(t *testing.T) {
+	custom := DarkTheme()
+	custom.Text = color.RGBA{R: 1, A: 1}
+	label := func(int) IComponent { return NewText("x") }
+
+	var tabs *Tabs
+	var list *VirtualList
+	var grid *VirtualGrid
+	WithTheme(custom, func() IComponent {
+		tabs = NewTabs(&Tab{Title: "a", Build: func() IComponent { return label(0) }})
+		list = NewVirtualList(1, nil, label)
+		grid = NewVirtualGrid(1, math.Vec2f32{X: 10, Y: 10}, label)
+		return NewContainer()
+	})
+	outer := CurrentTheme()
+
+	built := map[string]IComponent{
+		"tab panel": tabs.buildPanel().Children()[0],
+		"list item": list.buildItem(0),
+		"grid cell": grid.buildItem(0).Children()[0],
+	}
+	for name, comp := range built {
+		if got := comp.(*Text).Color; got != custom.Text {
+			t.Errorf("%s built in %v, want the subtree's %v", name, got, custom.Text)
+		}
+	}
+	if CurrentTheme() != outer {
+		t.Error("building lazily left the subtree's theme in use")
+	}
+}
+
+func TestThemeSpacingAndRadius(t *testing.T) {
+	custom := DarkTheme()
+	custom.Spacing, custom.RadiusSmall = 10, 7
+	previous := UseTheme(custom)
+	defer UseTheme(previous)
+
+	bubble := NewTooltip("tip").Bubble()
+	if bubble.Padding() != (math.Vec2f32{X: 20, Y: 10}) || bubble.BorderRadius() != 7 {
+		t.Errorf("tooltip bubble padding %v, radius %v", bubble.Padding(), bubble.BorderRadius())
+	}
+	s := NewSelect("a", "b")
+	if popup := s.Popup(100); popup.BorderRadius() != 7 {
+		t.Errorf("select popup radius %v, want 7", popup.BorderRadius())
+	}
+	if tabs := NewTabs(); tabs.HeaderPadding != (math.Vec2f32{X: 30, Y: 20}) || tabs.HeaderGap != 5 {
+		t.Errorf("tab header padding %v, gap %v", tabs.HeaderPadding, tabs.HeaderGap)
+	}
+}
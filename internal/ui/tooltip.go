@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Tooltip
+// ——————————————————————————————————————————————————————————————————————————————
+
+// DefaultTooltipDelay is how long, in seconds, a component must be hovered
+// before its tooltip appears.
+const DefaultTooltipDelay float32 = 0.5
+
+// Tooltip is a small popup shown next to a component after it has been
+// hovered for Delay seconds. It shows either Text or, if set, Content.
+// Attach one with a component's SetTooltip.
+type Tooltip struct {
+	Text            string
+	Content         IComponent
+	Placement       Placement
+	Delay           float32
+	FontSize        float32
+	TextColor       color.RGBA
+	BackgroundColor color.RGBA
+	Padding         math.Vec2f32
+	BorderRadius    float32
+}
+
+// NewTooltip returns a text tooltip shown above its component.
+func NewTooltip(text string) *Tooltip {
+	theme := CurrentTheme()
+	return &Tooltip{
+		Text:            text,
+		Placement:       PlacementTop,
+		Delay:           DefaultTooltipDelay,
+		FontSize:        theme.FontSizeSmall,
+		TextColor:       theme.Text,
+		BackgroundColor: theme.Surface.WithAlpha(0.95),
+		Padding:         math.Vec2f32{X: theme.Space(2), Y: theme.Space(1)},
+		BorderRadius:    theme.RadiusSmall,
+	}
+}
+
+// NewTooltipContent returns a tooltip that shows an arbitrary component.
+func NewTooltipContent(content IComponent) *Tooltip {
+	t := NewTooltip("")
+	t.Content = content
+	return t
+}
+
+// Bubble builds the tooltip's overlay tree.
+func (t *Tooltip) Bubble() *Container {
+	bubble := NewContainer().
+		SetDisplay(DisplayBlock).
+		SetBackgroundColor(t.BackgroundColor).
+		SetPadding(t.Padding).
+		SetBorderRadius(t.BorderRadius)
+	if t.Content != nil {
+		return bubble.AddChild(t.Content)
+	}
+	return bubble.AddChild(NewText(t.Text).SetFontSize(t.FontSize).SetColor(t.TextColor))
+}
+
+func (t *Tooltip) SetPlacement(placement Placement) *Tooltip {
+	t.Placement = placement
+	return t
+}
+
+func (t *Tooltip) SetDelay(seconds float32) *Tooltip {
+	t.Delay = max(0, seconds)
+	return t
+}
+
+func (t *Tooltip) SetFontSize(size float32) *Tooltip {
+	if size > 0 {
+		t.FontSize = size
+	}
+	return t
+}
+
+func (t *Tooltip) SetTextColor(color color.RGBA) *Tooltip {
+	t.TextColor = color
+	return t
+}
+
+func (t *Tooltip) SetBackgroundColor(color color.RGBA) *Tooltip {
+	t.BackgroundColor = color
+	return t
+}
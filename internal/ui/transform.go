@@ -0,0 +1,110 @@
+package ui
+
+import (
+	gomath "math"
+
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Transform
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Transform moves, turns, scales and slants a component where it is drawn,
+// as a CSS transform does, without changing its layout. Transforms compose
+// down the tree: a child is transformed along with its parent.
+type Transform struct {
+	Translate math.Vec2f32
+	// Rotate and the Skew angles are in degrees; positive angles turn
+	// clockwise.
+	Rotate float32
+	Scale  math.Vec2f32
+	Skew   math.Vec2f32
+	// Origin is the point the component turns, scales and slants about, as
+	// a fraction of its size: (0.5, 0.5), the default, is its center.
+	Origin math.Vec2f32
+}
+
+func NewTransform() *Transform {
+	return &Transform{
+		Scale:  math.Vec2f32{X: 1, Y: 1},
+		Origin: math.Vec2f32{X: 0.5, Y: 0.5},
+	}
+}
+
+func (t *Transform) SetTranslate(x, y float32) *Transform {
+	t.Translate = math.Vec2f32{X: x, Y: y}
+	return t
+}
+
+func (t *Transform) SetRotate(degrees float32) *Transform {
+	t.Rotate = degrees
+	return t
+}
+
+func (t *Transform) SetScale(x, y float32) *Transform {
+	t.Scale = math.Vec2f32{X: x, Y: y}
+	return t
+}
+
+func (t *Transform) SetSkew(x, y float32) *Transform {
+	t.Skew = math.Vec2f32{X: x, Y: y}
+	return t
+}
+
+// SetOrigin sets the point transformed about, as fractions of the width and
+// height.
+func (t *Transform) SetOrigin(x, y float32) *Transform {
+	t.Origin = math.Vec2f32{X: x, Y: y}
+	return t
+}
+
+// Matrix returns the transform of a box at pos with the given size, in the
+// same coordinates as pos. The box is scaled, then slanted, then turned,
+// then moved, all about its origin.
+func (t *Transform) Matrix(pos, size math.Vec2f32) math.Mat3 {
+	origin := math.Vec2f32{X: pos.X + t.Origin.X*size.X, Y: pos.Y + t.Origin.Y*size.Y}
+	return math.Translation(origin.X+t.Translate.X, origin.Y+t.Translate.Y).
+		Mul(math.Rotation(radians(t.Rotate))).
+		Mul(math.Skewing(radians(t.Skew.X), radians(t.Skew.Y))).
+		Mul(math.Scaling(t.Scale.X, t.Scale.Y)).
+		Mul(math.Translation(-origin.X, -origin.Y))
+}
+
+func radians(degrees float32) float32 { return degrees * gomath.Pi / 180 }
+
+// Transform returns the transform set on the component, or nil.
+func (c *Component) Transform() *Transform { return c.transform }
+
+func (c *Component) setTransform(transform *Transform) { c.transform = transform }
+
+// AbsoluteTransform returns the transform the component is drawn with: its
+// own composed with those of all its ancestors, taking its laid out box to
+// the screen.
+func (c *Component) AbsoluteTransform() math.Mat3 {
+	m := math.Identity()
+	if c.parent != nil {
+		m = c.parent.AbsoluteTransform()
+	}
+	if c.transform != nil {
+		m = m.Mul(c.transform.Matrix(c.AbsolutePos(), c.size))
+	}
+	return m
+}
+
+// LocalPoint maps a point on screen back to where it falls in the
+// component's laid out box, undoing its transforms, so it can be tested
+// against the box. A component squashed flat by a scale of zero has no
+// point under any other, and the point returned is never inside it.
+func (c *Component) LocalPoint(point math.Vec2f32) math.Vec2f32 {
+	m := c.AbsoluteTransform()
+	if m.IsIdentity() {
+		return point
+	}
+	inv, ok := m.Invert()
+	if !ok {
+		inf := float32(gomath.Inf(1))
+		return math.Vec2f32{X: inf, Y: inf}
+	}
+	return inv.Apply(point)
+}
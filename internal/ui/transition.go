@@ -0,0 +1,309 @@
+package ui
+
+import (
+	gomath "math"
+
+	"github.com/aj-2000/mogi/color"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Easing
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Easing maps the fraction of a transition's time that has passed, from 0 to
+// 1, to the fraction of the way the value has moved.
+type Easing func(t float32) float32
+
+// The easing curves of CSS.
+var (
+	EaseLinear Easing = func(t float32) float32 { return t }
+	Ease              = CubicBezier(0.25, 0.1, 0.25, 1)
+	EaseIn            = CubicBezier(0.42, 0, 1, 1)
+	EaseOut           = CubicBezier(0, 0, 0.58, 1)
+	EaseInOut         = CubicBezier(0.42, 0, 0.58, 1)
+)
+
+// CubicBezier returns the easing curve from (0, 0) to (1, 1) with control
+// points (x1, y1) and (x2, y2), like CSS cubic-bezier(). x1 and x2 are
+// clamped to [0, 1] so the curve is a function of time; y1 and y2 may go
+// outside it to overshoot.
+func CubicBezier(x1, y1, x2, y2 float32) Easing {
+	x1, x2 = max(0, min(x1, 1)), max(0, min(x2, 1))
+	// Polynomial coefficients of each coordinate.
+	cx := 3 * x1
+	bx := 3*(x2-x1) - cx
+	ax := 1 - cx - bx
+	cy := 3 * y1
+	by := 3*(y2-y1) - cy
+	ay := 1 - cy - by
+	sampleX := func(s float32) float32 { return ((ax*s+bx)*s + cx) * s }
+	slopeX := func(s float32) float32 { return (3*ax*s+2*bx)*s + cx }
+
+	return func(t float32) float32 {
+		if t <= 0 || t >= 1 {
+			return max(0, min(t, 1))
+		}
+		// Find the curve parameter at which x is t: Newton's method, with
+		// bisection when the slope is too flat for it.
+		s := t
+		for range 8 {
+			err := sampleX(s) - t
+			if err > -1e-6 && err < 1e-6 {
+				return ((ay*s+by)*s + cy) * s
+			}
+			slope := slopeX(s)
+			if slope > -1e-6 && slope < 1e-6 {
+				break
+			}
+			s -= err / slope
+		}
+		lo, hi := float32(0), float32(1)
+		s = t
+		for range 32 {
+			if x := sampleX(s); x < t {
+				lo = s
+			} else {
+				hi = s
+			}
+			s = (lo + hi) / 2
+		}
+		return ((ay*s+by)*s + cy) * s
+	}
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Transitions
+// ——————————————————————————————————————————————————————————————————————————————
+
+// Property is a component property that a transition can animate.
+type Property int
+
+const (
+	// PropertyOffset is the component's position within its parent, so a
+	// component glides to where layout moves it.
+	PropertyOffset Property = iota
+	PropertySize
+	PropertyBackgroundColor
+	PropertyOpacity
+	PropertyBorderRadius
+	// PropertyValue is a free-standing value animated with Animate.
+	PropertyValue
+)
+
+// Spring moves a value like a mass on a spring towards its target instead
+// of over a fixed time: stiffer springs are faster, and more damped ones
+// overshoot less.
+type Spring struct {
+	Stiffness float32
+	Damping   float32
+}
+
+// DefaultSpring settles quickly with a slight overshoot.
+var DefaultSpring = Spring{Stiffness: 170, Damping: 26}
+
+// Transition animates a property from its value on one frame to a new value
+// set on a later one, as a CSS transition does.
+type Transition struct {
+	Property Property
+	// Duration and Delay are in seconds.
+	Duration float32
+	Delay    float32
+	Easing   Easing
+	// Spring, if set, moves the property on a spring and Duration and Easing
+	// are not used.
+	Spring *Spring
+}
+
+func NewTransition(property Property, duration float32) *Transition {
+	return &Transition{Property: property, Duration: duration, Easing: Ease}
+}
+
+// NewSpringTransition returns a transition that moves property on spring.
+func NewSpringTransition(property Property, spring Spring) *Transition {
+	return &Transition{Property: property, Spring: &spring}
+}
+
+func (t *Transition) SetDuration(duration float32) *Transition {
+	t.Duration = duration
+	return t
+}
+
+func (t *Transition) SetDelay(delay float32) *Transition {
+	t.Delay = delay
+	return t
+}
+
+func (t *Transition) SetEasing(easing Easing) *Transition {
+	t.Easing = easing
+	return t
+}
+
+func (t *Transition) SetSpring(spring Spring) *Transition {
+	t.Spring = &spring
+	return t
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Tweens
+// ——————————————————————————————————————————————————————————————————————————————
+
+// tweenValue holds any animated value: a scalar in the first element, a
+// vector in the first two or a color in all four.
+type tweenValue [4]float32
+
+func colorValue(c color.RGBA) tweenValue { return tweenValue{c.R, c.G, c.B, c.A} }
+
+func (v tweenValue) color() color.RGBA { return color.RGBA{R: v[0], G: v[1], B: v[2], A: v[3]} }
+
+// tweenKey identifies an animated property of a component, by FullID.
+type tweenKey struct {
+	id       string
+	property Property
+}
+
+// tween is a property on its way from one value to another.
+type tween struct {
+	from, to, value, velocity tweenValue
+	elapsed                   float32
+	transition                Transition
+	// seen is the frame the tween was last advanced in; tweens not seen in a
+	// frame belong to components that are gone.
+	seen int
+}
+
+// springStep is the longest time step a spring is advanced by at once, so
+// that stiff springs stay stable at low frame rates.
+const springStep = 1.0 / 240
+
+// step advances the tween by dt seconds.
+func (tw *tween) step(dt float32) {
+	t := tw.transition
+	tw.elapsed += dt
+	if tw.elapsed < t.Delay {
+		return
+	}
+	if t.Spring != nil {
+		// Catch up at most a quarter of a second, after a stall.
+		for left := min(dt, tw.elapsed-t.Delay, 0.25); left > 0; left -= springStep {
+			h := min(left, springStep)
+			for i := range tw.value {
+				accel := -t.Spring.Stiffness*(tw.value[i]-tw.to[i]) - t.Spring.Damping*tw.velocity[i]
+				tw.velocity[i] += accel * h
+				tw.value[i] += tw.velocity[i] * h
+			}
+		}
+		settled := true
+		for i := range tw.value {
+			settled = settled && gomath.Abs(float64(tw.value[i]-tw.to[i])) < 1e-3 && gomath.Abs(float64(tw.velocity[i])) < 1e-2
+		}
+		if settled {
+			tw.value, tw.velocity = tw.to, tweenValue{}
+		}
+		return
+	}
+
+	progress := float32(1)
+	if t.Duration > 0 {
+		progress = min(1, (tw.elapsed-t.Delay)/t.Duration)
+	}
+	eased := progress
+	if t.Easing != nil {
+		eased = t.Easing(progress)
+	}
+	if t.Property == PropertyBackgroundColor {
+		tw.value = colorValue(tw.from.color().Lerp(tw.to.color(), eased))
+		return
+	}
+	for i := range tw.value {
+		tw.value[i] = tw.from[i] + (tw.to[i]-tw.from[i])*eased
+	}
+}
+
+// tween returns the value of the property key this frame, as it moves
+// towards target by transition t. A property seen for the first time starts
+// at its target; one whose target changes sets off from wherever it is.
+func (le *LayoutEngine) tween(key tweenKey, target tweenValue, t *Transition, dt float32) tweenValue {
+	tw, ok := le.tweens[key]
+	if !ok {
+		tw = &tween{from: target, to: target, value: target, seen: le.generation}
+		le.tweens[key] = tw
+	}
+	tw.transition = *t
+	if target != tw.to {
+		tw.from, tw.to, tw.elapsed = tw.value, target, 0
+	}
+	if tw.seen != le.generation {
+		tw.seen = le.generation
+		tw.step(dt)
+	}
+	return tw.value
+}
+
+// Animate returns a free-standing value keyed by id as it moves towards
+// target by transition t, for animating anything that is not a component
+// property. Call it once a frame with the time since the last one.
+func (le *LayoutEngine) Animate(id string, target float32, t *Transition, dt float32) float32 {
+	return le.tween(tweenKey{id, PropertyValue}, tweenValue{target}, t, dt)[0]
+}
+
+// AnimateColor is Animate for colors. Colors are keyed apart from values,
+// so the same id may be used for both.
+func (le *LayoutEngine) AnimateColor(id string, target color.RGBA, t *Transition, dt float32) color.RGBA {
+	t2 := *t
+	t2.Property = PropertyBackgroundColor
+	return le.tween(tweenKey{id, PropertyBackgroundColor}, colorValue(target), &t2, dt).color()
+}
+
+// AdvanceTransitions runs the transitions of every component in the tree by
+// dt seconds, after layout, and replaces each transitioned property with
+// the value it has reached. It may be called for several trees in a frame,
+// such as the main tree and each overlay; a property is advanced once a
+// frame however often it is seen.
+func (le *LayoutEngine) AdvanceTransitions(root IComponent, dt float32) {
+	le.advanceTransitionsRecursive(root, dt)
+}
+
+// endTransitions closes the frame for transitions: animations not seen in
+// it belong to components that are gone and are dropped.
+func (le *LayoutEngine) endTransitions() {
+	for key, tw := range le.tweens {
+		if tw.seen != le.generation {
+			delete(le.tweens, key)
+		}
+	}
+	le.generation++
+}
+
+func (le *LayoutEngine) advanceTransitionsRecursive(comp IComponent, dt float32) {
+	if comp == nil {
+		return
+	}
+	for _, t := range comp.Transitions() {
+		key := tweenKey{comp.FullID(), t.Property}
+		switch t.Property {
+		case PropertyOffset:
+			pos := comp.Pos()
+			v := le.tween(key, tweenValue{pos.X, pos.Y}, t, dt)
+			pos.X, pos.Y = v[0], v[1]
+			comp.setPos(pos)
+		case PropertySize:
+			size := comp.Size()
+			v := le.tween(key, tweenValue{size.X, size.Y}, t, dt)
+			size.X, size.Y = v[0], v[1]
+			comp.setSize(size)
+		case PropertyBackgroundColor:
+			target := comp.BackgroundColor()
+			if b, ok := comp.(*Button); ok {
+				target = b.stateColor()
+			}
+			comp.setBackgroundColor(le.tween(key, colorValue(target), t, dt).color())
+		case PropertyOpacity:
+			comp.setOpacity(le.tween(key, tweenValue{comp.Opacity()}, t, dt)[0])
+		case PropertyBorderRadius:
+			comp.setBorderRadius(le.tween(key, tweenValue{comp.BorderRadius()}, t, dt)[0])
+		}
+	}
+	for _, child := range comp.Children() {
+		le.advanceTransitionsRecursive(child, dt)
+	}
+}
@@ -0,0 +1,45 @@
+package ui
+
+import "testing"
+
+// TestTransitionsInOverlayTrees runs a frame the way the app does: the main
+// tree and an overlay tree are advanced separately and the frame ends once.
+// A hovered button in the overlay fades to its hover color over the fade
+// duration instead of jumping or staying put.
+func TestTransitionsInOverlayTrees(t *testing.T) {
+	le := NewLayoutEngine(monospace)
+	const dt = ButtonFadeDuration / 4
+	frame := func(hovered bool) (main, overlay *Button) {
+		le.BeginLayout()
+		main = NewButton("main")
+		main.IsMouseOver = hovered
+		le.AssignIDsRecursive(NewContainer().AddChild(main))
+		overlay = NewButton("overlay")
+		overlay.IsMouseOver = hovered
+		le.AssignOverlayIDs(NewContainer().AddChild(overlay), "modal#1")
+
+		for _, b := range []*Button{main, overlay} {
+			le.AdvanceTransitions(b, dt)
+		}
+		le.EndLayout()
+		return main, overlay
+	}
+
+	main, overlay := frame(false)
+	start := overlay.Fill()
+	if start != main.Fill() {
+		t.Fatalf("overlay button starts at %v, main at %v", start, main.Fill())
+	}
+	for i := 1; i <= 4; i++ {
+		main, overlay = frame(true)
+		if overlay.Fill() != main.Fill() {
+			t.Fatalf("frame %d: overlay button at %v, main at %v", i, overlay.Fill(), main.Fill())
+		}
+		if i < 4 && (overlay.Fill() == start || overlay.Fill() == overlay.HoverColor) {
+			t.Errorf("frame %d: overlay button at %v, want on its way from %v to %v", i, overlay.Fill(), start, overlay.HoverColor)
+		}
+	}
+	if overlay.Fill() != overlay.HoverColor {
+		t.Errorf("after the fade the overlay button is %v, want %v", overlay.Fill(), overlay.HoverColor)
+	}
+}
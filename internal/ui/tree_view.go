@@ -0,0 +1,595 @@
+package ui
+
+import (
+	"slices"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// TreeView Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// TreeDragThreshold is how far, in pixels, a pressed row must move before
+// it is dragged.
+const TreeDragThreshold float32 = 4
+
+// TreeNode is one node of a TreeView.
+type TreeNode struct {
+	// Key identifies the node across frames; it defaults to the path of
+	// labels from the root.
+	Key      string
+	Label    string
+	Children []*TreeNode
+	// Lazy marks a node whose children are loaded with
+	// TreeView.LoadChildren the first time it is expanded.
+	Lazy bool
+	Data any
+}
+
+func NewTreeNode(label string, children ...*TreeNode) *TreeNode {
+	return &TreeNode{Label: label, Children: children}
+}
+
+// TreeRow is a node shown in a TreeView, in display order.
+type TreeRow struct {
+	Node   *TreeNode
+	Parent *TreeNode // nil for roots
+	Key    string
+	Depth  int
+	Index  int // index of Node among its parent's children
+}
+
+// DropPosition is where a dragged node lands relative to the row under the
+// pointer.
+type DropPosition int
+
+const (
+	DropBefore DropPosition = iota
+	DropInside
+	DropAfter
+)
+
+// treeState is the part of a TreeView kept between frames.
+type treeState struct {
+	expanded map[string]bool
+	selected map[string]bool
+	loaded   map[string][]*TreeNode
+	cursor   string
+	anchor   string
+	hovered  int
+	pressed  int
+	dragging bool
+	pressAt  math.Vec2f32
+}
+
+// TreeView shows a hierarchy of nodes with indentation and expand/collapse
+// toggles. Expansion, selection and lazily loaded children are kept per
+// node key between frames. With MultiSelect, Shift extends the selection
+// and Ctrl toggles nodes in it; with Reorderable, nodes can be dragged and
+// OnMove is asked to move them.
+type TreeView struct {
+	Component
+	Roots []*TreeNode
+	// LoadChildren returns the children of a Lazy node when it is first
+	// expanded. The result is kept, so it is called once per node.
+	LoadChildren  func(node *TreeNode) []*TreeNode
+	MultiSelect   bool
+	Reorderable   bool
+	Indent        float32
+	FontSize      float32
+	TextColor     color.RGBA
+	HoverColor    color.RGBA
+	SelectedColor color.RGBA
+	DropColor     color.RGBA
+	FocusColor    color.RGBA
+	OnSelect      func(self *TreeView, keys []string)
+	OnToggle      func(self *TreeView, node *TreeNode, expanded bool)
+	// OnMove is called when a dragged node is dropped. parent is nil for
+	// the top level and index is the node's place among parent's children
+	// once it has been removed from its old place; see MoveTreeNode.
+	OnMove func(self *TreeView, node, parent *TreeNode, index int)
+	// Cursor is the key of the row moved by the keyboard.
+	Cursor string
+	// HoveredRow and PressedRow index Rows; -1 means none.
+	HoveredRow int
+	PressedRow int
+	IsDragging bool
+	// DropRow and Drop are where a dragged row would land; DropRow is -1
+	// when it cannot be dropped.
+	DropRow    int
+	Drop       DropPosition
+	IsFocused  bool
+	expanded   map[string]bool
+	selected   map[string]bool
+	loaded     map[string][]*TreeNode
+	anchor     string
+	pressAt    math.Vec2f32
+	rows       []TreeRow
+	controlled bool
+}
+
+func NewTreeView(roots ...*TreeNode) *TreeView {
+	theme := CurrentTheme()
+	t := &TreeView{
+		Component:     newComponentBase(TreeViewKind),
+		Roots:         roots,
+		Indent:        16,
+		FontSize:      theme.FontSizeBody,
+		TextColor:     theme.Text,
+		HoverColor:    theme.Tinted(0.08),
+		SelectedColor: theme.Selection(),
+		DropColor:     theme.Primary,
+		FocusColor:    theme.Focus,
+		HoveredRow:    -1,
+		PressedRow:    -1,
+		DropRow:       -1,
+		expanded:      make(map[string]bool),
+		selected:      make(map[string]bool),
+	}
+	t.Component.setDisplay(DisplayBlock)
+	return t
+}
+
+// RowHeight is the height of one row.
+func (t *TreeView) RowHeight() float32 { return t.FontSize + 8 }
+
+// Rows returns the visible nodes in display order. It is filled in by the
+// layout engine.
+func (t *TreeView) Rows() []TreeRow { return t.rows }
+
+// children returns the children of node, loading them if it is lazy.
+func (t *TreeView) children(node *TreeNode, key string) []*TreeNode {
+	if !node.Lazy {
+		return node.Children
+	}
+	if children, ok := t.loaded[key]; ok {
+		return children
+	}
+	if t.LoadChildren == nil {
+		return nil
+	}
+	if t.loaded == nil {
+		t.loaded = make(map[string][]*TreeNode)
+	}
+	children := t.LoadChildren(node)
+	t.loaded[key] = children
+	return children
+}
+
+// HasChildren reports whether row can be expanded. Lazy nodes can until
+// they turn out to have no children.
+func (t *TreeView) HasChildren(row TreeRow) bool {
+	if row.Node.Lazy {
+		children, ok := t.loaded[row.Key]
+		return !ok || len(children) > 0
+	}
+	return len(row.Node.Children) > 0
+}
+
+// IsExpanded reports whether the node with key is expanded.
+func (t *TreeView) IsExpanded(key string) bool { return t.expanded[key] }
+
+// IsSelected reports whether the node with key is selected.
+func (t *TreeView) IsSelected(key string) bool { return t.selected[key] }
+
+// flatten lists the visible rows, loading the children of expanded lazy
+// nodes.
+func (t *TreeView) flatten() {
+	t.rows = t.rows[:0]
+	var walk func(nodes []*TreeNode, parent *TreeNode, parentKey string, depth int)
+	walk = func(nodes []*TreeNode, parent *TreeNode, parentKey string, depth int) {
+		for i, node := range nodes {
+			key := node.Key
+			if key == "" {
+				key = parentKey + "/" + node.Label
+			}
+			t.rows = append(t.rows, TreeRow{Node: node, Parent: parent, Key: key, Depth: depth, Index: i})
+			if t.expanded[key] {
+				walk(t.children(node, key), node, key, depth+1)
+			}
+		}
+	}
+	walk(t.Roots, nil, "", 0)
+}
+
+// RowAt returns the row under point (relative to the content box), or -1.
+func (t *TreeView) RowAt(point math.Vec2f32) int {
+	if point.Y < 0 {
+		return -1
+	}
+	i := int(point.Y / t.RowHeight())
+	if i >= len(t.rows) {
+		return -1
+	}
+	return i
+}
+
+// RowIndex returns the row showing the node with key, or -1.
+func (t *TreeView) RowIndex(key string) int {
+	return slices.IndexFunc(t.rows, func(row TreeRow) bool { return row.Key == key })
+}
+
+// IsOnToggle reports whether x (relative to the content box) is on the
+// expand/collapse toggle of row i.
+func (t *TreeView) IsOnToggle(i int, x float32) bool {
+	if i < 0 || i >= len(t.rows) || !t.HasChildren(t.rows[i]) {
+		return false
+	}
+	start := float32(t.rows[i].Depth) * t.Indent
+	if t.IsRTL() {
+		start = t.contentWidth() - start - t.Indent
+	}
+	return x >= start && x <= start+t.Indent
+}
+
+func (t *TreeView) contentWidth() float32 {
+	return max(0, t.Size().X-2*(t.Padding().X+t.Border().X))
+}
+
+// SetNodeExpanded expands or collapses the node shown in row i and calls
+// OnToggle.
+func (t *TreeView) SetNodeExpanded(i int, expanded bool) {
+	if i < 0 || i >= len(t.rows) || t.expanded[t.rows[i].Key] == expanded {
+		return
+	}
+	if expanded && !t.HasChildren(t.rows[i]) {
+		return
+	}
+	row := t.rows[i]
+	if expanded {
+		t.expanded[row.Key] = true
+	} else {
+		delete(t.expanded, row.Key)
+	}
+	if t.OnToggle != nil {
+		t.OnToggle(t, row.Node, expanded)
+	}
+}
+
+// Toggle expands or collapses the node shown in row i.
+func (t *TreeView) Toggle(i int) {
+	if i >= 0 && i < len(t.rows) {
+		t.SetNodeExpanded(i, !t.expanded[t.rows[i].Key])
+	}
+}
+
+// SelectedKeys returns the keys of the selected nodes that are shown, in
+// display order.
+func (t *TreeView) SelectedKeys() []string {
+	var keys []string
+	for _, row := range t.rows {
+		if t.selected[row.Key] {
+			keys = append(keys, row.Key)
+		}
+	}
+	return keys
+}
+
+// SelectRow handles a click or key press on row i: it moves the cursor
+// there and selects the row alone, or with MultiSelect extends the
+// selection from the anchor (extend) or toggles the row in it (toggle).
+func (t *TreeView) SelectRow(i int, extend, toggle bool) {
+	if i < 0 || i >= len(t.rows) {
+		return
+	}
+	key := t.rows[i].Key
+	t.Cursor = key
+	if !t.MultiSelect {
+		extend, toggle = false, false
+	}
+	anchor := t.RowIndex(t.anchor)
+	switch {
+	case extend && anchor >= 0:
+		clear(t.selected)
+		for j := min(anchor, i); j <= max(anchor, i); j++ {
+			t.selected[t.rows[j].Key] = true
+		}
+	case toggle:
+		if t.selected[key] {
+			delete(t.selected, key)
+		} else {
+			t.selected[key] = true
+		}
+		t.anchor = key
+	default:
+		if len(t.selected) == 1 && t.selected[key] {
+			return
+		}
+		clear(t.selected)
+		t.selected[key] = true
+		t.anchor = key
+	}
+	if t.OnSelect != nil {
+		t.OnSelect(t, t.SelectedKeys())
+	}
+}
+
+// descendants returns how many rows after row i are its descendants.
+func (t *TreeView) descendants(i int) int {
+	n := 0
+	for j := i + 1; j < len(t.rows) && t.rows[j].Depth > t.rows[i].Depth; j++ {
+		n++
+	}
+	return n
+}
+
+// DropAt returns where the row being dragged would land with the pointer
+// at point (relative to the content box): the row under it and whether
+// before, inside or after it. row is -1 where it cannot be dropped.
+func (t *TreeView) DropAt(point math.Vec2f32) (row int, pos DropPosition) {
+	i := t.RowAt(point)
+	if i < 0 || t.PressedRow < 0 {
+		return -1, DropBefore
+	}
+	// A node cannot be dropped onto itself or into its own subtree.
+	if i >= t.PressedRow && i <= t.PressedRow+t.descendants(t.PressedRow) {
+		return -1, DropBefore
+	}
+	within := (point.Y - float32(i)*t.RowHeight()) / t.RowHeight()
+	switch {
+	case within < 0.25:
+		return i, DropBefore
+	case within > 0.75:
+		return i, DropAfter
+	default:
+		return i, DropInside
+	}
+}
+
+// DropDragged moves the dragged row to DropRow by calling OnMove.
+func (t *TreeView) DropDragged() {
+	from, to := t.PressedRow, t.DropRow
+	if from < 0 || to < 0 || from >= len(t.rows) || to >= len(t.rows) || t.OnMove == nil {
+		return
+	}
+	target := t.rows[to]
+	var parent *TreeNode
+	var index int
+	switch {
+	case t.Drop == DropInside:
+		parent = target.Node
+		index = len(t.children(target.Node, target.Key))
+		t.expanded[target.Key] = true
+	case t.Drop == DropAfter && t.expanded[target.Key] && t.HasChildren(target):
+		// After an expanded node means before its first child.
+		parent, index = target.Node, 0
+	case t.Drop == DropAfter:
+		parent, index = target.Parent, target.Index+1
+	default:
+		parent, index = target.Parent, target.Index
+	}
+	dragged := t.rows[from]
+	if parent == dragged.Parent && dragged.Index < index {
+		index--
+	}
+	if parent == dragged.Parent && index == dragged.Index {
+		return
+	}
+	t.OnMove(t, dragged.Node, parent, index)
+}
+
+// MoveTreeNode removes node from the tree under roots and inserts it into
+// parent's children (or roots, if parent is nil) at index. It returns the
+// new roots.
+func MoveTreeNode(roots []*TreeNode, node, parent *TreeNode, index int) []*TreeNode {
+	var remove func(nodes []*TreeNode) ([]*TreeNode, bool)
+	remove = func(nodes []*TreeNode) ([]*TreeNode, bool) {
+		if i := slices.Index(nodes, node); i >= 0 {
+			return slices.Delete(nodes, i, i+1), true
+		}
+		for _, n := range nodes {
+			if children, ok := remove(n.Children); ok {
+				n.Children = children
+				return nodes, true
+			}
+		}
+		return nodes, false
+	}
+	roots, _ = remove(roots)
+	if parent == nil {
+		return slices.Insert(roots, max(0, min(index, len(roots))), node)
+	}
+	parent.Children = slices.Insert(parent.Children, max(0, min(index, len(parent.Children))), node)
+	return roots
+}
+
+func (t *TreeView) saveState() *treeState {
+	return &treeState{
+		expanded: t.expanded,
+		selected: t.selected,
+		loaded:   t.loaded,
+		cursor:   t.Cursor,
+		anchor:   t.anchor,
+		hovered:  t.HoveredRow,
+		pressed:  t.PressedRow,
+		dragging: t.IsDragging,
+		pressAt:  t.pressAt,
+	}
+}
+
+func (t *TreeView) restoreState(st *treeState) {
+	t.expanded = st.expanded
+	t.loaded = st.loaded
+	t.Cursor = st.cursor
+	t.anchor = st.anchor
+	t.HoveredRow = st.hovered
+	t.PressedRow = st.pressed
+	t.IsDragging = st.dragging
+	t.pressAt = st.pressAt
+	if !t.controlled {
+		t.selected = st.selected
+	}
+}
+
+// StartPress records a press on row i at point, which becomes a drag once
+// the pointer moves TreeDragThreshold away.
+func (t *TreeView) StartPress(i int, point math.Vec2f32) {
+	t.PressedRow = i
+	t.pressAt = point
+	t.IsDragging = false
+}
+
+// PressMoved reports whether the pointer has moved far enough from the
+// press to start a drag.
+func (t *TreeView) PressMoved(point math.Vec2f32) bool {
+	dx, dy := point.X-t.pressAt.X, point.Y-t.pressAt.Y
+	return dx*dx+dy*dy >= TreeDragThreshold*TreeDragThreshold
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (t *TreeView) SetID(id string) *TreeView {
+	t.Component.setID(id)
+	return t
+}
+
+func (t *TreeView) SetRoots(roots ...*TreeNode) *TreeView {
+	t.Roots = roots
+	return t
+}
+
+func (t *TreeView) SetLoadChildren(load func(node *TreeNode) []*TreeNode) *TreeView {
+	t.LoadChildren = load
+	return t
+}
+
+// SetExpanded sets the nodes expanded on the first frame; afterwards the
+// user's expansion is kept.
+func (t *TreeView) SetExpanded(keys ...string) *TreeView {
+	for _, key := range keys {
+		t.expanded[key] = true
+	}
+	return t
+}
+
+// SetSelected makes the selection controlled: the given keys win over the
+// selection kept from the previous frame.
+func (t *TreeView) SetSelected(keys ...string) *TreeView {
+	t.selected = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		t.selected[key] = true
+	}
+	t.controlled = true
+	return t
+}
+
+func (t *TreeView) SetMultiSelect(multi bool) *TreeView {
+	t.MultiSelect = multi
+	return t
+}
+
+func (t *TreeView) SetReorderable(reorderable bool) *TreeView {
+	t.Reorderable = reorderable
+	return t
+}
+
+func (t *TreeView) SetIndent(indent float32) *TreeView {
+	t.Indent = max(0, indent)
+	return t
+}
+
+func (t *TreeView) SetFontSize(size float32) *TreeView {
+	if size > 0 {
+		t.FontSize = size
+	}
+	return t
+}
+
+func (t *TreeView) SetTextColor(color color.RGBA) *TreeView {
+	t.TextColor = color
+	return t
+}
+
+func (t *TreeView) SetSelectedColor(color color.RGBA) *TreeView {
+	t.SelectedColor = color
+	return t
+}
+
+func (t *TreeView) SetOnSelect(callback func(self *TreeView, keys []string)) *TreeView {
+	t.OnSelect = callback
+	return t
+}
+
+func (t *TreeView) SetOnToggle(callback func(self *TreeView, node *TreeNode, expanded bool)) *TreeView {
+	t.OnToggle = callback
+	return t
+}
+
+func (t *TreeView) SetOnMove(callback func(self *TreeView, node, parent *TreeNode, index int)) *TreeView {
+	t.OnMove = callback
+	return t
+}
+
+func (t *TreeView) SetBackgroundColor(color color.RGBA) *TreeView {
+	t.Component.setBackgroundColor(color)
+	return t
+}
+
+func (t *TreeView) SetSize(size math.Vec2f32) *TreeView {
+	t.Component.setSize(size)
+	return t
+}
+
+func (t *TreeView) SetWidthPercent(widthPercent float32) *TreeView {
+	t.Component.setWidthPercent(widthPercent)
+	return t
+}
+
+func (t *TreeView) SetDisplay(d Display) *TreeView {
+	t.Component.setDisplay(d)
+	return t
+}
+
+func (t *TreeView) SetPosition(pos Position) *TreeView {
+	t.Component.setPos(pos)
+	return t
+}
+
+func (t *TreeView) SetMargin(margin math.Vec2f32) *TreeView {
+	t.Component.setMargin(margin)
+	return t
+}
+
+func (t *TreeView) SetPadding(padding math.Vec2f32) *TreeView {
+	t.Component.setPadding(padding)
+	return t
+}
+
+func (t *TreeView) SetBorder(border math.Vec2f32) *TreeView {
+	t.Component.setBorder(border)
+	return t
+}
+
+func (t *TreeView) SetBorderColor(color color.RGBA) *TreeView {
+	t.Component.setBorderColor(color)
+	return t
+}
+
+func (t *TreeView) SetBorderRadius(radius float32) *TreeView {
+	t.Component.setBorderRadius(radius)
+	return t
+}
+
+func (t *TreeView) SetZIndex(zIndex int) *TreeView {
+	t.Component.setZIndex(zIndex)
+	return t
+}
+
+func (t *TreeView) SetDirection(direction Direction) *TreeView {
+	t.Component.setDirection(direction)
+	return t
+}
+
+func (t *TreeView) SetTooltip(tooltip *Tooltip) *TreeView {
+	t.Component.setTooltip(tooltip)
+	return t
+}
+
+func (t *TreeView) SetTransform(transform *Transform) *TreeView {
+	t.Component.setTransform(transform)
+	return t
+}
@@ -0,0 +1,653 @@
+package ui
+
+import (
+	"sort"
+
+	"github.com/aj-2000/mogi/color"
+	"github.com/aj-2000/mogi/math"
+)
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Virtual Scrolling
+// ——————————————————————————————————————————————————————————————————————————————
+
+const (
+	// DefaultVirtualItemHeight is used for list items that have not been
+	// measured yet when there is no height estimator.
+	DefaultVirtualItemHeight float32 = 24
+	// DefaultWheelStep is how far one notch of the mouse wheel scrolls.
+	DefaultWheelStep float32 = 40
+	// MinScrollThumb is the shortest a scrollbar thumb gets.
+	MinScrollThumb float32 = 20
+)
+
+// VirtualScroll is the vertical scroll state of a virtualized component.
+// Offset is how far the content is scrolled, in pixels.
+type VirtualScroll struct {
+	Offset          float32
+	WheelStep       float32
+	ScrollbarWidth  float32
+	TrackColor      color.RGBA
+	ThumbColor      color.RGBA
+	IsDraggingThumb bool
+	// ThumbGrab is where the thumb was grabbed, from its top.
+	ThumbGrab float32
+	// viewport and contentHeight come from the last layout.
+	viewport      math.Vec2f32
+	contentHeight float32
+}
+
+func newVirtualScroll() VirtualScroll {
+	theme := CurrentTheme()
+	return VirtualScroll{
+		WheelStep:      DefaultWheelStep,
+		ScrollbarWidth: 8,
+		TrackColor:     theme.Tinted(0.08),
+		ThumbColor:     theme.Tinted(0.35),
+	}
+}
+
+// ScrollState returns the scroll state, for code that handles both lists and
+// grids.
+func (s *VirtualScroll) ScrollState() *VirtualScroll { return s }
+
+// Viewport is the size of the visible area, inside padding and border.
+func (s *VirtualScroll) Viewport() math.Vec2f32 { return s.viewport }
+
+// ContentHeight is the height of all items together.
+func (s *VirtualScroll) ContentHeight() float32 { return s.contentHeight }
+
+// MaxOffset is the largest offset that still fills the viewport.
+func (s *VirtualScroll) MaxOffset() float32 { return max(0, s.contentHeight-s.viewport.Y) }
+
+func (s *VirtualScroll) clampOffset() {
+	s.Offset = max(0, min(s.Offset, s.MaxOffset()))
+}
+
+// Thumb returns the top and length of the scrollbar thumb, within the
+// viewport. ok is false when the content fits and there is no scrollbar.
+func (s *VirtualScroll) Thumb() (y, length float32, ok bool) {
+	if s.viewport.Y <= 0 || s.contentHeight <= s.viewport.Y {
+		return 0, 0, false
+	}
+	length = min(s.viewport.Y, max(MinScrollThumb, s.viewport.Y*s.viewport.Y/s.contentHeight))
+	return (s.viewport.Y - length) * s.Offset / s.MaxOffset(), length, true
+}
+
+// OffsetForThumb returns the offset that puts the top of the thumb at y.
+func (s *VirtualScroll) OffsetForThumb(y float32) float32 {
+	_, length, ok := s.Thumb()
+	if !ok || s.viewport.Y <= length {
+		return 0
+	}
+	return max(0, min(s.MaxOffset(), y/(s.viewport.Y-length)*s.MaxOffset()))
+}
+
+// scrollbarX returns the x of the scrollbar within the viewport: the end
+// edge, which is the left one in RTL.
+func (s *VirtualScroll) scrollbarX(rtl bool) float32 {
+	if rtl {
+		return 0
+	}
+	return s.viewport.X - s.ScrollbarWidth
+}
+
+// virtualState is the part of a virtualized component kept between frames.
+type virtualState struct {
+	scroll      VirtualScroll
+	heights     map[int]float32
+	offsets     []float32
+	anchor      int
+	anchorDelta float32
+}
+
+// virtualizer is a component that builds only the children in its viewport.
+type virtualizer interface {
+	IComponent
+	restoreVirtual(st *virtualState)
+	saveVirtual() *virtualState
+	// mountRange is the range of items to build, from the scroll offset
+	// and the viewport of the previous frame.
+	mountRange() (first, last int)
+	buildItem(i int) IComponent
+	mount(first int, items []IComponent)
+}
+
+func mountChildren(c *Component, parent IComponent, items []IComponent) {
+	c.children = items
+	for _, item := range items {
+		item.SetParent(parent)
+	}
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// VirtualList Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// VirtualList shows Count items in a scrolling column, building and laying
+// out only those in view. Items are built by Build; EstimateHeight guesses
+// the height of items that have not been shown yet, and each item's height
+// is measured once it is laid out. The item at the top of the view stays in
+// place when the heights of items above it turn out different from the
+// estimate.
+//
+// The visible range is chosen from the previous frame's viewport, so a list
+// without a fixed height is empty on its first frame.
+type VirtualList struct {
+	Component
+	VirtualScroll
+	Count          int
+	EstimateHeight func(i int) float32
+	Build          func(i int) IComponent
+	// Overscan is how many items beyond each edge of the view are built.
+	Overscan    int
+	heights     map[int]float32
+	offsets     []float32 // offsets[i] is the top of item i; offsets[Count] the end
+	first       int
+	anchor      int
+	anchorDelta float32
+	// theme is the theme in use when the list was created, which items
+	// are built with.
+	theme Theme
+}
+
+func NewVirtualList(count int, estimateHeight func(i int) float32, build func(i int) IComponent) *VirtualList {
+	v := &VirtualList{
+		Component:      newComponentBase(VirtualListKind),
+		VirtualScroll:  newVirtualScroll(),
+		Count:          count,
+		EstimateHeight: estimateHeight,
+		Build:          build,
+		Overscan:       2,
+		theme:          CurrentTheme(),
+	}
+	v.Component.setDisplay(DisplayBlock)
+	return v
+}
+
+// ItemHeight returns the measured height of item i, or its estimate.
+func (v *VirtualList) ItemHeight(i int) float32 {
+	if h, ok := v.heights[i]; ok {
+		return h
+	}
+	if v.EstimateHeight != nil {
+		if h := v.EstimateHeight(i); h > 0 {
+			return h
+		}
+	}
+	return DefaultVirtualItemHeight
+}
+
+func (v *VirtualList) ensureOffsets() {
+	if len(v.offsets) == v.Count+1 {
+		return
+	}
+	gap := v.Gap().Y
+	v.offsets = make([]float32, v.Count+1)
+	for i := 0; i < v.Count; i++ {
+		v.offsets[i+1] = v.offsets[i] + v.ItemHeight(i) + gap
+	}
+	v.contentHeight = 0
+	if v.Count > 0 {
+		v.contentHeight = v.offsets[v.Count] - gap
+	}
+}
+
+// ItemOffset returns the top of item i within the content.
+func (v *VirtualList) ItemOffset(i int) float32 {
+	v.ensureOffsets()
+	return v.offsets[max(0, min(i, v.Count))]
+}
+
+// IndexAt returns the item at y within the content.
+func (v *VirtualList) IndexAt(y float32) int {
+	v.ensureOffsets()
+	i := sort.Search(v.Count, func(i int) bool { return v.offsets[i+1] > y })
+	return min(i, max(0, v.Count-1))
+}
+
+// ScrollTo scrolls so that offset is at the top of the view.
+func (v *VirtualList) ScrollTo(offset float32) {
+	v.ensureOffsets()
+	v.Offset = offset
+	v.clampOffset()
+	v.anchor = v.IndexAt(v.Offset)
+	v.anchorDelta = v.Offset - v.ItemOffset(v.anchor)
+}
+
+// ScrollToIndex scrolls so that item i is at the top of the view.
+func (v *VirtualList) ScrollToIndex(i int) {
+	v.ScrollTo(v.ItemOffset(i))
+}
+
+// measure records the laid-out height of item i and reports whether it
+// differs from what was assumed.
+func (v *VirtualList) measure(i int, height float32) bool {
+	if h, ok := v.heights[i]; ok && h == height {
+		return false
+	}
+	if v.heights == nil {
+		v.heights = make(map[int]float32)
+	}
+	changed := v.ItemHeight(i) != height
+	v.heights[i] = height
+	if changed {
+		v.offsets = nil
+	}
+	return changed
+}
+
+// keepAnchor restores the offset from the item at the top of the view after
+// item heights changed.
+func (v *VirtualList) keepAnchor() {
+	v.ensureOffsets()
+	v.Offset = v.ItemOffset(v.anchor) + v.anchorDelta
+	v.clampOffset()
+}
+
+// ScrollbarX returns the x of the scrollbar within the viewport.
+func (v *VirtualList) ScrollbarX() float32 { return v.scrollbarX(v.IsRTL()) }
+
+// First returns the index of the first mounted item; Children()[k] is item
+// First()+k.
+func (v *VirtualList) First() int { return v.first }
+
+func (v *VirtualList) mountRange() (int, int) {
+	if v.Count == 0 || v.viewport.Y <= 0 {
+		return 0, 0
+	}
+	first := max(0, v.IndexAt(v.Offset)-v.Overscan)
+	last := min(v.Count, v.IndexAt(v.Offset+v.viewport.Y)+1+v.Overscan)
+	return first, last
+}
+
+func (v *VirtualList) buildItem(i int) IComponent {
+	defer UseTheme(UseTheme(v.theme))
+	if v.Build != nil {
+		if item := v.Build(i); item != nil {
+			return item
+		}
+	}
+	return NewContainer()
+}
+
+func (v *VirtualList) mount(first int, items []IComponent) {
+	v.first = first
+	mountChildren(&v.Component, v, items)
+}
+
+func (v *VirtualList) saveVirtual() *virtualState {
+	return &virtualState{
+		scroll:      v.VirtualScroll,
+		heights:     v.heights,
+		offsets:     v.offsets,
+		anchor:      v.anchor,
+		anchorDelta: v.anchorDelta,
+	}
+}
+
+func (v *VirtualList) restoreVirtual(st *virtualState) {
+	v.restoreScroll(st.scroll)
+	v.heights = st.heights
+	v.offsets = st.offsets
+	v.anchor = st.anchor
+	v.anchorDelta = st.anchorDelta
+	v.ensureOffsets()
+	// Items may have been added or removed since the last frame.
+	v.anchor = min(v.anchor, max(0, v.Count-1))
+}
+
+// restoreScroll keeps the builder's appearance settings and takes the
+// scroll position and layout results from the previous frame.
+func (s *VirtualScroll) restoreScroll(prev VirtualScroll) {
+	s.Offset = prev.Offset
+	s.IsDraggingThumb = prev.IsDraggingThumb
+	s.ThumbGrab = prev.ThumbGrab
+	s.viewport = prev.viewport
+	s.contentHeight = prev.contentHeight
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// VirtualGrid Component
+// ——————————————————————————————————————————————————————————————————————————————
+
+// VirtualGrid shows Count cells of the same size in rows, building and
+// laying out only the rows in view. With Columns 0 it fits as many columns
+// as the viewport allows.
+type VirtualGrid struct {
+	Component
+	VirtualScroll
+	Count    int
+	CellSize math.Vec2f32
+	Columns  int
+	Build    func(i int) IComponent
+	// Overscan is how many rows beyond each edge of the view are built.
+	Overscan int
+	first    int
+	// theme is the theme in use when the grid was created, which cells are
+	// built with.
+	theme Theme
+}
+
+func NewVirtualGrid(count int, cellSize math.Vec2f32, build func(i int) IComponent) *VirtualGrid {
+	g := &VirtualGrid{
+		Component:     newComponentBase(VirtualGridKind),
+		VirtualScroll: newVirtualScroll(),
+		Count:         count,
+		CellSize:      cellSize,
+		Build:         build,
+		Overscan:      1,
+		theme:         CurrentTheme(),
+	}
+	g.Component.setDisplay(DisplayBlock)
+	return g
+}
+
+// ColumnCount returns the number of columns in the current viewport.
+func (g *VirtualGrid) ColumnCount() int {
+	if g.Columns > 0 {
+		return g.Columns
+	}
+	width := g.viewport.X - g.ScrollbarWidth + g.Gap().X
+	return max(1, int(width/(g.CellSize.X+g.Gap().X)))
+}
+
+// RowHeight is the distance between the tops of two rows.
+func (g *VirtualGrid) RowHeight() float32 { return g.CellSize.Y + g.Gap().Y }
+
+// CellOffset returns the top-left of cell i within the content, before
+// scrolling.
+func (g *VirtualGrid) CellOffset(i int) math.Vec2f32 {
+	columns := g.ColumnCount()
+	return math.Vec2f32{
+		X: float32(i%columns) * (g.CellSize.X + g.Gap().X),
+		Y: float32(i/columns) * g.RowHeight(),
+	}
+}
+
+func (g *VirtualGrid) updateContentHeight() {
+	columns := g.ColumnCount()
+	rows := (g.Count + columns - 1) / columns
+	g.contentHeight = max(0, float32(rows)*g.RowHeight()-g.Gap().Y)
+}
+
+// ScrollTo scrolls so that offset is at the top of the view.
+func (g *VirtualGrid) ScrollTo(offset float32) {
+	g.Offset = offset
+	g.clampOffset()
+}
+
+// ScrollToIndex scrolls so that the row of cell i is at the top of the view.
+func (g *VirtualGrid) ScrollToIndex(i int) {
+	g.ScrollTo(g.CellOffset(i).Y)
+}
+
+// ScrollbarX returns the x of the scrollbar within the viewport.
+func (g *VirtualGrid) ScrollbarX() float32 { return g.scrollbarX(g.IsRTL()) }
+
+// First returns the index of the first mounted cell; Children()[k] is cell
+// First()+k.
+func (g *VirtualGrid) First() int { return g.first }
+
+func (g *VirtualGrid) mountRange() (int, int) {
+	if g.Count == 0 || g.viewport.Y <= 0 || g.RowHeight() <= 0 {
+		return 0, 0
+	}
+	columns := g.ColumnCount()
+	firstRow := max(0, int(g.Offset/g.RowHeight())-g.Overscan)
+	lastRow := int((g.Offset+g.viewport.Y)/g.RowHeight()) + 1 + g.Overscan
+	return min(g.Count, firstRow*columns), min(g.Count, lastRow*columns)
+}
+
+func (g *VirtualGrid) buildItem(i int) IComponent {
+	defer UseTheme(UseTheme(g.theme))
+	cell := NewContainer().SetSize(g.CellSize)
+	if g.Build != nil {
+		if content := g.Build(i); content != nil {
+			cell.AddChild(content)
+		}
+	}
+	return cell
+}
+
+func (g *VirtualGrid) mount(first int, items []IComponent) {
+	g.first = first
+	mountChildren(&g.Component, g, items)
+}
+
+func (g *VirtualGrid) saveVirtual() *virtualState {
+	return &virtualState{scroll: g.VirtualScroll}
+}
+
+func (g *VirtualGrid) restoreVirtual(st *virtualState) {
+	g.restoreScroll(st.scroll)
+}
+
+// ——————————————————————————————————————————————————————————————————————————————
+// Fluent Setters
+// ——————————————————————————————————————————————————————————————————————————————
+
+func (v *VirtualList) SetID(id string) *VirtualList {
+	v.Component.setID(id)
+	return v
+}
+
+func (v *VirtualList) SetOverscan(items int) *VirtualList {
+	v.Overscan = max(0, items)
+	return v
+}
+
+func (v *VirtualList) SetWheelStep(step float32) *VirtualList {
+	v.WheelStep = step
+	return v
+}
+
+func (v *VirtualList) SetScrollbarWidth(width float32) *VirtualList {
+	v.ScrollbarWidth = max(0, width)
+	return v
+}
+
+func (v *VirtualList) SetThumbColor(color color.RGBA) *VirtualList {
+	v.ThumbColor = color
+	return v
+}
+
+func (v *VirtualList) SetTrackColor(color color.RGBA) *VirtualList {
+	v.TrackColor = color
+	return v
+}
+
+func (v *VirtualList) SetGap(gap float32) *VirtualList {
+	v.Component.setGap(math.Vec2f32{Y: gap})
+	return v
+}
+
+func (v *VirtualList) SetBackgroundColor(color color.RGBA) *VirtualList {
+	v.Component.setBackgroundColor(color)
+	return v
+}
+
+func (v *VirtualList) SetSize(size math.Vec2f32) *VirtualList {
+	v.Component.setSize(size)
+	return v
+}
+
+func (v *VirtualList) SetWidthPercent(widthPercent float32) *VirtualList {
+	v.Component.setWidthPercent(widthPercent)
+	return v
+}
+
+func (v *VirtualList) SetHeightPercent(heightPercent float32) *VirtualList {
+	v.Component.setHeightPercent(heightPercent)
+	return v
+}
+
+func (v *VirtualList) SetDisplay(d Display) *VirtualList {
+	v.Component.setDisplay(d)
+	return v
+}
+
+func (v *VirtualList) SetPosition(pos Position) *VirtualList {
+	v.Component.setPos(pos)
+	return v
+}
+
+func (v *VirtualList) SetMargin(margin math.Vec2f32) *VirtualList {
+	v.Component.setMargin(margin)
+	return v
+}
+
+func (v *VirtualList) SetPadding(padding math.Vec2f32) *VirtualList {
+	v.Component.setPadding(padding)
+	return v
+}
+
+func (v *VirtualList) SetBorder(border math.Vec2f32) *VirtualList {
+	v.Component.setBorder(border)
+	return v
+}
+
+func (v *VirtualList) SetBorderColor(color color.RGBA) *VirtualList {
+	v.Component.setBorderColor(color)
+	return v
+}
+
+func (v *VirtualList) SetBorderRadius(radius float32) *VirtualList {
+	v.Component.setBorderRadius(radius)
+	return v
+}
+
+func (v *VirtualList) SetZIndex(zIndex int) *VirtualList {
+	v.Component.setZIndex(zIndex)
+	return v
+}
+
+func (v *VirtualList) SetDirection(direction Direction) *VirtualList {
+	v.Component.setDirection(direction)
+	return v
+}
+
+func (v *VirtualList) SetTooltip(tooltip *Tooltip) *VirtualList {
+	v.Component.setTooltip(tooltip)
+	return v
+}
+
+func (v *VirtualList) SetTransform(transform *Transform) *VirtualList {
+	v.Component.setTransform(transform)
+	return v
+}
+
+func (g *VirtualGrid) SetID(id string) *VirtualGrid {
+	g.Component.setID(id)
+	return g
+}
+
+func (g *VirtualGrid) SetColumns(columns int) *VirtualGrid {
+	g.Columns = max(0, columns)
+	return g
+}
+
+func (g *VirtualGrid) SetOverscan(rows int) *VirtualGrid {
+	g.Overscan = max(0, rows)
+	return g
+}
+
+func (g *VirtualGrid) SetWheelStep(step float32) *VirtualGrid {
+	g.WheelStep = step
+	return g
+}
+
+func (g *VirtualGrid) SetScrollbarWidth(width float32) *VirtualGrid {
+	g.ScrollbarWidth = max(0, width)
+	return g
+}
+
+func (g *VirtualGrid) SetThumbColor(color color.RGBA) *VirtualGrid {
+	g.ThumbColor = color
+	return g
+}
+
+func (g *VirtualGrid) SetTrackColor(color color.RGBA) *VirtualGrid {
+	g.TrackColor = color
+	return g
+}
+
+func (g *VirtualGrid) SetGap(gap math.Vec2f32) *VirtualGrid {
+	g.Component.setGap(gap)
+	return g
+}
+
+func (g *VirtualGrid) SetBackgroundColor(color color.RGBA) *VirtualGrid {
+	g.Component.setBackgroundColor(color)
+	return g
+}
+
+func (g *VirtualGrid) SetSize(size math.Vec2f32) *VirtualGrid {
+	g.Component.setSize(size)
+	return g
+}
+
+func (g *VirtualGrid) SetWidthPercent(widthPercent float32) *VirtualGrid {
+	g.Component.setWidthPercent(widthPercent)
+	return g
+}
+
+func (g *VirtualGrid) SetHeightPercent(heightPercent float32) *VirtualGrid {
+	g.Component.setHeightPercent(heightPercent)
+	return g
+}
+
+func (g *VirtualGrid) SetDisplay(d Display) *VirtualGrid {
+	g.Component.setDisplay(d)
+	return g
+}
+
+func (g *VirtualGrid) SetPosition(pos Position) *VirtualGrid {
+	g.Component.setPos(pos)
+	return g
+}
+
+func (g *VirtualGrid) SetMargin(margin math.Vec2f32) *VirtualGrid {
+	g.Component.setMargin(margin)
+	return g
+}
+
+func (g *VirtualGrid) SetPadding(padding math.Vec2f32) *VirtualGrid {
+	g.Component.setPadding(padding)
+	return g
+}
+
+func (g *VirtualGrid) SetBorder(border math.Vec2f32) *VirtualGrid {
+	g.Component.setBorder(border)
+	return g
+}
+
+func (g *VirtualGrid) SetBorderColor(color color.RGBA) *VirtualGrid {
+	g.Component.setBorderColor(color)
+	return g
+}
+
+func (g *VirtualGrid) SetBorderRadius(radius float32) *VirtualGrid {
+	g.Component.setBorderRadius(radius)
+	return g
+}
+
+func (g *VirtualGrid) SetZIndex(zIndex int) *VirtualGrid {
+	g.Component.setZIndex(zIndex)
+	return g
+}
+
+func (g *VirtualGrid) SetDirection(direction Direction) *VirtualGrid {
+	g.Component.setDirection(direction)
+	return g
+}
+
+func (g *VirtualGrid) SetTooltip(tooltip *Tooltip) *VirtualGrid {
+	g.Component.setTooltip(tooltip)
+	return g
+}
+
+func (g *VirtualGrid) SetTransform(transform *Transform) *VirtualGrid {
+	g.Component.setTransform(transform)
+	return g
+}
@@ -0,0 +1,113 @@
+// math/matrix.go
+package math
+
+import "math"
+
+//
+// ——————————————————————————————————————————————————————————————————————————————
+// 2D affine transforms
+// ——————————————————————————————————————————————————————————————————————————————
+//
+
+// Mat3 is a 3x3 matrix in row-major order, m[row][col], that transforms
+// points in 2D as column vectors (x, y, 1). Affine transforms keep the last
+// row at (0, 0, 1); the translation is in the last column.
+type Mat3 [3][3]float32
+
+func Identity() Mat3 {
+	return Mat3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+}
+
+func Translation(x, y float32) Mat3 {
+	return Mat3{{1, 0, x}, {0, 1, y}, {0, 0, 1}}
+}
+
+// Rotation turns by angle radians, clockwise on screen, where y points down.
+func Rotation(angle float32) Mat3 {
+	sin, cos := math.Sincos(float64(angle))
+	s, c := float32(sin), float32(cos)
+	return Mat3{{c, -s, 0}, {s, c, 0}, {0, 0, 1}}
+}
+
+func Scaling(x, y float32) Mat3 {
+	return Mat3{{x, 0, 0}, {0, y, 0}, {0, 0, 1}}
+}
+
+// Skewing slants the x axis by angleY and the y axis by angleX, in radians,
+// like CSS skew(angleX, angleY).
+func Skewing(angleX, angleY float32) Mat3 {
+	return Mat3{
+		{1, float32(math.Tan(float64(angleX))), 0},
+		{float32(math.Tan(float64(angleY))), 1, 0},
+		{0, 0, 1},
+	}
+}
+
+// Mul returns m·n: the transform that applies n first and then m.
+func (m Mat3) Mul(n Mat3) Mat3 {
+	var r Mat3
+	for i := range 3 {
+		for j := range 3 {
+			r[i][j] = m[i][0]*n[0][j] + m[i][1]*n[1][j] + m[i][2]*n[2][j]
+		}
+	}
+	return r
+}
+
+// Determinant is the factor by which m scales areas; it is negative when m
+// mirrors.
+func (m Mat3) Determinant() float32 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+// Invert returns the transform that undoes m, or false when m flattens the
+// plane onto a line or point and cannot be undone.
+func (m Mat3) Invert() (Mat3, bool) {
+	det := m.Determinant()
+	if det == 0 || math.IsNaN(float64(det)) {
+		return Mat3{}, false
+	}
+	inv := 1 / det
+	return Mat3{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) * inv,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) * inv,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) * inv,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) * inv,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) * inv,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) * inv,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) * inv,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) * inv,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) * inv,
+		},
+	}, true
+}
+
+// Apply transforms the point p.
+func (m Mat3) Apply(p Vec2f32) Vec2f32 {
+	x := m[0][0]*p.X + m[0][1]*p.Y + m[0][2]
+	y := m[1][0]*p.X + m[1][1]*p.Y + m[1][2]
+	if w := m[2][0]*p.X + m[2][1]*p.Y + m[2][2]; w != 1 && w != 0 {
+		x, y = x/w, y/w
+	}
+	return Vec2f32{x, y}
+}
+
+func (m Mat3) IsIdentity() bool { return m == Identity() }
+
+// ApplyRect returns the smallest upright rect around r transformed by m,
+// which is r itself moved and scaled unless m turns or slants it.
+func (m Mat3) ApplyRect(r Rect) Rect {
+	hi := r.Max()
+	a := m.Apply(r.Pos)
+	b := m.Apply(Vec2f32{hi.X, r.Pos.Y})
+	c := m.Apply(Vec2f32{r.Pos.X, hi.Y})
+	d := m.Apply(hi)
+	return RectFromCorners(a.Min(b).Min(c).Min(d), a.Max(b).Max(c).Max(d))
+}
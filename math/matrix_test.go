@@ -0,0 +1,137 @@
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+const epsilon = 1e-4
+
+func near(a, b float32) bool { return math.Abs(float64(a-b)) <= epsilon }
+
+func nearVec(a, b Vec2f32) bool { return near(a.X, b.X) && near(a.Y, b.Y) }
+
+func nearMat(a, b Mat3) bool {
+	for i := range 3 {
+		for j := range 3 {
+			if !near(a[i][j], b[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestMat3Mul(t *testing.T) {
+	tests := []struct {
+		name string
+		m, n Mat3
+		want Mat3
+	}{
+		{"identity", Translation(3, 4), Identity(), Translation(3, 4)},
+		{"translations add", Translation(1, 2), Translation(3, 4), Translation(4, 6)},
+		{"scalings multiply", Scaling(2, 3), Scaling(4, 5), Scaling(8, 15)},
+		{"scale after translate", Scaling(2, 2), Translation(1, 1), Mat3{{2, 0, 2}, {0, 2, 2}, {0, 0, 1}}},
+		{"translate after scale", Translation(1, 1), Scaling(2, 2), Mat3{{2, 0, 1}, {0, 2, 1}, {0, 0, 1}}},
+		{"quarter turns", Rotation(math.Pi / 2), Rotation(math.Pi / 2), Rotation(math.Pi)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Mul(tt.n); !nearMat(got, tt.want) {
+				t.Errorf("%v.Mul(%v) = %v, want %v", tt.m, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMat3Invert(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Mat3
+		ok   bool
+	}{
+		{"identity", Identity(), true},
+		{"translation", Translation(5, -7), true},
+		{"scaling", Scaling(2, 0.5), true},
+		{"mirror", Scaling(-1, 1), true},
+		{"rotation", Rotation(0.3), true},
+		{"skew", Skewing(0.2, 0.4), true},
+		{"combined", Translation(10, 20).Mul(Rotation(1)).Mul(Scaling(3, 4)), true},
+		{"zero scale", Scaling(0, 1), false},
+		{"onto a line", Mat3{{1, 2, 0}, {2, 4, 0}, {0, 0, 1}}, false},
+		{"zero", Mat3{}, false},
+		{"nan", Scaling(float32(math.NaN()), 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv, ok := tt.m.Invert()
+			if ok != tt.ok {
+				t.Fatalf("%v.Invert() ok = %v, want %v", tt.m, ok, tt.ok)
+			}
+			if !ok {
+				if inv != (Mat3{}) {
+					t.Errorf("%v.Invert() = %v, want the zero matrix", tt.m, inv)
+				}
+				return
+			}
+			if got := tt.m.Mul(inv); !nearMat(got, Identity()) {
+				t.Errorf("m·m⁻¹ = %v, want the identity", got)
+			}
+			if got := inv.Mul(tt.m); !nearMat(got, Identity()) {
+				t.Errorf("m⁻¹·m = %v, want the identity", got)
+			}
+		})
+	}
+}
+
+func TestMat3Apply(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Mat3
+		p    Vec2f32
+		want Vec2f32
+	}{
+		{"identity", Identity(), Vec2f32{3, 4}, Vec2f32{3, 4}},
+		{"translation", Translation(10, -5), Vec2f32{3, 4}, Vec2f32{13, -1}},
+		{"scaling", Scaling(2, 3), Vec2f32{3, 4}, Vec2f32{6, 12}},
+		{"rotation is clockwise on screen", Rotation(math.Pi / 2), Vec2f32{1, 0}, Vec2f32{0, 1}},
+		{"skew", Skewing(math.Pi/4, 0), Vec2f32{0, 2}, Vec2f32{2, 2}},
+		{"translate then scale", Scaling(2, 2).Mul(Translation(1, 1)), Vec2f32{1, 1}, Vec2f32{4, 4}},
+		{"projective", Mat3{{1, 0, 0}, {0, 1, 0}, {0, 0, 2}}, Vec2f32{4, 6}, Vec2f32{2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Apply(tt.p); !nearVec(got, tt.want) {
+				t.Errorf("%v.Apply(%v) = %v, want %v", tt.m, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMat3ApplyRect(t *testing.T) {
+	r := NewRect(0, 0, 10, 20)
+	tests := []struct {
+		name string
+		m    Mat3
+		r    Rect
+		want Rect
+	}{
+		{"identity", Identity(), r, r},
+		{"translation", Translation(5, 5), r, NewRect(5, 5, 10, 20)},
+		{"scaling", Scaling(2, 0.5), r, NewRect(0, 0, 20, 10)},
+		{"mirror", Scaling(-1, 1), r, NewRect(-10, 0, 10, 20)},
+		{"quarter turn", Rotation(math.Pi / 2), r, NewRect(-20, 0, 20, 10)},
+		{"eighth turn", Rotation(math.Pi / 4), NewRect(0, 0, 10, 10), NewRect(-7.0711, 0, 14.1421, 14.1421)},
+		{"skew", Skewing(math.Pi/4, 0), r, NewRect(0, 0, 30, 20)},
+		{"empty", Translation(1, 1), NewRect(0, 0, 0, 0), NewRect(1, 1, 0, 0)},
+		{"flattened", Scaling(0, 1), r, NewRect(0, 0, 0, 20)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.m.ApplyRect(tt.r)
+			if !nearVec(got.Pos, tt.want.Pos) || !nearVec(got.Size, tt.want.Size) {
+				t.Errorf("%v.ApplyRect(%v) = %v, want %v", tt.m, tt.r, got, tt.want)
+			}
+		})
+	}
+}
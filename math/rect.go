@@ -0,0 +1,107 @@
+// math/rect.go
+package math
+
+//
+// ——————————————————————————————————————————————————————————————————————————————
+// rectangles
+// ——————————————————————————————————————————————————————————————————————————————
+//
+
+// Rect is an upright rectangle with its top-left corner at Pos. Rects with
+// no width or height, or a negative one, are empty; the operations below
+// that work out a new size never return a negative one.
+type Rect struct{ Pos, Size Vec2f32 }
+
+func NewRect(x, y, width, height float32) Rect {
+	return Rect{Vec2f32{x, y}, Vec2f32{width, height}}
+}
+
+// RectFromCorners returns the rect spanning two opposite corners, in either
+// order.
+func RectFromCorners(a, b Vec2f32) Rect {
+	lo, hi := a.Min(b), a.Max(b)
+	return Rect{lo, hi.Minus(lo)}
+}
+
+// Min returns the top-left corner.
+func (r Rect) Min() Vec2f32 { return r.Pos }
+
+// Max returns the bottom-right corner.
+func (r Rect) Max() Vec2f32 { return r.Pos.Plus(r.Size) }
+
+func (r Rect) Center() Vec2f32 { return r.Pos.Plus(r.Size.Times(0.5)) }
+
+func (r Rect) IsEmpty() bool { return r.Size.X <= 0 || r.Size.Y <= 0 }
+
+// Contains reports whether p is inside r or on its edge.
+func (r Rect) Contains(p Vec2f32) bool {
+	hi := r.Max()
+	return p.X >= r.Pos.X && p.X <= hi.X && p.Y >= r.Pos.Y && p.Y <= hi.Y
+}
+
+// ContainsRect reports whether all of o is inside r.
+func (r Rect) ContainsRect(o Rect) bool {
+	return r.Contains(o.Pos) && r.Contains(o.Max())
+}
+
+// Intersect returns the part of r that is also in o, which is empty when
+// they do not overlap.
+func (r Rect) Intersect(o Rect) Rect {
+	lo, hi := r.Pos.Max(o.Pos), r.Max().Min(o.Max())
+	return Rect{lo, Vec2f32{max(0, hi.X-lo.X), max(0, hi.Y-lo.Y)}}
+}
+
+// Union returns the smallest rect around both r and o. An empty rect adds
+// nothing to the other.
+func (r Rect) Union(o Rect) Rect {
+	if r.IsEmpty() {
+		return o
+	}
+	if o.IsEmpty() {
+		return r
+	}
+	return RectFromCorners(r.Pos.Min(o.Pos), r.Max().Max(o.Max()))
+}
+
+// Inset moves each edge inwards by its own amount, or outwards for a
+// negative one. Edges that would cross meet in the middle.
+func (r Rect) Inset(left, top, right, bottom float32) Rect {
+	width, height := r.Size.X-left-right, r.Size.Y-top-bottom
+	x, y := r.Pos.X+left, r.Pos.Y+top
+	if width < 0 {
+		if left+right != 0 {
+			x += width * left / (left + right)
+		}
+		width = 0
+	}
+	if height < 0 {
+		if top+bottom != 0 {
+			y += height * top / (top + bottom)
+		}
+		height = 0
+	}
+	return Rect{Vec2f32{x, y}, Vec2f32{width, height}}
+}
+
+// Translate returns r moved by offset.
+func (r Rect) Translate(offset Vec2f32) Rect {
+	return Rect{r.Pos.Plus(offset), r.Size}
+}
+
+// SplitX cuts r at x, measured from its left edge and kept inside it, into
+// the parts to the left and right.
+func (r Rect) SplitX(x float32) (left, right Rect) {
+	width := max(0, r.Size.X)
+	x = max(0, min(x, width))
+	return Rect{r.Pos, Vec2f32{x, r.Size.Y}},
+		Rect{Vec2f32{r.Pos.X + x, r.Pos.Y}, Vec2f32{width - x, r.Size.Y}}
+}
+
+// SplitY cuts r at y, measured from its top edge and kept inside it, into
+// the parts above and below.
+func (r Rect) SplitY(y float32) (top, bottom Rect) {
+	height := max(0, r.Size.Y)
+	y = max(0, min(y, height))
+	return Rect{r.Pos, Vec2f32{r.Size.X, y}},
+		Rect{Vec2f32{r.Pos.X, r.Pos.Y + y}, Vec2f32{r.Size.X, height - y}}
+}
@@ -0,0 +1,178 @@
+package math
+
+import "testing"
+
+func TestRectContains(t *testing.T) {
+	r := NewRect(10, 20, 30, 40)
+	tests := []struct {
+		name string
+		rect Rect
+		p    Vec2f32
+		want bool
+	}{
+		{"inside", r, Vec2f32{20, 30}, true},
+		{"top-left corner", r, Vec2f32{10, 20}, true},
+		{"bottom-right corner", r, Vec2f32{40, 60}, true},
+		{"left of", r, Vec2f32{9, 30}, false},
+		{"below", r, Vec2f32{20, 61}, false},
+		{"empty rect at the point", NewRect(5, 5, 0, 0), Vec2f32{5, 5}, true},
+		{"negative size", NewRect(10, 10, -5, -5), Vec2f32{7, 7}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rect.Contains(tt.p); got != tt.want {
+				t.Errorf("%v.Contains(%v) = %v, want %v", tt.rect, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRectContainsRect(t *testing.T) {
+	r := NewRect(0, 0, 100, 100)
+	tests := []struct {
+		name string
+		rect Rect
+		o    Rect
+		want bool
+	}{
+		{"inside", r, NewRect(10, 10, 20, 20), true},
+		{"itself", r, r, true},
+		{"overlapping", r, NewRect(90, 90, 20, 20), false},
+		{"outside", r, NewRect(200, 0, 10, 10), false},
+		{"empty inside", r, NewRect(50, 50, 0, 0), true},
+		{"around it", NewRect(10, 10, 10, 10), r, false},
+		{"in a rect of negative size", NewRect(100, 100, -100, -100), NewRect(10, 10, 10, 10), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rect.ContainsRect(tt.o); got != tt.want {
+				t.Errorf("%v.ContainsRect(%v) = %v, want %v", tt.rect, tt.o, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRectIntersect(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Rect
+		want Rect
+	}{
+		{"overlapping", NewRect(0, 0, 10, 10), NewRect(5, 5, 10, 10), NewRect(5, 5, 5, 5)},
+		{"nested", NewRect(0, 0, 10, 10), NewRect(2, 3, 4, 5), NewRect(2, 3, 4, 5)},
+		{"touching", NewRect(0, 0, 10, 10), NewRect(10, 0, 10, 10), NewRect(10, 0, 0, 10)},
+		{"apart", NewRect(0, 0, 10, 10), NewRect(20, 20, 5, 5), NewRect(20, 20, 0, 0)},
+		{"with an empty rect", NewRect(0, 0, 10, 10), NewRect(5, 5, 0, 0), NewRect(5, 5, 0, 0)},
+		{"with a negative size", NewRect(0, 0, 10, 10), NewRect(8, 8, -4, -4), NewRect(8, 8, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.a.Intersect(tt.b)
+			if got != tt.want {
+				t.Errorf("%v.Intersect(%v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if !got.IsEmpty() && got != tt.b.Intersect(tt.a) {
+				t.Errorf("Intersect is not symmetric for %v and %v", tt.a, tt.b)
+			}
+		})
+	}
+}
+
+func TestRectUnion(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Rect
+		want Rect
+	}{
+		{"overlapping", NewRect(0, 0, 10, 10), NewRect(5, 5, 10, 10), NewRect(0, 0, 15, 15)},
+		{"apart", NewRect(0, 0, 10, 10), NewRect(20, 30, 5, 5), NewRect(0, 0, 25, 35)},
+		{"nested", NewRect(0, 0, 10, 10), NewRect(2, 2, 2, 2), NewRect(0, 0, 10, 10)},
+		{"empty first", NewRect(100, 100, 0, 0), NewRect(0, 0, 10, 10), NewRect(0, 0, 10, 10)},
+		{"empty second", NewRect(0, 0, 10, 10), NewRect(-50, -50, 0, 5), NewRect(0, 0, 10, 10)},
+		{"negative size", NewRect(0, 0, 10, 10), NewRect(50, 50, -5, -5), NewRect(0, 0, 10, 10)},
+		{"both empty", NewRect(1, 1, 0, 0), NewRect(2, 2, 0, 0), NewRect(2, 2, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Union(tt.b); got != tt.want {
+				t.Errorf("%v.Union(%v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRectInset(t *testing.T) {
+	tests := []struct {
+		name                     string
+		rect                     Rect
+		left, top, right, bottom float32
+		want                     Rect
+	}{
+		{"even", NewRect(0, 0, 100, 50), 10, 10, 10, 10, NewRect(10, 10, 80, 30)},
+		{"uneven", NewRect(0, 0, 100, 50), 1, 2, 3, 4, NewRect(1, 2, 96, 44)},
+		{"outset", NewRect(10, 10, 10, 10), -5, -5, -5, -5, NewRect(5, 5, 20, 20)},
+		{"crossing meets in the middle", NewRect(0, 0, 10, 10), 10, 0, 10, 0, NewRect(5, 0, 0, 10)},
+		{"crossing unevenly", NewRect(0, 0, 10, 10), 0, 15, 0, 5, NewRect(0, 7.5, 10, 0)},
+		{"empty", NewRect(5, 5, 0, 0), 1, 1, 1, 1, NewRect(5, 5, 0, 0)},
+		{"negative size, no inset", NewRect(10, 10, -4, -6), 0, 0, 0, 0, NewRect(10, 10, 0, 0)},
+		{"negative size", NewRect(10, 10, -4, -4), 1, 1, 1, 1, NewRect(8, 8, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rect.Inset(tt.left, tt.top, tt.right, tt.bottom); got != tt.want {
+				t.Errorf("%v.Inset(%v, %v, %v, %v) = %v, want %v",
+					tt.rect, tt.left, tt.top, tt.right, tt.bottom, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRectSplit(t *testing.T) {
+	tests := []struct {
+		name       string
+		rect       Rect
+		at         float32
+		wantLeft   Rect
+		wantRight  Rect
+		wantTop    Rect
+		wantBottom Rect
+	}{
+		{
+			name: "inside", rect: NewRect(10, 20, 100, 50), at: 30,
+			wantLeft: NewRect(10, 20, 30, 50), wantRight: NewRect(40, 20, 70, 50),
+			wantTop: NewRect(10, 20, 100, 30), wantBottom: NewRect(10, 50, 100, 20),
+		},
+		{
+			name: "before the start", rect: NewRect(0, 0, 10, 10), at: -5,
+			wantLeft: NewRect(0, 0, 0, 10), wantRight: NewRect(0, 0, 10, 10),
+			wantTop: NewRect(0, 0, 10, 0), wantBottom: NewRect(0, 0, 10, 10),
+		},
+		{
+			name: "past the end", rect: NewRect(0, 0, 10, 10), at: 50,
+			wantLeft: NewRect(0, 0, 10, 10), wantRight: NewRect(10, 0, 0, 10),
+			wantTop: NewRect(0, 0, 10, 10), wantBottom: NewRect(0, 10, 10, 0),
+		},
+		{
+			name: "empty", rect: NewRect(5, 5, 0, 0), at: 1,
+			wantLeft: NewRect(5, 5, 0, 0), wantRight: NewRect(5, 5, 0, 0),
+			wantTop: NewRect(5, 5, 0, 0), wantBottom: NewRect(5, 5, 0, 0),
+		},
+		{
+			name: "negative size", rect: NewRect(5, 5, -10, 10), at: 3,
+			wantLeft: NewRect(5, 5, 0, 10), wantRight: NewRect(5, 5, 0, 10),
+			wantTop: NewRect(5, 5, -10, 3), wantBottom: NewRect(5, 8, -10, 7),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right := tt.rect.SplitX(tt.at)
+			if left != tt.wantLeft || right != tt.wantRight {
+				t.Errorf("%v.SplitX(%v) = %v, %v, want %v, %v", tt.rect, tt.at, left, right, tt.wantLeft, tt.wantRight)
+			}
+			top, bottom := tt.rect.SplitY(tt.at)
+			if top != tt.wantTop || bottom != tt.wantBottom {
+				t.Errorf("%v.SplitY(%v) = %v, %v, want %v, %v", tt.rect, tt.at, top, bottom, tt.wantTop, tt.wantBottom)
+			}
+		})
+	}
+}
@@ -196,6 +196,53 @@ func (v *Vec2f32) ToVec2() Vec2 {
 	return Vec2{float64(v.X), float64(v.Y)}
 }
 
+// The helpers below return a new vector and leave v as it is.
+
+// Plus returns v + u.
+func (v Vec2f32) Plus(u Vec2f32) Vec2f32 {
+	return Vec2f32{v.X + u.X, v.Y + u.Y}
+}
+
+// Minus returns v - u.
+func (v Vec2f32) Minus(u Vec2f32) Vec2f32 {
+	return Vec2f32{v.X - u.X, v.Y - u.Y}
+}
+
+// Times returns v scaled by s.
+func (v Vec2f32) Times(s float32) Vec2f32 {
+	return Vec2f32{v.X * s, v.Y * s}
+}
+
+// Distance returns how far u is from v.
+func (v Vec2f32) Distance(u Vec2f32) float32 {
+	return float32(math.Hypot(float64(u.X-v.X), float64(u.Y-v.Y)))
+}
+
+// Lerp returns the point a fraction t of the way from v to u.
+func (v Vec2f32) Lerp(u Vec2f32, t float32) Vec2f32 {
+	return Vec2f32{v.X + (u.X-v.X)*t, v.Y + (u.Y-v.Y)*t}
+}
+
+// Min returns the smaller of each coordinate of v and u.
+func (v Vec2f32) Min(u Vec2f32) Vec2f32 {
+	return Vec2f32{min(v.X, u.X), min(v.Y, u.Y)}
+}
+
+// Max returns the larger of each coordinate of v and u.
+func (v Vec2f32) Max(u Vec2f32) Vec2f32 {
+	return Vec2f32{max(v.X, u.X), max(v.Y, u.Y)}
+}
+
+// Clamp limits each coordinate of v to the range between lo and hi.
+func (v Vec2f32) Clamp(lo, hi Vec2f32) Vec2f32 {
+	return v.Max(lo).Min(hi)
+}
+
+// Floor rounds each coordinate down, to snap a point to the pixel grid.
+func (v Vec2f32) Floor() Vec2f32 {
+	return Vec2f32{float32(math.Floor(float64(v.X))), float32(math.Floor(float64(v.Y)))}
+}
+
 type Vec3f32 struct{ X, Y, Z float32 }
 
 func NewVec3f32(x, y, z float32) *Vec3f32 { return &Vec3f32{x, y, z} }
@@ -0,0 +1,158 @@
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVec2f32Mutating(t *testing.T) {
+	u := Vec2f32{1, -2}
+	tests := []struct {
+		name string
+		op   func(v *Vec2f32) *Vec2f32
+		want Vec2f32
+	}{
+		{"add", func(v *Vec2f32) *Vec2f32 { return v.Add(u) }, Vec2f32{4, 2}},
+		{"sub", func(v *Vec2f32) *Vec2f32 { return v.Sub(u) }, Vec2f32{2, 6}},
+		{"scale", func(v *Vec2f32) *Vec2f32 { return v.Scale(-2) }, Vec2f32{-6, -8}},
+		{"normalize", func(v *Vec2f32) *Vec2f32 { return v.Normalize() }, Vec2f32{0.6, 0.8}},
+		{"chained", func(v *Vec2f32) *Vec2f32 { return v.Add(u).Sub(Vec2f32{3, 4}).Scale(3) }, Vec2f32{3, -6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := Vec2f32{3, 4}
+			got := tt.op(&v)
+			if got != &v {
+				t.Errorf("returned %p, want the receiver %p", got, &v)
+			}
+			if !nearVec(v, tt.want) {
+				t.Errorf("v = %v, want %v", v, tt.want)
+			}
+		})
+	}
+
+	zero := Vec2f32{}
+	if got := *zero.Normalize(); got != (Vec2f32{}) {
+		t.Errorf("normalized zero = %v", got)
+	}
+	v := Vec2f32{3, 4}
+	clone := v.Clone()
+	clone.Add(u)
+	if v != (Vec2f32{3, 4}) || *clone != (Vec2f32{4, 2}) {
+		t.Errorf("after adding to a clone, v = %v and the clone = %v", v, *clone)
+	}
+	if got := v.Dot(u); got != -5 {
+		t.Errorf("Dot = %v, want -5", got)
+	}
+	if got := v.Norm(); got != 5 {
+		t.Errorf("Norm = %v, want 5", got)
+	}
+}
+
+func TestVec2f32Arithmetic(t *testing.T) {
+	v, u := Vec2f32{3, 4}, Vec2f32{1, -2}
+	tests := []struct {
+		name string
+		got  Vec2f32
+		want Vec2f32
+	}{
+		{"plus", v.Plus(u), Vec2f32{4, 2}},
+		{"minus", v.Minus(u), Vec2f32{2, 6}},
+		{"times", v.Times(-2), Vec2f32{-6, -8}},
+		{"chained", v.Plus(u).Minus(v).Times(3), Vec2f32{3, -6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !nearVec(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+	// The receiver is left as it is.
+	if v != (Vec2f32{3, 4}) {
+		t.Errorf("v changed to %v", v)
+	}
+	if got := u.Distance(v); got != float32(math.Sqrt(40)) {
+		t.Errorf("Distance = %v, want √40", got)
+	}
+}
+
+func TestVec2f32Lerp(t *testing.T) {
+	a, b := Vec2f32{0, 10}, Vec2f32{10, -10}
+	tests := []struct {
+		t    float32
+		want Vec2f32
+	}{
+		{0, a},
+		{1, b},
+		{0.5, Vec2f32{5, 0}},
+		{0.25, Vec2f32{2.5, 5}},
+		{-1, Vec2f32{-10, 30}},
+		{2, Vec2f32{20, -30}},
+	}
+	for _, tt := range tests {
+		if got := a.Lerp(b, tt.t); !nearVec(got, tt.want) {
+			t.Errorf("%v.Lerp(%v, %v) = %v, want %v", a, b, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestVec2f32MinMax(t *testing.T) {
+	tests := []struct {
+		name     string
+		v, u     Vec2f32
+		min, max Vec2f32
+	}{
+		{"mixed", Vec2f32{1, 5}, Vec2f32{3, 2}, Vec2f32{1, 2}, Vec2f32{3, 5}},
+		{"equal", Vec2f32{2, 2}, Vec2f32{2, 2}, Vec2f32{2, 2}, Vec2f32{2, 2}},
+		{"negative", Vec2f32{-1, -5}, Vec2f32{-3, 0}, Vec2f32{-3, -5}, Vec2f32{-1, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.Min(tt.u); got != tt.min {
+				t.Errorf("%v.Min(%v) = %v, want %v", tt.v, tt.u, got, tt.min)
+			}
+			if got := tt.v.Max(tt.u); got != tt.max {
+				t.Errorf("%v.Max(%v) = %v, want %v", tt.v, tt.u, got, tt.max)
+			}
+		})
+	}
+}
+
+func TestVec2f32Clamp(t *testing.T) {
+	lo, hi := Vec2f32{0, 10}, Vec2f32{100, 20}
+	tests := []struct {
+		name string
+		v    Vec2f32
+		want Vec2f32
+	}{
+		{"inside", Vec2f32{50, 15}, Vec2f32{50, 15}},
+		{"below both", Vec2f32{-5, 0}, Vec2f32{0, 10}},
+		{"above both", Vec2f32{200, 30}, Vec2f32{100, 20}},
+		{"one of each", Vec2f32{-5, 30}, Vec2f32{0, 20}},
+		{"on the edges", Vec2f32{0, 20}, Vec2f32{0, 20}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.Clamp(lo, hi); got != tt.want {
+				t.Errorf("%v.Clamp(%v, %v) = %v, want %v", tt.v, lo, hi, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVec2f32Floor(t *testing.T) {
+	tests := []struct {
+		v, want Vec2f32
+	}{
+		{Vec2f32{1.7, 2.2}, Vec2f32{1, 2}},
+		{Vec2f32{3, 4}, Vec2f32{3, 4}},
+		{Vec2f32{-0.5, -1.5}, Vec2f32{-1, -2}},
+		{Vec2f32{0.999, -0.001}, Vec2f32{0, -1}},
+	}
+	for _, tt := range tests {
+		if got := tt.v.Floor(); got != tt.want {
+			t.Errorf("%v.Floor() = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
@@ -35,6 +35,23 @@ typedef struct {
     float a;
 } ColorRGBA;
 
+/**
+ * @brief A corner of a triangle, with a factor on the opacity of the color
+ *        the triangle is drawn in (used to fade out anti-aliased edges).
+ */
+typedef struct {
+    Vec2 position;
+    float alpha;
+} MeshVertex;
+
+/**
+ * @brief A corner of a triangle with a color of its own (used for gradients).
+ */
+typedef struct {
+    Vec2 position;
+    ColorRGBA color;
+} ColorVertex;
+
 /**
  * @brief Represents a rectangle defined by its top-left position, width, and height.
  */
@@ -66,11 +83,14 @@ typedef struct {
 // =============================================================================
 
 // Configuration for the font texture atlas generated by stb_truetype
-#define FONT_ATLAS_WIDTH 512  ///< Width of the font texture atlas in pixels.
-#define FONT_ATLAS_HEIGHT 512 ///< Height of the font texture atlas in pixels.
+#define FONT_ATLAS_WIDTH 1024  ///< Width of the font texture atlas in pixels.
+#define FONT_ATLAS_HEIGHT 1024 ///< Height of the font texture atlas in pixels.
 #define FONT_FIRST_CHAR 32    ///< First ASCII character code included in the atlas (space).
 #define FONT_NUM_CHARS 95     ///< Number of consecutive characters included (ASCII 32-126).
+#define FONT_RANGE_COUNT 5    ///< Codepoint ranges packed into the atlas: ASCII, Hebrew, Arabic and Arabic presentation forms A/B.
+#define FONT_TOTAL_CHARS (FONT_NUM_CHARS + 112 + 256 + 176 + 144) ///< Sum of the sizes of all packed ranges.
 #define ROUNDED_RECT_CORNER_SEGMENTS 64 ///< Number of segments for rounded corners.
+#define MAX_CLIP_MASKS 32 ///< Depth of the clip mask stack; deeper masks are ignored.
 #ifndef M_PI
 #define M_PI 3.14159265358979323846 ///< Value of pi for circle calculations (if needed).
 #endif
@@ -81,7 +101,8 @@ typedef struct {
  */
 typedef struct FontData {
     unsigned char* ttf_buffer; ///< Pointer to the loaded TTF file data in memory.
-    stbtt_packedchar char_data[FONT_NUM_CHARS]; ///< Packed character data from stb_truetype.
+    stbtt_packedchar char_data[FONT_TOTAL_CHARS]; ///< Packed character data from stb_truetype, range after range.
+    int num_ranges;            ///< Number of ranges actually packed (1 = ASCII only, when the atlas is too small).
     GLuint texture_id;         ///< OpenGL texture ID for the font atlas.
     float font_height_pixels;  ///< The requested font height in pixels during loading.
     // Cached metrics for performance (calculated during load_font)
@@ -95,6 +116,15 @@ typedef struct FontData {
 // Renderer Context (Opaque-like, details needed for Go CGO)
 // =============================================================================
 
+/**
+ * @brief A convex shape drawing is clipped to, kept so that it can be taken
+ *        out of the stencil buffer again when it is popped.
+ */
+typedef struct ClipMask {
+    Vec2* points; ///< The shape's outline in screen coordinates (owned).
+    int count;    ///< The number of points.
+} ClipMask;
+
 /**
  * @brief Holds the internal state of the renderer, primarily the GLFW window.
  *        NOTE: Kept non-opaque for easier CGO interop in the user's Go code.
@@ -108,6 +138,9 @@ typedef struct Renderer {
     float fps;             ///< Current frames per second (FPS).
     float delta_time;     ///< Time elapsed since the last frame in seconds.
     float last_frame_time; ///< Timestamp of the last frame in seconds.
+    Vec2 scroll_delta;     ///< Mouse wheel movement since the last take_scroll_delta call.
+    ClipMask clip_masks[MAX_CLIP_MASKS]; ///< The clip mask stack, outermost first.
+    int clip_mask_depth;   ///< The number of masks pushed, including any past MAX_CLIP_MASKS.
 } Renderer;
 
 
@@ -226,6 +259,54 @@ void draw_circle_filled_outline(void* renderer_ptr, Circle circle, ColorRGBA fil
  */
 void draw_line_thick(void* renderer_ptr, Line line, ColorRGBA color, float thickness);
 
+// --- Clipping ---
+/**
+ * @brief Restricts drawing to a rectangle until clear_clip_rect is called.
+ * @param renderer_ptr Renderer context.
+ * @param rect The clip rectangle in screen coordinates.
+ */
+void set_clip_rect(void* renderer_ptr, Rect rect);
+
+/**
+ * @brief Removes the clip rectangle set by set_clip_rect.
+ * @param renderer_ptr Renderer context.
+ */
+void clear_clip_rect(void* renderer_ptr);
+
+/**
+ * @brief Pushes a convex shape onto the clip mask stack: until it is popped,
+ *        only what is inside it and every mask under it is drawn. Masks are
+ *        kept in the stencil buffer, so unlike clip rectangles they can have
+ *        rounded corners or be turned.
+ * @param renderer_ptr Renderer context.
+ * @param points The shape's outline in screen coordinates, in order.
+ * @param count The number of points (at least 3).
+ */
+void push_clip_mask(void* renderer_ptr, const Vec2* points, int count);
+
+/**
+ * @brief Pops the mask pushed last by push_clip_mask.
+ * @param renderer_ptr Renderer context.
+ */
+void pop_clip_mask(void* renderer_ptr);
+
+// --- Transforms ---
+/**
+ * @brief Transforms everything drawn until clear_transform is called by a
+ *        2D affine matrix, mapping (x, y) to
+ *        (m[0]*x + m[1]*y + m[2], m[3]*x + m[4]*y + m[5]).
+ * @note Clip rectangles are not transformed; they stay in screen coordinates.
+ * @param renderer_ptr Renderer context.
+ * @param m The top two rows of the matrix, row by row.
+ */
+void set_transform(void* renderer_ptr, const float m[6]);
+
+/**
+ * @brief Removes the transform set by set_transform.
+ * @param renderer_ptr Renderer context.
+ */
+void clear_transform(void* renderer_ptr);
+
 /**
  * @brief Draws a dashed line.
  * @param renderer_ptr Renderer context.
@@ -246,6 +327,48 @@ void draw_line_dashed(void* renderer_ptr, Line line, ColorRGBA color, float dash
  */
 void draw_line_dotted(void* renderer_ptr, Line line, ColorRGBA color, float dot_radius, float gap_factor);
 
+/**
+ * @brief Draws connected line segments through points, with round joins.
+ * @param renderer_ptr Renderer context.
+ * @param points The points to connect, in order.
+ * @param count The number of points.
+ * @param color The line color.
+ * @param thickness The thickness of the line in pixels.
+ */
+void draw_polyline(void* renderer_ptr, const Vec2* points, int count, ColorRGBA color, float thickness);
+
+// --- Polygons ---
+/**
+ * @brief Fills a polygon as a triangle fan around its first point.
+ * @note The result is only correct for convex polygons (or polygons every
+ *       point of which can be seen from the first one).
+ * @param renderer_ptr Renderer context.
+ * @param points The polygon's points, in order.
+ * @param count The number of points (at least 3).
+ * @param color The fill color.
+ */
+void draw_polygon_filled(void* renderer_ptr, const Vec2* points, int count, ColorRGBA color);
+
+// --- Meshes ---
+/**
+ * @brief Draws a list of triangles, three vertices each, in one color whose
+ *        opacity each vertex scales by its alpha.
+ * @param renderer_ptr Renderer context.
+ * @param vertices The triangles' vertices.
+ * @param count The number of vertices (a multiple of 3).
+ * @param color The color of the triangles.
+ */
+void draw_triangles(void* renderer_ptr, const MeshVertex* vertices, int count, ColorRGBA color);
+
+/**
+ * @brief Draws a list of triangles, three vertices each, blending the
+ *        vertices' colors across each triangle.
+ * @param renderer_ptr Renderer context.
+ * @param vertices The triangles' vertices.
+ * @param count The number of vertices (a multiple of 3).
+ */
+void draw_colored_triangles(void* renderer_ptr, const ColorVertex* vertices, int count);
+
 
 // =============================================================================
 // Font Loading and Text Rendering
@@ -270,7 +393,7 @@ void destroy_font(FontData* font_data);
  * @brief Draws text on the screen using a loaded font.
  * @param renderer_ptr Renderer context.
  * @param font_data Pointer to the loaded FontData for the desired font.
- * @param text The null-terminated string to draw.
+ * @param text The null-terminated UTF-8 string to draw, already in visual order and shaped.
  * @param pos The top-left position where the text rendering should begin (baseline adjusted internally).
  * @param color The color of the text.
  */
@@ -279,7 +402,7 @@ void draw_text(void* renderer_ptr, FontData* font_data, const char* text, Vec2 p
 /**
  * @brief Calculates the horizontal width of a given string if rendered with the specified font.
  * @param font_data Pointer to the loaded FontData for the desired font.
- * @param text The null-terminated string to measure.
+ * @param text The null-terminated UTF-8 string to measure.
  * @return The calculated width in pixels.
  */
 float calculate_text_width(FontData* font_data, const char* text);
@@ -321,6 +444,16 @@ void free_texture(GLuint texture_id);
  */
 void draw_texture(void* renderer_ptr, GLuint texture_id, Rect rect, ColorRGBA color);
 
+/**
+ * @brief Draws a sub-region of a texture (e.g. a sprite from an atlas page).
+ * @param renderer_ptr Renderer context.
+ * @param texture_id The OpenGL texture ID to sample from.
+ * @param rect The rectangle where the region should be drawn (position and size).
+ * @param uv The region in normalized texture coordinates (position = u0/v0, width/height = extent).
+ * @param color The color to tint the texture (RGBA).
+ */
+void draw_texture_region(void* renderer_ptr, GLuint texture_id, Rect rect, Rect uv, ColorRGBA color);
+
 // =============================================================================
 // Utilities / Getters
 // =============================================================================
@@ -347,6 +480,13 @@ Vec2 get_window_size(void* renderer_ptr);
  */
 Vec2 get_cursor_pos(void* renderer_ptr);
 
+/**
+ * @brief Returns the mouse wheel movement since the previous call and resets it.
+ * @param renderer_ptr Renderer context.
+ * @return A Vec2 with the horizontal (x) and vertical (y) scroll offsets; positive y scrolls up.
+ */
+Vec2 take_scroll_delta(void* renderer_ptr);
+
 /**
  * @brief Checks if a specific mouse button is currently pressed.
  * @param renderer_ptr Renderer context.
@@ -363,6 +503,14 @@ int is_mouse_button_pressed(void* renderer_ptr, int button);
  */
 int is_mouse_button_released(void* renderer_ptr, int button);
 
+/**
+ * @brief Checks if a keyboard key is currently held down.
+ * @param renderer_ptr Renderer context.
+ * @param key The key to check (e.g., GLFW_KEY_SPACE).
+ * @return Non-zero if the key is pressed, 0 otherwise.
+ */
+int is_key_pressed(void* renderer_ptr, int key);
+
 #ifdef __cplusplus
 } // extern "C"
 #endif
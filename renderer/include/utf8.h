@@ -0,0 +1,44 @@
+#ifndef UTF8_H
+#define UTF8_H
+
+// Decodes one UTF-8 sequence from a NUL-terminated string and advances *p
+// past it. Overlong forms, surrogates, code points above U+10FFFF and
+// truncated sequences decode as U+FFFD. A bad sequence is consumed up to the
+// first byte that cannot continue it, so it yields a single U+FFFD and the
+// terminating NUL is never skipped.
+static int decode_utf8(const char** p) {
+    const unsigned char* s = (const unsigned char*)*p;
+    // Allowed range of the second byte; later bytes are always 0x80..0xBF.
+    unsigned char lo = 0x80, hi = 0xBF;
+    int cp, len;
+    if (s[0] < 0x80) {
+        *p += 1;
+        return s[0];
+    } else if (s[0] >= 0xC2 && s[0] <= 0xDF) {
+        cp = s[0] & 0x1F; len = 2;
+    } else if (s[0] >= 0xE0 && s[0] <= 0xEF) {
+        cp = s[0] & 0x0F; len = 3;
+        if (s[0] == 0xE0) lo = 0xA0; // overlong
+        if (s[0] == 0xED) hi = 0x9F; // surrogates
+    } else if (s[0] >= 0xF0 && s[0] <= 0xF4) {
+        cp = s[0] & 0x07; len = 4;
+        if (s[0] == 0xF0) lo = 0x90; // overlong
+        if (s[0] == 0xF4) hi = 0x8F; // above U+10FFFF
+    } else {
+        *p += 1;
+        return 0xFFFD;
+    }
+    for (int i = 1; i < len; ++i) {
+        if (s[i] < lo || s[i] > hi) {
+            *p += i;
+            return 0xFFFD;
+        }
+        lo = 0x80;
+        hi = 0xBF;
+        cp = (cp << 6) | (s[i] & 0x3F);
+    }
+    *p += len;
+    return cp;
+}
+
+#endif // UTF8_H
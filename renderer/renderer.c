@@ -5,6 +5,7 @@
 #include <stdarg.h> // Needed for va_list in dprintf
 
 #include "include/renderer.h"
+#include "include/utf8.h"
 
 #include "external/glad/glad.h"  // Include GLAD for OpenGL function loading
 #include "external/glfw/glfw3.h" // Include GLFW for window management
@@ -58,6 +59,16 @@ void framebuffer_size_callback(GLFWwindow* window, int width, int height) {
     dprintf("Window resized to %d x %d. Viewport and Ortho updated.\n", width, height);
 }
 
+// --- GLFW Scroll Callback ---
+// Accumulates wheel movement until the application takes it once per frame
+void scroll_callback(GLFWwindow* window, double xoffset, double yoffset) {
+    Renderer* ctx = (Renderer*)glfwGetWindowUserPointer(window);
+    if (ctx) {
+        ctx->scroll_delta.x += (float)xoffset;
+        ctx->scroll_delta.y += (float)yoffset;
+    }
+}
+
 // --- Renderer Creation ---
 void* create_renderer(int width, int height, const char* title) {
     if (!glfwInit()) {
@@ -69,6 +80,7 @@ void* create_renderer(int width, int height, const char* title) {
     // glfwWindowHint(GLFW_CONTEXT_VERSION_MAJOR, 3);
     // glfwWindowHint(GLFW_CONTEXT_VERSION_MINOR, 3);
     // glfwWindowHint(GLFW_OPENGL_PROFILE, GLFW_OPENGL_CORE_PROFILE); // Requires shader-based rendering
+    glfwWindowHint(GLFW_STENCIL_BITS, 8); // Clip masks are kept in the stencil buffer
 
     GLFWwindow* window = glfwCreateWindow(width, height, title, NULL, NULL);
     if (!window) {
@@ -106,12 +118,17 @@ void* create_renderer(int width, int height, const char* title) {
     renderer->window = window;
     renderer->current_width = width;  // Store initial size
     renderer->current_height = height;
+    renderer->scroll_delta.x = 0.0f;
+    renderer->scroll_delta.y = 0.0f;
+    memset(renderer->clip_masks, 0, sizeof(renderer->clip_masks));
+    renderer->clip_mask_depth = 0;
 
     // Store pointer to Renderer struct in GLFW window for access in callbacks
     glfwSetWindowUserPointer(window, renderer);
 
     // *** REGISTER THE RESIZE CALLBACK HERE ***
     glfwSetFramebufferSizeCallback(window, framebuffer_size_callback);
+    glfwSetScrollCallback(window, scroll_callback);
 
     // *** IMPORTANT: Call the callback ONCE manually to set initial state ***
     // This ensures viewport/projection are set correctly even if no resize happens
@@ -150,6 +167,9 @@ void destroy_renderer(void* renderer_ptr) {
         if (ctx->window) {
             glfwDestroyWindow(ctx->window);
         }
+        for (int i = 0; i < MAX_CLIP_MASKS; i++) {
+            free(ctx->clip_masks[i].points);
+        }
         free(ctx);
     }
     glfwTerminate(); // Terminate GLFW only after all windows are destroyed
@@ -163,7 +183,11 @@ void clear_screen(void* renderer_ptr, ColorRGBA color) {
 
     // dprintf("Clearing screen with color: %f, %f, %f, %f\n", color.r, color.g, color.b, color.a);
     glClearColor(color.r, color.g, color.b, color.a);
-    glClear(GL_COLOR_BUFFER_BIT); // Only need to clear color for 2D
+    // The stencil buffer holds the clip masks, which start each frame empty
+    glClearStencil(0);
+    glClear(GL_COLOR_BUFFER_BIT | GL_STENCIL_BUFFER_BIT);
+    glDisable(GL_STENCIL_TEST);
+    ctx->clip_mask_depth = 0;
 }
 
 void present_screen(void* renderer_ptr) {
@@ -477,6 +501,108 @@ void draw_line_thick(void* renderer_ptr, Line line, ColorRGBA color, float thick
     glEnd();
 }
 
+// Restricts drawing to a screen rectangle with the scissor test
+void set_clip_rect(void* renderer_ptr, Rect rect) {
+    Renderer* ctx = (Renderer*)renderer_ptr;
+    if (!ctx) return;
+    // glScissor counts from the bottom-left corner of the framebuffer
+    GLint y = (GLint)(ctx->current_height - rect.position.y - rect.height);
+    glEnable(GL_SCISSOR_TEST);
+    glScissor((GLint)rect.position.x, y, (GLsizei)(rect.width > 0 ? rect.width : 0), (GLsizei)(rect.height > 0 ? rect.height : 0));
+}
+
+// Lets drawing cover the whole window again
+void clear_clip_rect(void* renderer_ptr) {
+    (void)renderer_ptr; // Mark as unused
+    glDisable(GL_SCISSOR_TEST);
+}
+
+// Adds 1 to (or, with GL_DECR, takes 1 from) the stencil inside a mask's
+// shape where it is ref, without drawing any color. The shape is in screen
+// coordinates and clip rectangles do not apply to it, so that the mask is
+// whole for every command drawn inside it.
+static void stencil_clip_mask(const ClipMask* mask, GLint ref, GLenum op) {
+    GLboolean scissor = glIsEnabled(GL_SCISSOR_TEST);
+    glDisable(GL_SCISSOR_TEST);
+    glMatrixMode(GL_MODELVIEW);
+    glPushMatrix();
+    glLoadIdentity();
+    glDisable(GL_TEXTURE_2D);
+    glEnable(GL_STENCIL_TEST);
+    glColorMask(GL_FALSE, GL_FALSE, GL_FALSE, GL_FALSE);
+    glStencilFunc(GL_EQUAL, ref, 0xFF);
+    glStencilOp(GL_KEEP, GL_KEEP, op);
+
+    glBegin(GL_TRIANGLE_FAN);
+    for (int i = 0; i < mask->count; i++) {
+        glVertex2f(mask->points[i].x, mask->points[i].y);
+    }
+    glEnd();
+
+    glColorMask(GL_TRUE, GL_TRUE, GL_TRUE, GL_TRUE);
+    glStencilOp(GL_KEEP, GL_KEEP, GL_KEEP);
+    glPopMatrix();
+    if (scissor) glEnable(GL_SCISSOR_TEST);
+}
+
+// Inside every mask on the stack the stencil equals the depth of the stack,
+// so drawing passes where it does.
+void push_clip_mask(void* renderer_ptr, const Vec2* points, int count) {
+    Renderer* ctx = (Renderer*)renderer_ptr;
+    if (!ctx || !points || count < 3) return;
+    int depth = ctx->clip_mask_depth++;
+    if (depth >= MAX_CLIP_MASKS) return;
+
+    ClipMask* mask = &ctx->clip_masks[depth];
+    Vec2* copy = (Vec2*)realloc(mask->points, sizeof(Vec2) * count);
+    if (!copy) {
+        mask->count = 0;
+        return;
+    }
+    memcpy(copy, points, sizeof(Vec2) * count);
+    mask->points = copy;
+    mask->count = count;
+
+    stencil_clip_mask(mask, depth, GL_INCR);
+    glStencilFunc(GL_EQUAL, depth + 1, 0xFF);
+}
+
+void pop_clip_mask(void* renderer_ptr) {
+    Renderer* ctx = (Renderer*)renderer_ptr;
+    if (!ctx || ctx->clip_mask_depth == 0) return;
+    int depth = --ctx->clip_mask_depth;
+    if (depth >= MAX_CLIP_MASKS) return;
+
+    stencil_clip_mask(&ctx->clip_masks[depth], depth + 1, GL_DECR);
+    if (depth == 0) {
+        glDisable(GL_STENCIL_TEST);
+    } else {
+        glStencilFunc(GL_EQUAL, depth, 0xFF);
+    }
+}
+
+// Draws everything after it through the affine transform m, given as the
+// first two rows of a 3x3 matrix: {a, b, tx, c, d, ty}
+void set_transform(void* renderer_ptr, const float m[6]) {
+    if (!renderer_ptr || !m) return;
+    // OpenGL matrices are 4x4 and column-major
+    const GLfloat gl[16] = {
+        m[0], m[3], 0.0f, 0.0f,
+        m[1], m[4], 0.0f, 0.0f,
+        0.0f, 0.0f, 1.0f, 0.0f,
+        m[2], m[5], 0.0f, 1.0f,
+    };
+    glMatrixMode(GL_MODELVIEW);
+    glLoadMatrixf(gl);
+}
+
+// Draws in plain screen coordinates again
+void clear_transform(void* renderer_ptr) {
+    (void)renderer_ptr; // Mark as unused
+    glMatrixMode(GL_MODELVIEW);
+    glLoadIdentity();
+}
+
 // Draw a dashed line (using GL_LINES)
 void draw_line_dashed(void* renderer_ptr, Line line, ColorRGBA color, float dash_length, float gap_length) {
     Renderer* ctx = (Renderer*)renderer_ptr;
@@ -545,6 +671,135 @@ void draw_line_dotted(void* renderer_ptr, Line line, ColorRGBA color, float dot_
     }
 }
 
+// Draw connected thick segments (using draw_line_thick), rounding the joins
+// with circles so that bends have no gaps
+void draw_polyline(void* renderer_ptr, const Vec2* points, int count, ColorRGBA color, float thickness) {
+    Renderer* ctx = (Renderer*)renderer_ptr;
+    if (!ctx || !ctx->window || !points || count < 2 || thickness <= 0.0f) return;
+
+    for (int i = 0; i + 1 < count; i++) {
+        Line segment = {points[i], points[i + 1]};
+        draw_line_thick(renderer_ptr, segment, color, thickness);
+    }
+    // Thin lines do not show the gaps at their joins.
+    if (thickness <= 2.0f) return;
+    for (int i = 1; i + 1 < count; i++) {
+        Circle join = {points[i], thickness / 2.0f};
+        draw_circle_filled(renderer_ptr, join, color);
+    }
+}
+
+// Fill a polygon (using GL_TRIANGLE_FAN around its first point)
+void draw_polygon_filled(void* renderer_ptr, const Vec2* points, int count, ColorRGBA color) {
+    Renderer* ctx = (Renderer*)renderer_ptr;
+    if (!ctx || !ctx->window || !points || count < 3) return;
+
+    glDisable(GL_TEXTURE_2D);
+    glColor4f(color.r, color.g, color.b, color.a);
+
+    glBegin(GL_TRIANGLE_FAN);
+    for (int i = 0; i < count; i++) {
+        glVertex2f(points[i].x, points[i].y);
+    }
+    glEnd();
+}
+
+// Draw a triangle list (using GL_TRIANGLES), fading each vertex by its alpha
+void draw_triangles(void* renderer_ptr, const MeshVertex* vertices, int count, ColorRGBA color) {
+    Renderer* ctx = (Renderer*)renderer_ptr;
+    if (!ctx || !ctx->window || !vertices || count < 3) return;
+
+    glDisable(GL_TEXTURE_2D);
+
+    glBegin(GL_TRIANGLES);
+    for (int i = 0; i + 2 < count; i += 3) {
+        for (int j = i; j < i + 3; j++) {
+            glColor4f(color.r, color.g, color.b, color.a * vertices[j].alpha);
+            glVertex2f(vertices[j].position.x, vertices[j].position.y);
+        }
+    }
+    glEnd();
+}
+
+// Draw a triangle list (using GL_TRIANGLES), colored per vertex
+void draw_colored_triangles(void* renderer_ptr, const ColorVertex* vertices, int count) {
+    Renderer* ctx = (Renderer*)renderer_ptr;
+    if (!ctx || !ctx->window || !vertices || count < 3) return;
+
+    glDisable(GL_TEXTURE_2D);
+    glShadeModel(GL_SMOOTH);
+
+    int n = count - count % 3;
+    glBegin(GL_TRIANGLES);
+    for (int i = 0; i < n; i++) {
+        const ColorVertex* v = &vertices[i];
+        glColor4f(v->color.r, v->color.g, v->color.b, v->color.a);
+        glVertex2f(v->position.x, v->position.y);
+    }
+    glEnd();
+}
+
+
+// --- Font Character Ranges ---
+typedef struct {
+    int first;
+    int count;
+} FontCharRange;
+
+// Packed in this order into FontData.char_data. Keep FONT_TOTAL_CHARS in sync.
+static const FontCharRange font_ranges[FONT_RANGE_COUNT] = {
+    {FONT_FIRST_CHAR, FONT_NUM_CHARS}, // ASCII
+    {0x0590, 112},                     // Hebrew
+    {0x0600, 256},                     // Arabic
+    {0xFB50, 176},                     // Arabic Presentation Forms-A (Persian/Urdu letters)
+    {0xFE70, 144},                     // Arabic Presentation Forms-B (contextual forms)
+};
+
+// Returns the index of codepoint in font_data->char_data, or -1 if it was not packed.
+static int font_glyph_index(const FontData* font_data, int codepoint) {
+    int offset = 0;
+    for (int i = 0; i < font_data->num_ranges; ++i) {
+        if (codepoint >= font_ranges[i].first && codepoint < font_ranges[i].first + font_ranges[i].count) {
+            return offset + codepoint - font_ranges[i].first;
+        }
+        offset += font_ranges[i].count;
+    }
+    return -1;
+}
+
+// Packs the character ranges into bitmap. Falls back to ASCII only if the
+// full set does not fit (very large font sizes). Returns the number of ranges packed.
+static int pack_font_ranges(FontData* font_data, unsigned char* bitmap, const unsigned char* ttf_buffer, float font_height_pixels) {
+    int counts[2] = {FONT_RANGE_COUNT, 1};
+    for (int attempt = 0; attempt < 2; ++attempt) {
+        int num_ranges = counts[attempt];
+        stbtt_pack_context pack_context;
+        if (!stbtt_PackBegin(&pack_context, bitmap, FONT_ATLAS_WIDTH, FONT_ATLAS_HEIGHT, 0, 1, NULL)) {
+            fprintf(stderr, "ERROR: Failed to initialize stbtt_pack_context\n");
+            return 0;
+        }
+        stbtt_PackSetOversampling(&pack_context, 1, 1); // No oversampling
+
+        stbtt_pack_range ranges[FONT_RANGE_COUNT];
+        int offset = 0;
+        for (int i = 0; i < num_ranges; ++i) {
+            memset(&ranges[i], 0, sizeof(ranges[i]));
+            ranges[i].font_size = font_height_pixels;
+            ranges[i].first_unicode_codepoint_in_range = font_ranges[i].first;
+            ranges[i].num_chars = font_ranges[i].count;
+            ranges[i].chardata_for_range = font_data->char_data + offset;
+            offset += font_ranges[i].count;
+        }
+        int ok = stbtt_PackFontRanges(&pack_context, ttf_buffer, 0, ranges, num_ranges);
+        stbtt_PackEnd(&pack_context);
+        if (ok) {
+            return num_ranges;
+        }
+        memset(bitmap, 0, FONT_ATLAS_WIDTH * FONT_ATLAS_HEIGHT);
+        dprintf("Font atlas too small for all ranges at %.1fpx, retrying with ASCII only\n", font_height_pixels);
+    }
+    return 0;
+}
 
 // --- Font Loading ---
 FontData* load_font(const char* font_path, float font_height_pixels) {
@@ -606,29 +861,15 @@ FontData* load_font(const char* font_path, float font_height_pixels) {
     }
 
     // Use stb_truetype to pack characters into the bitmap
-    stbtt_pack_context pack_context;
-    if (!stbtt_PackBegin(&pack_context, temp_bitmap, FONT_ATLAS_WIDTH, FONT_ATLAS_HEIGHT, 0, 1, NULL)) {
-        fprintf(stderr, "ERROR: Failed to initialize stbtt_pack_context\n");
-        free(temp_bitmap);
-        free(font_data->ttf_buffer);
-        free(font_data);
-        return NULL;
-    }
-
-    stbtt_PackSetOversampling(&pack_context, 1, 1); // No oversampling
-
-    // Pack the desired character range (ASCII 32-126)
-    if (!stbtt_PackFontRange(&pack_context, ttf_buffer, 0, font_height_pixels, FONT_FIRST_CHAR, FONT_NUM_CHARS, font_data->char_data)) {
+    font_data->num_ranges = pack_font_ranges(font_data, temp_bitmap, ttf_buffer, font_height_pixels);
+    if (font_data->num_ranges == 0) {
         fprintf(stderr, "ERROR: Failed to pack font range into atlas\n");
-        stbtt_PackEnd(&pack_context);
         free(temp_bitmap);
         free(font_data->ttf_buffer);
         free(font_data);
         return NULL;
     }
 
-    stbtt_PackEnd(&pack_context); // Finish packing
-
     // --- Create OpenGL Texture ---
     glGenTextures(1, &font_data->texture_id);
     glBindTexture(GL_TEXTURE_2D, font_data->texture_id);
@@ -704,14 +945,16 @@ void draw_text(void* renderer_ptr, FontData* font_data, const char* text, Vec2 p
     float current_y = pos.y + font_data->ascent;
 
     glBegin(GL_QUADS);
-    for (const char* p = text; *p; ++p) {
-        // Check if character is in the packed range
-        if (*p >= FONT_FIRST_CHAR && *p < FONT_FIRST_CHAR + FONT_NUM_CHARS) {
+    for (const char* p = text; *p;) {
+        int codepoint = decode_utf8(&p);
+        int index = font_glyph_index(font_data, codepoint);
+        // Check if character is in the packed ranges
+        if (index >= 0) {
             stbtt_aligned_quad quad;
             stbtt_GetPackedQuad(
                 font_data->char_data,
                 FONT_ATLAS_WIDTH, FONT_ATLAS_HEIGHT,
-                *p - FONT_FIRST_CHAR, // Character index
+                index,                // Character index
                 &current_x,           // Updated by function
                 &current_y,           // Updated by function (important for vertical alignment)
                 &quad,
@@ -724,13 +967,13 @@ void draw_text(void* renderer_ptr, FontData* font_data, const char* text, Vec2 p
             glTexCoord2f(quad.s1, quad.t1); glVertex2f(quad.x1, quad.y1);
             glTexCoord2f(quad.s1, quad.t0); glVertex2f(quad.x1, quad.y0);
         } else {
-            // Handle characters outside the range (e.g., space, tabs, unknown)
+            // Handle characters outside the range (e.g., tabs, unknown)
             // Get advance width for space if possible, otherwise estimate
             // Note: Getting advance requires stbtt_fontinfo, could be slow if done per char
             // For simplicity, use an estimate based on font height
-             if (*p == ' ') {
+             if (codepoint == ' ') {
                  current_x += font_data->font_height_pixels * 0.3f; // Approx space width
-             } else if (*p == '\t') {
+             } else if (codepoint == '\t') {
                  current_x += font_data->font_height_pixels * 0.3f * 4; // Approx tab width
              } else {
                  current_x += font_data->font_height_pixels * 0.5f; // Approx unknown char width
@@ -752,14 +995,16 @@ float calculate_text_width(FontData* font_data, const char* text) {
     // Use a dummy y value, it's not needed for width calculation with GetPackedQuad
     float dummy_y = 0.0f;
 
-    for (const char* p = text; *p; ++p) {
-        if (*p >= FONT_FIRST_CHAR && *p < FONT_FIRST_CHAR + FONT_NUM_CHARS) {
+    for (const char* p = text; *p;) {
+        int codepoint = decode_utf8(&p);
+        int index = font_glyph_index(font_data, codepoint);
+        if (index >= 0) {
             stbtt_aligned_quad quad;
             // We only care about how much current_x advances
             stbtt_GetPackedQuad(
                 font_data->char_data,
                 FONT_ATLAS_WIDTH, FONT_ATLAS_HEIGHT,
-                *p - FONT_FIRST_CHAR,
+                index,
                 &current_x, // This gets updated
                 &dummy_y,   // This also gets updated but we ignore it
                 &quad,
@@ -769,9 +1014,9 @@ float calculate_text_width(FontData* font_data, const char* text) {
             // So, the final value of current_x represents the total width.
         } else {
              // Estimate width for non-renderable characters
-             if (*p == ' ') {
+             if (codepoint == ' ') {
                  current_x += font_data->font_height_pixels * 0.3f;
-             } else if (*p == '\t') {
+             } else if (codepoint == '\t') {
                  current_x += font_data->font_height_pixels * 0.3f * 4;
              } else {
                  current_x += font_data->font_height_pixels * 0.5f;
@@ -898,6 +1143,30 @@ void draw_texture(void* renderer_ptr, GLuint texture_id, Rect rect, ColorRGBA co
     glDisable(GL_TEXTURE_2D);
 }
 
+void draw_texture_region(void* renderer_ptr, GLuint texture_id, Rect rect, Rect uv, ColorRGBA color) {
+    Renderer* ctx = (Renderer*)renderer_ptr;
+    if (!ctx || !ctx->window || texture_id == 0) return;
+
+    float u0 = uv.position.x;
+    float v0 = uv.position.y;
+    float u1 = uv.position.x + uv.width;
+    float v1 = uv.position.y + uv.height;
+
+    glEnable(GL_TEXTURE_2D);
+    glBindTexture(GL_TEXTURE_2D, texture_id);
+    glColor4f(color.r, color.g, color.b, color.a);
+
+    glBegin(GL_QUADS);
+    glTexCoord2f(u0, v0); glVertex2f(rect.position.x, rect.position.y); // Top-left
+    glTexCoord2f(u1, v0); glVertex2f(rect.position.x + rect.width, rect.position.y); // Top-right
+    glTexCoord2f(u1, v1); glVertex2f(rect.position.x + rect.width, rect.position.y + rect.height); // Bottom-right
+    glTexCoord2f(u0, v1); glVertex2f(rect.position.x, rect.position.y + rect.height); // Bottom-left
+    glEnd();
+
+    glBindTexture(GL_TEXTURE_2D, 0);
+    glDisable(GL_TEXTURE_2D);
+}
+
 float get_current_time(void* renderer_ptr) {
     Renderer* ctx = (Renderer*)renderer_ptr;
     if (!ctx || !ctx->window) return 0.0f;
@@ -916,6 +1185,16 @@ Vec2 get_cursor_pos(void* renderer_ptr) {
     return mouse_pos;
 }
 
+Vec2 take_scroll_delta(void* renderer_ptr) {
+    Renderer* ctx = (Renderer*)renderer_ptr;
+    Vec2 delta = {0.0f, 0.0f};
+    if (!ctx) return delta;
+    delta = ctx->scroll_delta;
+    ctx->scroll_delta.x = 0.0f;
+    ctx->scroll_delta.y = 0.0f;
+    return delta;
+}
+
 int is_mouse_button_pressed(void* renderer_ptr, int button) {
     Renderer* ctx = (Renderer*)renderer_ptr;
     if (!ctx || !ctx->window) return 0;
@@ -929,3 +1208,10 @@ int is_mouse_button_released(void* renderer_ptr, int button) {
     // Check mouse button state using GLFW
     return glfwGetMouseButton(ctx->window, button) == GLFW_RELEASE;
 }
+
+int is_key_pressed(void* renderer_ptr, int key) {
+    Renderer* ctx = (Renderer*)renderer_ptr;
+    if (!ctx || !ctx->window) return 0;
+    // Check keyboard key state using GLFW
+    return glfwGetKey(ctx->window, key) == GLFW_PRESS;
+}